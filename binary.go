@@ -0,0 +1,54 @@
+package und
+
+import (
+	"fmt"
+
+	"github.com/ngicks/und/option"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// u is encoded as a state tag byte, the value of [State],
+// followed by the value encoded by [option.MarshalBinaryValue] if u is defined.
+// Use [option.RegisterBinaryCodec] to choose how T is encoded.
+// The format is shared with option.Option[T], and is the one of github.com/ngicks/und/undcodec without its first 2 bytes.
+func (u Und[T]) MarshalBinary() ([]byte, error) {
+	if !u.IsDefined() {
+		return []byte{byte(u.State())}, nil
+	}
+	bin, err := option.MarshalBinaryValue(u.Value())
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(StateDefined)}, bin...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// data must be in the format [Und.MarshalBinary] produces,
+// otherwise UnmarshalBinary returns an error wrapping [option.ErrBinaryFormat].
+func (u *Und[T]) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty input", option.ErrBinaryFormat)
+	}
+	switch s := State(data[0]); s {
+	case StateUndefined, StateNull:
+		if len(data) != 1 {
+			return fmt.Errorf("%w: trailing bytes after state tag", option.ErrBinaryFormat)
+		}
+		if s == StateNull {
+			*u = Null[T]()
+		} else {
+			*u = Undefined[T]()
+		}
+		return nil
+	case StateDefined:
+		t, err := option.UnmarshalBinaryValue[T](data[1:])
+		if err != nil {
+			return err
+		}
+		*u = Defined(t)
+		return nil
+	}
+	return fmt.Errorf("%w: unknown tag %d", option.ErrBinaryFormat, data[0])
+}
@@ -0,0 +1,40 @@
+package und_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnd_Binary(t *testing.T) {
+	now := time.Now()
+	tBin, err := now.MarshalBinary()
+	assert.NilError(t, err)
+
+	for _, tc := range []struct {
+		u        und.Und[time.Time]
+		expected []byte
+	}{
+		{und.Undefined[time.Time](), []byte{1}},
+		{und.Null[time.Time](), []byte{2}},
+		{und.Defined(now), append([]byte{4}, tBin...)},
+	} {
+		bin, err := tc.u.MarshalBinary()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, tc.expected, bin)
+
+		var u und.Und[time.Time]
+		assert.NilError(t, u.UnmarshalBinary(bin))
+		assert.Assert(t, u.EqualFunc(tc.u, time.Time.Equal))
+	}
+
+	for _, data := range [][]byte{nil, {0}, {1, 0}, {2, 0}, {3}} {
+		var u und.Und[int]
+		err := u.UnmarshalBinary(data)
+		assert.Assert(t, errors.Is(err, option.ErrBinaryFormat), "data = %v, err = %v", data, err)
+	}
+}
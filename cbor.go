@@ -0,0 +1,35 @@
+package und
+
+import (
+	"encoding/json"
+
+	"github.com/ngicks/und/internal/transcode"
+)
+
+// MarshalCBOR implements the marshaler interface of github.com/fxamacker/cbor/v2.
+//
+// Undefined is encoded as the CBOR simple value undefined (0xf7), null as null,
+// and a defined value as its JSON representation transcoded into CBOR.
+// See [option.Option.MarshalCBOR] for details.
+func (u Und[T]) MarshalCBOR() ([]byte, error) {
+	if u.IsUndefined() {
+		return []byte{transcode.CBORUndefined}, nil
+	}
+	return u.opt.Value().MarshalCBOR()
+}
+
+// UnmarshalCBOR implements the unmarshaler interface of github.com/fxamacker/cbor/v2.
+//
+// The CBOR simple value undefined becomes undefined, and null becomes null.
+// Other data is transcoded into JSON, then decoded as UnmarshalJSON does.
+func (u *Und[T]) UnmarshalCBOR(data []byte) error {
+	if transcode.IsCBORUndefined(data) {
+		*u = Undefined[T]()
+		return nil
+	}
+	bin, err := transcode.CBORToJSON(nil, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bin, u)
+}
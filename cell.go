@@ -0,0 +1,100 @@
+package und
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Cell is a concurrency-safe container of Und[T] which notifies watchers of stores.
+//
+// The zero value is an empty Cell holding undefined Und[T].
+// A Cell must not be copied after first use.
+type Cell[T any] struct {
+	mu      sync.Mutex
+	v       Und[T]
+	changed chan struct{}
+}
+
+// NewCell returns a new Cell holding u.
+func NewCell[T any](u Und[T]) *Cell[T] {
+	return &Cell[T]{v: u}
+}
+
+// Load returns the value stored in c.
+func (c *Cell[T]) Load() Und[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.v
+}
+
+// Store stores u into c and notifies watchers.
+func (c *Cell[T]) Store(u Und[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(u)
+}
+
+// Swap stores u into c, notifies watchers and returns the previous value.
+func (c *Cell[T]) Swap(u Und[T]) (old Und[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old = c.v
+	c.store(u)
+	return old
+}
+
+// CompareAndSwap stores new into c only if the value stored in c is equal to old,
+// and reports whether new is stored.
+// Values are compared by [EqualDeep].
+func (c *Cell[T]) CompareAndSwap(old, new Und[T]) (swapped bool) {
+	return c.CompareAndSwapFunc(old, new, func(i, j Und[T]) bool { return EqualDeep(i, j) })
+}
+
+// CompareAndSwapFunc is like [Cell.CompareAndSwap] but compares values by cmp.
+func (c *Cell[T]) CompareAndSwapFunc(old, new Und[T], cmp func(i, j Und[T]) bool) (swapped bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !cmp(c.v, old) {
+		return false
+	}
+	c.store(new)
+	return true
+}
+
+func (c *Cell[T]) store(u Und[T]) {
+	c.v = u
+	if c.changed != nil {
+		close(c.changed)
+		c.changed = nil
+	}
+}
+
+// Watch returns an iterator over values stored in c.
+//
+// The iterator yields the current value first, then the latest value each time c is stored.
+// Values stored in quick succession may be coalesced; watchers always observe the latest one.
+// The iterator stops when ctx is done or the loop is broken.
+func (c *Cell[T]) Watch(ctx context.Context) iter.Seq[Und[T]] {
+	return func(yield func(Und[T]) bool) {
+		for {
+			c.mu.Lock()
+			v := c.v
+			if c.changed == nil {
+				c.changed = make(chan struct{})
+			}
+			changed := c.changed
+			c.mu.Unlock()
+
+			if ctx.Err() != nil || !yield(v) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package und_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestCell(t *testing.T) {
+	var c und.Cell[int]
+	assert.Assert(t, c.Load().IsUndefined())
+
+	c.Store(und.Null[int]())
+	assert.Assert(t, c.Load().IsNull())
+
+	old := c.Swap(und.Defined(1))
+	assert.Assert(t, old.IsNull())
+	assert.Assert(t, und.Equal(c.Load(), und.Defined(1)))
+
+	assert.Assert(t, !c.CompareAndSwap(und.Defined(2), und.Defined(3)))
+	assert.Assert(t, c.CompareAndSwap(und.Defined(1), und.Defined(3)))
+	assert.Assert(t, und.Equal(c.Load(), und.Defined(3)))
+
+	cs := und.NewCell(und.Defined([]int{1}))
+	assert.Assert(t, cs.CompareAndSwap(und.Defined([]int{1}), und.Null[[]int]()))
+	assert.Assert(t, cs.Load().IsNull())
+}
+
+func TestCell_Watch(t *testing.T) {
+	c := und.NewCell(und.Undefined[int]())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		observed []und.Und[int]
+		ready    = make(chan struct{})
+		next     = make(chan struct{})
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for u := range c.Watch(ctx) {
+			observed = append(observed, u)
+			if len(observed) == 1 {
+				close(ready)
+			} else {
+				next <- struct{}{}
+			}
+			if u.IsDefined() && u.Value() == 2 {
+				return
+			}
+		}
+	}()
+
+	<-ready
+	c.Store(und.Null[int]())
+	<-next
+	c.Store(und.Defined(2))
+	<-next
+	wg.Wait()
+
+	assert.Equal(t, len(observed), 3)
+	assert.Assert(t, observed[0].IsUndefined())
+	assert.Assert(t, observed[1].IsNull())
+	assert.Assert(t, und.Equal(observed[2], und.Defined(2)))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range c.Watch(ctx) {
+		}
+	}()
+	cancel()
+	<-done
+}
@@ -0,0 +1,205 @@
+package und
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+var (
+	// ErrNotColumn is returned by [Columns] if a field is not suitable to be extracted as a column.
+	ErrNotColumn = errors.New("not column")
+)
+
+// ColumnData is a column of a field extracted by [Columns].
+//
+// The layout follows Apache Arrow's one:
+// values are stored densely along with validity bitmaps in least-significant bit numbering.
+// Valid can be used as a validity (null) bitmap buffer of an Arrow array as it is,
+// and Values as its value buffer if V is a fixed-width primitive type.
+// Arrow has no notion of undefined; undefined rows are invalid, as are null rows.
+type ColumnData struct {
+	// Len is number of rows.
+	Len int
+	// Values is a []V, where V is the type of the field's value, e.g. V for und.Und[V].
+	// Values has the length of Len.
+	// Elements for rows that are not defined are zero value of V.
+	Values any
+	// Valid is a bitmap where bits are set for rows that are defined (or some, for option.Option[V]).
+	Valid []byte
+	// Null is a bitmap where bits are set for rows that are null (or none, for option.Option[V]).
+	// Rows whose bit is set in neither of Valid nor Null are undefined.
+	Null []byte
+}
+
+// IsValid reports whether i-th row is defined.
+func (c ColumnData) IsValid(i int) bool {
+	return bitmapGet(c.Valid, i)
+}
+
+// IsNull reports whether i-th row is null.
+func (c ColumnData) IsNull(i int) bool {
+	return bitmapGet(c.Null, i)
+}
+
+// State returns the state of i-th row.
+func (c ColumnData) State(i int) State {
+	switch {
+	case c.IsValid(i):
+		return StateDefined
+	case c.IsNull(i):
+		return StateNull
+	default:
+		return StateUndefined
+	}
+}
+
+func bitmapGet(b []byte, i int) bool {
+	if i < 0 || len(b) <= i/8 {
+		return false
+	}
+	return b[i/8]&(1<<(i%8)) != 0
+}
+
+func bitmapSet(b []byte, i int) {
+	b[i/8] |= 1 << (i % 8)
+}
+
+// Columns extracts fields of rows into column vectors.
+//
+// T must be a struct type or a pointer to a struct type.
+// A nil pointer row is treated as a row whose fields are all undefined.
+// fields are names of struct fields (not names in encoded forms, e.g. JSON).
+// If no fields are given, all exported und-typed fields are extracted.
+//
+// The fields must be one of und.Und[V], sliceund.Und[V] or option.Option[V],
+// or more precisely, types which implement [UndStater], or IsDefined, IsNull and IsUndefined (or IsNone),
+// and a Value method that returns V.
+// Elastic types can not be extracted since those have multiple values per row.
+// Otherwise Columns returns an error wrapping [ErrNotColumn].
+func Columns[T any](rows []T, fields ...string) (map[string]ColumnData, error) {
+	rt := reflect.TypeFor[T]()
+	isPointer := rt.Kind() == reflect.Pointer
+	if isPointer {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: T must be a struct or a pointer to a struct but is %s", validate.ErrNotStruct, rt)
+	}
+
+	if len(fields) == 0 {
+		for _, f := range reflect.VisibleFields(rt) {
+			if f.IsExported() && !f.Anonymous && isColumnType(f.Type) {
+				fields = append(fields, f.Name)
+			}
+		}
+	}
+
+	columns := make(map[string]ColumnData, len(fields))
+	for _, name := range fields {
+		f, ok := rt.FieldByName(name)
+		if !ok || !f.IsExported() {
+			return nil, fmt.Errorf("%w: %s has no exported field named %q", ErrNotColumn, rt, name)
+		}
+		if !isColumnType(f.Type) {
+			return nil, fmt.Errorf("%w: type of field %q, %s, is not an und type", ErrNotColumn, name, f.Type)
+		}
+
+		valueMethod, _ := f.Type.MethodByName("Value")
+		valueTy := valueMethod.Type.Out(0)
+		values := reflect.MakeSlice(reflect.SliceOf(valueTy), len(rows), len(rows))
+		c := ColumnData{
+			Len:   len(rows),
+			Valid: make([]byte, (len(rows)+7)/8),
+			Null:  make([]byte, (len(rows)+7)/8),
+		}
+		for i := range rows {
+			rv := reflect.ValueOf(&rows[i]).Elem()
+			if isPointer {
+				if rv.IsNil() {
+					continue
+				}
+				rv = rv.Elem()
+			}
+			fv, err := rv.FieldByIndexErr(f.Index)
+			if err != nil {
+				// nil embedded pointer
+				continue
+			}
+			switch s, _ := undreflect.StateOf(fv.Interface()); s {
+			case StateDefined:
+				bitmapSet(c.Valid, i)
+				values.Index(i).Set(fv.Method(valueMethod.Index).Call(nil)[0])
+			case StateNull:
+				bitmapSet(c.Null, i)
+			}
+		}
+		c.Values = values.Interface()
+		columns[name] = c
+	}
+	return columns, nil
+}
+
+func isColumnType(rt reflect.Type) bool {
+	return !rt.Implements(undreflect.ElasticLikeTy) && undreflect.HasState(rt) && undreflect.ValueType(rt) != nil
+}
+
+// FromColumn converts a column vector, values along with Arrow-style validity bitmaps, back into []Und[V].
+//
+// Rows whose bit is set in valid become defined.
+// Other rows become null if null is nil or the row's bit is set in null, undefined otherwise.
+// Passing nil null therefore converts the values and the validity bitmap of an Arrow array,
+// where invalid means null.
+//
+// FromColumn panics if valid or non-nil null is shorter than needed for len(values) rows.
+func FromColumn[V any](values []V, valid, null []byte) []Und[V] {
+	size := (len(values) + 7) / 8
+	if len(valid) < size || (null != nil && len(null) < size) {
+		panic(fmt.Sprintf("und.FromColumn: bitmap too short: %d rows, len(valid) = %d, len(null) = %d", len(values), len(valid), len(null)))
+	}
+	us := make([]Und[V], len(values))
+	for i, v := range values {
+		switch {
+		case bitmapGet(valid, i):
+			us[i] = Defined(v)
+		case null == nil || bitmapGet(null, i):
+			us[i] = Null[V]()
+		}
+	}
+	return us
+}
+
+// ToColumn converts us into a column vector, values along with Arrow-style validity bitmaps.
+// It is the inverse of [FromColumn] and the typed counterpart of [Columns] for a single field.
+//
+// values has the length of len(us); elements for rows that are not defined are zero value of V.
+// Bits of valid are set for defined rows, and bits of null for null rows.
+// values and valid can be used as buffers of an Arrow array as they are if V is a fixed-width primitive type.
+func ToColumn[V any](us []Und[V]) (values []V, valid, null []byte) {
+	values = make([]V, len(us))
+	valid = make([]byte, (len(us)+7)/8)
+	null = make([]byte, (len(us)+7)/8)
+	for i, u := range us {
+		switch {
+		case u.IsDefined():
+			values[i] = u.Value()
+			bitmapSet(valid, i)
+		case u.IsNull():
+			bitmapSet(null, i)
+		}
+	}
+	return values, valid, null
+}
+
+// Column converts c into []Und[V].
+// It returns an error wrapping [ErrNotColumn] if c's values are not []V.
+func Column[V any](c ColumnData) ([]Und[V], error) {
+	values, ok := c.Values.([]V)
+	if !ok {
+		return nil, fmt.Errorf("%w: values are %T, not %T", ErrNotColumn, c.Values, values)
+	}
+	return FromColumn(values, c.Valid, c.Null), nil
+}
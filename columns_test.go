@@ -0,0 +1,112 @@
+package und_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+type columnsRow struct {
+	Foo    und.Und[string]
+	Bar    sliceund.Und[int]
+	Baz    option.Option[float64]
+	Qux    elastic.Elastic[int]
+	Plain  string
+	hidden und.Und[string]
+}
+
+func TestColumns(t *testing.T) {
+	rows := []columnsRow{
+		{Foo: und.Defined("foo"), Bar: sliceund.Null[int](), Baz: option.Some(1.5)},
+		{Foo: und.Null[string](), Bar: sliceund.Defined(5)},
+		{},
+	}
+
+	columns, err := und.Columns(rows)
+	assert.NilError(t, err)
+	assert.Equal(t, len(columns), 3)
+
+	foo := columns["Foo"]
+	assert.Equal(t, foo.Len, 3)
+	assert.DeepEqual(t, foo.Values, []string{"foo", "", ""})
+	assert.DeepEqual(t, foo.Valid, []byte{0b001})
+	assert.DeepEqual(t, foo.Null, []byte{0b010})
+	assert.Equal(t, foo.State(0), und.StateDefined)
+	assert.Equal(t, foo.State(1), und.StateNull)
+	assert.Equal(t, foo.State(2), und.StateUndefined)
+
+	bar := columns["Bar"]
+	assert.DeepEqual(t, bar.Values, []int{0, 5, 0})
+	assert.Equal(t, bar.State(0), und.StateNull)
+	assert.Equal(t, bar.State(1), und.StateDefined)
+	assert.Equal(t, bar.State(2), und.StateUndefined)
+
+	baz := columns["Baz"]
+	assert.DeepEqual(t, baz.Values, []float64{1.5, 0, 0})
+	assert.Equal(t, baz.State(0), und.StateDefined)
+	assert.Equal(t, baz.State(1), und.StateNull)
+	assert.Equal(t, baz.State(2), und.StateNull)
+
+	columns, err = und.Columns([]*columnsRow{{Foo: und.Defined("foo")}, nil}, "Foo")
+	assert.NilError(t, err)
+	assert.Equal(t, len(columns), 1)
+	assert.DeepEqual(t, columns["Foo"].Values, []string{"foo", ""})
+	assert.Equal(t, columns["Foo"].State(1), und.StateUndefined)
+
+	for _, field := range []string{"Qux", "Plain", "hidden", "NotFound"} {
+		_, err = und.Columns(rows, field)
+		assert.Assert(t, errors.Is(err, und.ErrNotColumn), "field = %s, err = %v", field, err)
+	}
+
+	_, err = und.Columns([]int{1})
+	assert.Assert(t, errors.Is(err, validate.ErrNotStruct))
+}
+
+func TestFromColumn(t *testing.T) {
+	values := []int{1, 0, 0, 4}
+	valid := []byte{0b1001}
+
+	assert.Assert(t, slices.Equal(
+		und.FromColumn(values, valid, []byte{0b0010}),
+		[]und.Und[int]{und.Defined(1), und.Null[int](), und.Undefined[int](), und.Defined(4)},
+	))
+	assert.Assert(t, slices.Equal(
+		und.FromColumn(values, valid, nil),
+		[]und.Und[int]{und.Defined(1), und.Null[int](), und.Null[int](), und.Defined(4)},
+	))
+
+	rows := []columnsRow{
+		{Foo: und.Defined("foo")},
+		{Foo: und.Null[string]()},
+		{},
+	}
+	columns, err := und.Columns(rows, "Foo")
+	assert.NilError(t, err)
+	foo, err := und.Column[string](columns["Foo"])
+	assert.NilError(t, err)
+	assert.Assert(t, slices.Equal(foo, []und.Und[string]{rows[0].Foo, rows[1].Foo, rows[2].Foo}))
+
+	_, err = und.Column[int](columns["Foo"])
+	assert.Assert(t, errors.Is(err, und.ErrNotColumn))
+}
+
+func TestToColumn(t *testing.T) {
+	us := []und.Und[int]{und.Defined(1), und.Null[int](), und.Undefined[int](), und.Defined(4)}
+	values, valid, null := und.ToColumn(us)
+	assert.DeepEqual(t, []int{1, 0, 0, 4}, values)
+	assert.DeepEqual(t, []byte{0b1001}, valid)
+	assert.DeepEqual(t, []byte{0b0010}, null)
+	assert.Assert(t, slices.Equal(us, und.FromColumn(values, valid, null)))
+
+	values, valid, null = und.ToColumn[int](nil)
+	assert.Equal(t, 0, len(values))
+	assert.Equal(t, 0, len(valid))
+	assert.Equal(t, 0, len(null))
+}
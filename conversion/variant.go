@@ -0,0 +1,33 @@
+package conversion
+
+import (
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+)
+
+// ToSlice converts u into sliceund.Und[T], keeping its state and value.
+//
+// Undefined und.Und is omitted only by omitzero, while undefined sliceund.Und is also omitted by omitempty of encoding/json v1.
+// Converting between the two lets a model defined once be marshaled by whichever encoder a service uses.
+func ToSlice[T any](u und.Und[T]) sliceund.Und[T] {
+	return sliceund.FromUnd(u)
+}
+
+// FromSlice converts u into und.Und[T], keeping its state and value.
+func FromSlice[T any](u sliceund.Und[T]) und.Und[T] {
+	return und.FromOption(u.Unwrap())
+}
+
+// ToSliceElastic converts e into sliceund/elastic.Elastic[T], keeping its state and values.
+// The returned value shares the underlying options with e.
+func ToSliceElastic[T any](e elastic.Elastic[T]) sliceelastic.Elastic[T] {
+	return sliceelastic.FromUnd(ToSlice(e.Unwrap()))
+}
+
+// FromSliceElastic converts e into elastic.Elastic[T], keeping its state and values.
+// The returned value shares the underlying options with e.
+func FromSliceElastic[T any](e sliceelastic.Elastic[T]) elastic.Elastic[T] {
+	return elastic.FromUnd(FromSlice(e.Unwrap()))
+}
@@ -0,0 +1,40 @@
+package conversion_test
+
+import (
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/conversion"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+func TestVariant(t *testing.T) {
+	for _, u := range []und.Und[int]{und.Undefined[int](), und.Null[int](), und.Defined(5)} {
+		s := conversion.ToSlice(u)
+		assert.Equal(t, u.State(), s.State())
+		assert.Equal(t, u.Value(), s.Value())
+		assert.Assert(t, und.Equal(u, conversion.FromSlice(s)))
+	}
+	assert.Assert(t, sliceund.Equal(conversion.ToSlice(und.Defined(5)), sliceund.Defined(5)))
+
+	opts := []option.Option[int]{option.None[int](), option.Some(1)}
+	for _, e := range []elastic.Elastic[int]{
+		elastic.Undefined[int](),
+		elastic.Null[int](),
+		elastic.FromOptions[int](),
+		elastic.FromOptions(opts...),
+	} {
+		s := conversion.ToSliceElastic(e)
+		assert.Equal(t, e.State(), s.State())
+		assert.Assert(t, option.EqualOptions(e.Unwrap().Value(), s.Unwrap().Value()))
+		assert.Assert(t, elastic.Equal(e, conversion.FromSliceElastic(s)))
+	}
+	assert.Assert(t, sliceelastic.Equal(
+		conversion.ToSliceElastic(elastic.FromOptions(opts...)),
+		sliceelastic.FromOptions(opts...),
+	))
+}
@@ -0,0 +1,125 @@
+package und
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/undtag"
+	"github.com/ngicks/und/validate"
+)
+
+// ApplyDefaults fills fields of the struct pointed by structPtr with values specified by `und:"default=value"` struct tag option,
+// if those are not defined (or not some, for option.Option[T]).
+// Fields of nested structs are filled recursively,
+// including those pointed by non-nil pointers; each pointed struct is visited only once, so cyclic references are allowed.
+//
+// The default value is decoded as JSON into the field.
+// If the value type of the field is a string kind, e.g. T of Und[T] is string,
+// and the default value does not start with a double quote, it is used as is without decoding.
+// The default option consumes rest of the tag; it must be the last option.
+//
+//	type Config struct {
+//		Host und.Und[string]         `und:"default=localhost"`
+//		Port und.Und[int]            `und:"default=8080"`
+//		Tags option.Option[[]string] `und:"default=[\"a\",\"b\"]"`
+//	}
+//
+// Fields with the default option must be one of und types, or more precisely,
+// types which implement [UndStater], validate.UndLike or validate.OptionLike, and json.Unmarshaler via their pointer.
+// Otherwise ApplyDefaults returns an error.
+func ApplyDefaults(structPtr any) error {
+	rv := reflect.ValueOf(structPtr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: input must be a non-nil pointer to a struct but is %T", validate.ErrNotStruct, structPtr)
+	}
+	visited := map[visitKey]struct{}{{rv.Pointer(), rv.Type()}: {}}
+	return applyDefaults(rv.Elem(), visited)
+}
+
+// visitKey identifies a struct pointed by a pointer.
+// The type is included since a struct and its first field share the address.
+type visitKey struct {
+	ptr uintptr
+	ty  reflect.Type
+}
+
+func applyDefaults(rv reflect.Value, visited map[visitKey]struct{}) error {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		ft := rt.Field(i)
+		if !ft.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+
+		def, err := defaultOf(ft)
+		if err != nil {
+			return err
+		}
+
+		if !undreflect.IsUndType(ft.Type) {
+			if def.IsSome() {
+				return fmt.Errorf("%s: default option on non und type %s", ft.Name, ft.Type)
+			}
+			if err := applyDefaultsNested(fv, visited); err != nil {
+				return fmt.Errorf("%s.%w", ft.Name, err)
+			}
+			continue
+		}
+
+		if def.IsNone() || isDefined(fv) {
+			continue
+		}
+		nv := reflect.New(ft.Type)
+		if err := nv.Interface().(json.Unmarshaler).UnmarshalJSON(defaultJSON(ft.Type, def.Value())); err != nil {
+			return fmt.Errorf("%s: decoding default value %q: %w", ft.Name, def.Value(), err)
+		}
+		fv.Set(nv.Elem())
+	}
+	return nil
+}
+
+func defaultOf(ft reflect.StructField) (option.Option[string], error) {
+	tag, ok := ft.Tag.Lookup(undtag.TagName)
+	if !ok {
+		return option.None[string](), nil
+	}
+	opt, err := undtag.ParseOption(tag)
+	if err != nil {
+		return option.None[string](), fmt.Errorf("%s: %w", ft.Name, err)
+	}
+	return option.FromPointer(opt.Default().Pointer()), nil
+}
+
+func applyDefaultsNested(fv reflect.Value, visited map[visitKey]struct{}) error {
+	switch fv.Kind() {
+	case reflect.Struct:
+		return applyDefaults(fv, visited)
+	case reflect.Pointer:
+		if !fv.IsNil() && fv.Elem().Kind() == reflect.Struct && fv.Elem().CanSet() {
+			key := visitKey{fv.Pointer(), fv.Type()}
+			if _, ok := visited[key]; ok {
+				return nil
+			}
+			visited[key] = struct{}{}
+			return applyDefaults(fv.Elem(), visited)
+		}
+	}
+	return nil
+}
+
+func isDefined(fv reflect.Value) bool {
+	s, _ := undreflect.StateOf(fv.Interface())
+	return s == StateDefined
+}
+
+func defaultJSON(rt reflect.Type, def string) []byte {
+	if vt := undreflect.ValueType(rt); vt != nil && vt.Kind() == reflect.String && (len(def) == 0 || def[0] != '"') {
+		bin, _ := json.Marshal(def)
+		return bin
+	}
+	return []byte(def)
+}
@@ -0,0 +1,97 @@
+package und_test
+
+import (
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+type defaultsNested struct {
+	Timeout und.Und[int] `und:"default=30"`
+}
+
+type defaultsSample struct {
+	Host     und.Und[string]         `und:"def,und,default=localhost"`
+	Quoted   und.Und[string]         `und:"default=\"quoted\""`
+	Comma    sliceund.Und[string]    `und:"default=foo, bar"`
+	Port     und.Und[int]            `und:"default=8080"`
+	Tags     option.Option[[]string] `und:"default=[\"a\",\"b\"]"`
+	Ela      elastic.Elastic[int]    `und:"default=[1,null]"`
+	Set      und.Und[int]            `und:"default=5"`
+	Null     und.Und[int]            `und:"default=5"`
+	NoTag    und.Und[int]
+	Nested   defaultsNested
+	NestedP  *defaultsNested
+	NestedNP *defaultsNested
+}
+
+func TestUnd_OrDefault(t *testing.T) {
+	assert.Equal(t, und.Defined(1).OrDefault(2).Value(), 1)
+	assert.Equal(t, und.Null[int]().OrDefault(2).Value(), 2)
+	assert.Equal(t, und.Undefined[int]().OrDefault(2).Value(), 2)
+	assert.Equal(t, sliceund.Defined(1).OrDefault(2).Value(), 1)
+	assert.Equal(t, sliceund.Null[int]().OrDefault(2).Value(), 2)
+	assert.Equal(t, sliceund.Undefined[int]().OrDefault(2).Value(), 2)
+}
+
+func TestApplyDefaults(t *testing.T) {
+	s := defaultsSample{
+		Set:     und.Defined(1),
+		Null:    und.Null[int](),
+		NestedP: &defaultsNested{},
+	}
+	assert.NilError(t, und.ApplyDefaults(&s))
+
+	assert.Equal(t, s.Host.Value(), "localhost")
+	assert.Equal(t, s.Quoted.Value(), "quoted")
+	assert.Equal(t, s.Comma.Value(), "foo, bar")
+	assert.Equal(t, s.Port.Value(), 8080)
+	assert.DeepEqual(t, s.Tags.Value(), []string{"a", "b"})
+	assert.Assert(t, elastic.Equal(s.Ela, elastic.FromOptions(option.Some(1), option.None[int]())))
+	assert.Equal(t, s.Set.Value(), 1)
+	assert.Equal(t, s.Null.Value(), 5)
+	assert.Assert(t, s.NoTag.IsUndefined())
+	assert.Equal(t, s.Nested.Timeout.Value(), 30)
+	assert.Equal(t, s.NestedP.Timeout.Value(), 30)
+	assert.Assert(t, s.NestedNP == nil)
+
+	// default option alone does not constrain states.
+	assert.NilError(t, validate.UndValidate(defaultsNested{}))
+
+	assert.ErrorIs(t, und.ApplyDefaults(s), validate.ErrNotStruct)
+	assert.ErrorIs(t, und.ApplyDefaults((*defaultsSample)(nil)), validate.ErrNotStruct)
+
+	var invalid struct {
+		Port und.Und[int] `und:"default=foo"`
+	}
+	assert.ErrorContains(t, und.ApplyDefaults(&invalid), "Port")
+
+	var nonUnd struct {
+		Port int `und:"default=5"`
+	}
+	assert.ErrorContains(t, und.ApplyDefaults(&nonUnd), "non und type")
+}
+
+type defaultsNode struct {
+	Name   und.Und[string] `und:"default=node"`
+	Parent *defaultsNode
+}
+
+func TestApplyDefaults_cycle(t *testing.T) {
+	root := &defaultsNode{}
+	child := &defaultsNode{Parent: root}
+	root.Parent = root
+	other := &defaultsNode{Parent: child}
+	child.Parent = other
+	other.Parent = root
+
+	assert.NilError(t, und.ApplyDefaults(child))
+	assert.Equal(t, child.Name.Value(), "node")
+	assert.Equal(t, other.Name.Value(), "node")
+	assert.Equal(t, root.Name.Value(), "node")
+}
@@ -0,0 +1,22 @@
+// Package und defines Und[T], a type which expresses T | null | undefined,
+// so that structs can tell missing fields apart from null ones in JSON and other formats.
+//
+// # YAML
+//
+// Und[T] implements the function-based unmarshaler interface of gopkg.in/yaml.v2.
+// gopkg.in/yaml.v2 and gopkg.in/yaml.v3 do not call unmarshalers for null;
+// the field is left untouched or zeroed, which keeps a field of a freshly allocated struct undefined.
+// Thus with those decoders an explicit `key: null` can not be told apart from a missing key,
+// and both are decoded as undefined.
+// This is not supported, and no workaround is provided in this module.
+// The node-based unmarshaler of gopkg.in/yaml.v3, UnmarshalYAML(*yaml.Node) error,
+// would not help either: gopkg.in/yaml.v3 checks for null before looking for any unmarshaler.
+//
+// # MessagePack
+//
+// Und[T] implements the marshaler and unmarshaler interfaces of github.com/vmihailenco/msgpack/v5.
+// Both null and undefined are encoded as nil, and undefined fields are omitted with the omitempty option.
+// github.com/vmihailenco/msgpack/v5 does not call unmarshalers for nil; it zeroes the field instead.
+// Thus nil is decoded as undefined, and null does not survive a round trip through MessagePack.
+// This is not supported; the CustomDecoder interface would not help either, as it is skipped for nil too.
+package und
@@ -0,0 +1,223 @@
+package und
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+var (
+	jsonMarshalerTy = reflect.TypeFor[json.Marshaler]()
+	textMarshalerTy = reflect.TypeFor[encoding.TextMarshaler]()
+	rawMessageTy    = reflect.TypeFor[json.RawMessage]()
+)
+
+// MarshalJSONOmitUndefined marshals v into JSON by encoding/json,
+// omitting undefined fields of und types, e.g. Und[T] and elastic.Elastic[T], as if they were of sliceund types.
+//
+// encoding/json v1 can not omit struct types by omitempty option,
+// and omitzero, which does, is only available since Go 1.24.
+// MarshalJSONOmitUndefined lets a single struct definition on Und[T] produce
+// the same output as its sliceund counterpart does under omitempty,
+// so that teams do not have to maintain parallel struct definitions.
+//
+// v must be a struct or a pointer to a struct, otherwise it is marshaled by json.Marshal as is.
+// Struct fields, and struct fields of nested struct fields, whose type implements IsUndefined,
+// are re-wrapped into json.RawMessage with omitempty option at runtime by reflection.
+// Nested struct types which implement json.Marshaler or encoding.TextMarshaler are left untouched,
+// as well as structs in slices, maps or behind pointers.
+//
+// v is marshaled by json.Marshal as is if v implements json.Marshaler or encoding.TextMarshaler,
+// including methods promoted from embedded fields,
+// or if v has embedded fields that can not be rebuilt without changing the output:
+// pointers to structs, structs of unexported types, and structs implementing json.Marshaler or encoding.TextMarshaler.
+// Other embedded structs are rebuilt in place so that their fields are still promoted.
+func MarshalJSONOmitUndefined(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		if isMarshaler(rv.Type()) {
+			return json.Marshal(v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || isMarshaler(rv.Type()) || isMarshaler(reflect.PointerTo(rv.Type())) {
+		return json.Marshal(v)
+	}
+	t := omitUndefinedTypeOf(rv.Type())
+	if t.fallback {
+		return json.Marshal(v)
+	}
+	converted, err := t.convert(rv)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(converted.Interface())
+}
+
+func isMarshaler(rt reflect.Type) bool {
+	return rt.Implements(jsonMarshalerTy) || rt.Implements(textMarshalerTy)
+}
+
+type omitUndefinedField struct {
+	index int
+	isUnd bool
+	sub   *omitUndefinedType // for nested struct fields
+}
+
+type omitUndefinedType struct {
+	rt     reflect.Type
+	fields []omitUndefinedField
+	// fallback is true if rt can not be rebuilt, and values must be marshaled as they are.
+	fallback bool
+}
+
+var omitUndefinedTypes sync.Map // reflect.Type -> *omitUndefinedType
+
+func omitUndefinedTypeOf(rt reflect.Type) *omitUndefinedType {
+	if t, ok := omitUndefinedTypes.Load(rt); ok {
+		return t.(*omitUndefinedType)
+	}
+
+	var (
+		fields       []omitUndefinedField
+		structFields []reflect.StructField
+	)
+	for i := range rt.NumField() {
+		f := rt.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		if !f.IsExported() {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && ft.Kind() == reflect.Struct {
+				// encoding/json encodes exported fields of embedded structs of unexported types,
+				// but reflect.StructOf can not make unexported fields.
+				return storeOmitUndefinedType(rt, &omitUndefinedType{rt: rt, fallback: true})
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && name == "" {
+			// encoding/json promotes fields of embedded structs.
+			// reflect.StructOf can not promote methods of embedded fields,
+			// so embedded types are replaced with rebuilt structs, which have no method.
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if f.Type.Kind() == reflect.Pointer || isMarshaler(ft) || isMarshaler(reflect.PointerTo(ft)) {
+					return storeOmitUndefinedType(rt, &omitUndefinedType{rt: rt, fallback: true})
+				}
+				sub := omitUndefinedTypeOf(ft)
+				if sub.fallback {
+					return storeOmitUndefinedType(rt, &omitUndefinedType{rt: rt, fallback: true})
+				}
+				fields = append(fields, omitUndefinedField{index: i, sub: sub})
+				structFields = append(structFields, reflect.StructField{
+					Name:      f.Name,
+					Type:      sub.rt,
+					Tag:       f.Tag,
+					Anonymous: true,
+				})
+				continue
+			}
+			// Embedded non-struct types are encoded as fields named after their type names,
+			// just like non-embedded fields.
+		}
+		field := omitUndefinedField{index: i}
+		switch {
+		case f.Type.Implements(undreflect.UndLikeTy) && f.Type.Implements(jsonMarshalerTy):
+			field.isUnd = true
+			f.Type = rawMessageTy
+			f.Tag = omitEmptyTag(f)
+		case f.Type.Kind() == reflect.Struct && !isMarshaler(f.Type) && !isMarshaler(reflect.PointerTo(f.Type)):
+			sub := omitUndefinedTypeOf(f.Type)
+			if sub.fallback {
+				break
+			}
+			field.sub = sub
+			f.Type = field.sub.rt
+		}
+		fields = append(fields, field)
+		structFields = append(structFields, reflect.StructField{
+			Name: f.Name,
+			Type: f.Type,
+			Tag:  f.Tag,
+		})
+	}
+
+	return storeOmitUndefinedType(rt, &omitUndefinedType{
+		rt:     reflect.StructOf(structFields),
+		fields: fields,
+	})
+}
+
+func storeOmitUndefinedType(rt reflect.Type, t *omitUndefinedType) *omitUndefinedType {
+	stored, _ := omitUndefinedTypes.LoadOrStore(rt, t)
+	return stored.(*omitUndefinedType)
+}
+
+// omitEmptyTag returns f's tag whose json tag has omitempty option.
+// Other options and other keys of the tag are kept as they are.
+func omitEmptyTag(f reflect.StructField) reflect.StructTag {
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		return reflect.StructTag(strings.TrimSpace(string(f.Tag) + ` json:",omitempty"`))
+	}
+	if tag == "-" {
+		return f.Tag
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" {
+			return f.Tag
+		}
+	}
+	replaced := name + ",omitempty"
+	if opts != "" {
+		replaced += "," + opts
+	}
+	old := `json:` + strconv.Quote(tag)
+	if !strings.Contains(string(f.Tag), old) {
+		// the tag is quoted in an unusual way; drop other keys rather than produce a broken tag.
+		return reflect.StructTag(`json:` + strconv.Quote(replaced))
+	}
+	return reflect.StructTag(strings.Replace(string(f.Tag), old, `json:`+strconv.Quote(replaced), 1))
+}
+
+func (t *omitUndefinedType) convert(rv reflect.Value) (reflect.Value, error) {
+	converted := reflect.New(t.rt).Elem()
+	for i, f := range t.fields {
+		fv := rv.Field(f.index)
+		switch {
+		case f.isUnd:
+			if fv.Interface().(validate.UndLike).IsUndefined() {
+				continue
+			}
+			bin, err := fv.Interface().(json.Marshaler).MarshalJSON()
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			converted.Field(i).Set(reflect.ValueOf(json.RawMessage(bin)))
+		case f.sub != nil:
+			sub, err := f.sub.convert(fv)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			converted.Field(i).Set(sub)
+		default:
+			converted.Field(i).Set(fv)
+		}
+	}
+	return converted, nil
+}
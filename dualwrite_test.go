@@ -0,0 +1,205 @@
+package und_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+type dualWriteNested struct {
+	Qux und.Und[int] `json:"qux"`
+}
+
+type dualWriteSample struct {
+	Foo     und.Und[string]         `json:"foo"`
+	Bar     und.Und[int]            `json:"bar,omitempty,string"`
+	Baz     elastic.Elastic[string] `json:"baz"`
+	Time    time.Time               `json:"time"`
+	Nested  dualWriteNested         `json:"nested"`
+	Plain   string                  `json:"plain"`
+	Ignored und.Und[string]         `json:"-"`
+	private und.Und[string]
+}
+
+type dualWriteSliceNested struct {
+	Qux sliceund.Und[int] `json:"qux,omitempty"`
+}
+
+type dualWriteSliceSample struct {
+	Foo    sliceund.Und[string]         `json:"foo,omitempty"`
+	Bar    sliceund.Und[int]            `json:"bar,omitempty"`
+	Baz    sliceelastic.Elastic[string] `json:"baz,omitempty"`
+	Time   time.Time                    `json:"time"`
+	Nested dualWriteSliceNested         `json:"nested"`
+	Plain  string                       `json:"plain"`
+}
+
+func TestMarshalJSONOmitUndefined(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tc := range []struct {
+		v     dualWriteSample
+		slice dualWriteSliceSample
+	}{
+		{
+			dualWriteSample{Time: now},
+			dualWriteSliceSample{Time: now},
+		},
+		{
+			dualWriteSample{
+				Foo:     und.Null[string](),
+				Bar:     und.Defined(5),
+				Baz:     elastic.FromValues("a", "b"),
+				Nested:  dualWriteNested{Qux: und.Null[int]()},
+				Plain:   "plain",
+				Ignored: und.Defined("ignored"),
+				private: und.Defined("private"),
+			},
+			dualWriteSliceSample{
+				Foo:    sliceund.Null[string](),
+				Bar:    sliceund.Defined(5),
+				Baz:    sliceelastic.FromValues("a", "b"),
+				Nested: dualWriteSliceNested{Qux: sliceund.Null[int]()},
+				Plain:  "plain",
+			},
+		},
+	} {
+		bin, err := und.MarshalJSONOmitUndefined(tc.v)
+		assert.NilError(t, err)
+		expected, err := json.Marshal(tc.slice)
+		assert.NilError(t, err)
+		assert.Equal(t, string(expected), string(bin))
+
+		binPtr, err := und.MarshalJSONOmitUndefined(&tc.v)
+		assert.NilError(t, err)
+		assert.Equal(t, string(bin), string(binPtr))
+	}
+
+	bin, err := und.MarshalJSONOmitUndefined([]int{1})
+	assert.NilError(t, err)
+	assert.Equal(t, string(bin), "[1]")
+}
+
+type DualWriteEmbedded struct {
+	Qux und.Und[int] `json:"qux"`
+}
+
+func (DualWriteEmbedded) Method() {}
+
+type DualWriteEmbeddedSlice struct {
+	Qux sliceund.Und[int] `json:"qux,omitempty"`
+}
+
+type dualWriteUnexported struct {
+	Quux und.Und[int] `json:"quux"`
+}
+
+func TestMarshalJSONOmitUndefined_embedded(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	type tc struct {
+		name     string
+		v        any
+		expected string
+	}
+	for _, tc := range []tc{
+		{
+			// promoted MarshalJSON of time.Time
+			name: "time",
+			v: struct {
+				time.Time
+				B und.Und[int]
+			}{Time: now},
+			expected: `"2024-01-02T03:04:05Z"`,
+		},
+		{
+			// promoted MarshalJSON of und.Und
+			name: "und",
+			v: struct {
+				und.Und[int]
+				B int
+			}{Und: und.Defined(1), B: 2},
+			expected: `1`,
+		},
+		{
+			name: "struct with methods",
+			v: struct {
+				DualWriteEmbedded
+				B und.Und[int] `json:"b"`
+			}{},
+			expected: `{}`,
+		},
+		{
+			name: "struct with methods, defined",
+			v: struct {
+				DualWriteEmbedded
+				B und.Und[int] `json:"b"`
+			}{DualWriteEmbedded: DualWriteEmbedded{Qux: und.Null[int]()}, B: und.Defined(1)},
+			expected: `{"qux":null,"b":1}`,
+		},
+		{
+			name: "named embedded",
+			v: struct {
+				DualWriteEmbedded `json:"e"`
+			}{},
+			expected: `{"e":{}}`,
+		},
+		{
+			// falls back to json.Marshal
+			name: "named unexported",
+			v: struct {
+				dualWriteUnexported `json:"e"`
+			}{},
+			expected: `{"e":{"quux":null}}`,
+		},
+		{
+			// falls back to json.Marshal
+			name: "pointer",
+			v: struct {
+				*DualWriteEmbedded
+				B und.Und[int] `json:"b"`
+			}{DualWriteEmbedded: &DualWriteEmbedded{}},
+			expected: `{"qux":null,"b":null}`,
+		},
+		{
+			// falls back to json.Marshal
+			name: "unexported",
+			v: struct {
+				dualWriteUnexported
+			}{},
+			expected: `{"quux":null}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bin, err := und.MarshalJSONOmitUndefined(tc.v)
+			assert.NilError(t, err)
+			assert.Equal(t, tc.expected, string(bin))
+		})
+	}
+
+	bin, err := und.MarshalJSONOmitUndefined(struct {
+		DualWriteEmbedded
+	}{DualWriteEmbedded{Qux: und.Defined(3)}})
+	assert.NilError(t, err)
+	expected, err := json.Marshal(struct {
+		DualWriteEmbeddedSlice
+	}{DualWriteEmbeddedSlice{Qux: sliceund.Defined(3)}})
+	assert.NilError(t, err)
+	assert.Equal(t, string(expected), string(bin))
+}
+
+type dualWriteTagged struct {
+	A und.Und[int] `json:"a,string" yaml:"a"`
+	B und.Und[int] `yaml:"b"`
+}
+
+func TestMarshalJSONOmitUndefined_tag(t *testing.T) {
+	bin, err := und.MarshalJSONOmitUndefined(dualWriteTagged{A: und.Defined(1)})
+	assert.NilError(t, err)
+	assert.Equal(t, `{"a":1}`, string(bin))
+}
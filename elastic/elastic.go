@@ -22,6 +22,7 @@ var (
 	_ validate.UndValidator = Elastic[any]{}
 	_ validate.UndChecker   = Elastic[any]{}
 	_ validate.ElasticLike  = Elastic[any]{}
+	_ und.UndStater         = Elastic[any]{}
 )
 
 // Elastic[T] is a type that can express *undefined* | *null* | T | [](null | T).
@@ -72,6 +73,37 @@ func FromUnd[T any, Opts ~[]option.Option[T]](u und.Und[Opts]) Elastic[T] {
 	return Elastic[T]{und.Map(u, func(o Opts) option.Options[T] { return option.Options[T](o) })}
 }
 
+// FromUndSlice converts und.Und[[]T] into Elastic[T].
+// Undefined and null are kept as they are, and elements of a defined slice become some values.
+//
+// To convert und.Und[option.Options[T]] losslessly, use [FromUnd] instead.
+func FromUndSlice[T any, S ~[]T](u und.Und[S]) Elastic[T] {
+	switch {
+	case u.IsUndefined():
+		return Undefined[T]()
+	case u.IsNull():
+		return Null[T]()
+	default:
+		return FromValues(u.Value()...)
+	}
+}
+
+// ToUndSlice converts e into und.Und[[]T].
+// Undefined and null are kept as they are, and values of a defined e are converted as [Elastic.Values] does:
+// None elements become zero value of T.
+//
+// To convert e losslessly into und.Und[option.Options[T]], use [Elastic.Unwrap] instead.
+func ToUndSlice[T any](e Elastic[T]) und.Und[[]T] {
+	switch {
+	case e.IsUndefined():
+		return und.Undefined[[]T]()
+	case e.IsNull():
+		return und.Null[[]T]()
+	default:
+		return und.Defined(e.Values())
+	}
+}
+
 func (e Elastic[T]) inner() und.Und[option.Options[T]] {
 	return e.v
 }
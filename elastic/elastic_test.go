@@ -175,3 +175,18 @@ func Test_Clone(t *testing.T) {
 	cloned = Clone(undefined)
 	assert.Assert(t, cloned.IsUndefined())
 }
+
+func TestUndSlice(t *testing.T) {
+	assert.Assert(t, FromUndSlice(und.Undefined[[]int]()).IsUndefined())
+	assert.Assert(t, FromUndSlice(und.Null[[]int]()).IsNull())
+	assert.Assert(t, Equal(FromUndSlice(und.Defined([]int{1, 2})), FromValues(1, 2)))
+	fromNil := FromUndSlice(und.Defined([]int(nil)))
+	assert.Assert(t, fromNil.IsDefined())
+	assert.Equal(t, 0, fromNil.Len())
+
+	assert.Assert(t, ToUndSlice(Undefined[int]()).IsUndefined())
+	assert.Assert(t, ToUndSlice(Null[int]()).IsNull())
+	u := ToUndSlice(FromOptions(option.Some(1), option.None[int](), option.Some(3)))
+	assert.Assert(t, u.IsDefined())
+	assert.DeepEqual(t, []int{1, 0, 3}, u.Value())
+}
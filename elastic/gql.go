@@ -0,0 +1,37 @@
+package elastic
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// portable methods that can be copied from github.com/ngicks/und/elastic into github.com/ngicks/und/sliceund/elastic
+
+// MarshalGQL implements the marshaler interface of github.com/99designs/gqlgen.
+//
+// e is written as what MarshalJSON returns; a defined Elastic[T] is written as a list.
+// If T can not be marshaled into JSON, null is written instead, as the interface has no way to report errors.
+func (e Elastic[T]) MarshalGQL(w io.Writer) {
+	bin, err := e.MarshalJSON()
+	if err != nil {
+		bin = []byte(`null`)
+	}
+	_, _ = w.Write(bin)
+}
+
+// UnmarshalGQL implements the unmarshaler interface of github.com/99designs/gqlgen.
+//
+// nil makes e null. Other v is converted through JSON, then decoded as UnmarshalJSON does;
+// like UnmarshalJSON, it accepts either a list of (null | T) or a single T.
+// UnmarshalGQL never makes e undefined; e stays undefined only if UnmarshalGQL is not called.
+func (e *Elastic[T]) UnmarshalGQL(v any) error {
+	if v == nil {
+		*e = Null[T]()
+		return nil
+	}
+	bin, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalJSON(bin)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"iter"
 	"log/slog"
+	"reflect"
 	"slices"
 
 	"github.com/ngicks/und"
@@ -78,6 +79,9 @@ func (e Elastic[T]) IsZero() bool {
 	return e.IsUndefined()
 }
 
+// UndValidate validates each some value of e if e is defined.
+// A returned error is annotated with the index of the failing element.
+// See [option.Options.UndValidate] for details.
 func (e Elastic[T]) UndValidate() error {
 	return e.inner().Value().UndValidate()
 }
@@ -86,11 +90,281 @@ func (e Elastic[T]) UndCheck() error {
 	return e.inner().Value().UndCheck()
 }
 
+// EqualDeep is like [Equal] but tests equality of values by [reflect.DeepEqual].
+// Unlike [Equal], EqualDeep accepts any T, including uncomparable ones, e.g. slices and maps.
+// For types that need special comparison, use [Elastic.EqualFunc] instead.
+func EqualDeep[T any](l, r Elastic[T]) bool {
+	return l.EqualFunc(r, func(i, j T) bool { return reflect.DeepEqual(i, j) })
+}
+
+// Clone returns a copy of e.
+// If T implements option.Cloner[T], values are cloned by their Clone method,
+// otherwise those are copied by assignment.
+func (e Elastic[T]) Clone() Elastic[T] {
+	return FromUnd(e.inner().Clone())
+}
+
+// Get returns the element at index i of e's values.
+// ok is false if e is not defined or i is out of range.
+func (e Elastic[T]) Get(i int) (opt option.Option[T], ok bool) {
+	opts := e.inner().Value()
+	if i < 0 || len(opts) <= i {
+		return option.None[T](), false
+	}
+	return opts[i], true
+}
+
+// First returns the first element of e's values.
+// ok is false if e is not defined or has no element.
+func (e Elastic[T]) First() (opt option.Option[T], ok bool) {
+	return e.Get(0)
+}
+
+// Last returns the last element of e's values.
+// ok is false if e is not defined or has no element.
+func (e Elastic[T]) Last() (opt option.Option[T], ok bool) {
+	return e.Get(e.Len() - 1)
+}
+
+// Append returns a new Elastic[T] whose values are e's followed by vs as some values.
+// If e is not defined, the returned value only has vs.
+func (e Elastic[T]) Append(vs ...T) Elastic[T] {
+	return e.AppendOptions(someOptions(vs)...)
+}
+
+// AppendOptions is like [Elastic.Append] but appends options as they are.
+func (e Elastic[T]) AppendOptions(opts ...option.Option[T]) Elastic[T] {
+	return FromOptions(slices.Concat(e.inner().Value(), opts)...)
+}
+
+// Prepend returns a new Elastic[T] whose values are vs as some values followed by e's.
+// If e is not defined, the returned value only has vs.
+func (e Elastic[T]) Prepend(vs ...T) Elastic[T] {
+	return FromOptions(slices.Concat(someOptions(vs), e.inner().Value())...)
+}
+
+// Insert returns a new Elastic[T] where vs are inserted as some values at index i of e's values.
+// Insert panics if i is out of range, i.e. i < 0 or i > e.Len().
+func (e Elastic[T]) Insert(i int, vs ...T) Elastic[T] {
+	return e.InsertOptions(i, someOptions(vs)...)
+}
+
+// InsertOptions is like [Elastic.Insert] but inserts options as they are.
+func (e Elastic[T]) InsertOptions(i int, opts ...option.Option[T]) Elastic[T] {
+	cur := e.inner().Value()
+	return FromOptions(slices.Concat(cur[:i], opts, cur[i:])...)
+}
+
+// Delete returns a new Elastic[T] where the element at index i of e's values is removed.
+// Delete panics if i is out of range, i.e. i < 0 or i >= e.Len().
+func (e Elastic[T]) Delete(i int) Elastic[T] {
+	cur := e.inner().Value()
+	return FromOptions(slices.Concat(cur[:i], cur[i+1:])...)
+}
+
+// Set returns a new Elastic[T] where the element at index i of e's values is replaced with opt.
+// Set panics if i is out of range, i.e. i < 0 or i >= e.Len().
+func (e Elastic[T]) Set(i int, opt option.Option[T]) Elastic[T] {
+	opts := slices.Clone(e.inner().Value())
+	opts[i] = opt
+	return FromOptions(opts...)
+}
+
+// Compact returns a new Elastic[T] where None elements of e's values are removed.
+// Undefined and null e are kept as they are.
+func (e Elastic[T]) Compact() Elastic[T] {
+	if !e.IsDefined() {
+		return e
+	}
+	var opts option.Options[T]
+	for _, opt := range e.inner().Value() {
+		if opt.IsSome() {
+			opts = append(opts, opt)
+		}
+	}
+	return FromOptions(opts...)
+}
+
+// Filter returns a new Elastic[T] where some values for which pred reports false are removed.
+// None elements remain as None; combine with [Elastic.Compact] to remove them too.
+// Undefined and null e are kept as they are.
+func (e Elastic[T]) Filter(pred func(t T) bool) Elastic[T] {
+	if !e.IsDefined() {
+		return e
+	}
+	var opts option.Options[T]
+	for _, opt := range e.inner().Value() {
+		if opt.IsNone() || pred(opt.Value()) {
+			opts = append(opts, opt)
+		}
+	}
+	return FromOptions(opts...)
+}
+
+// Dedup returns a new Elastic[T] where duplicate elements of e's values are removed,
+// keeping the first occurrence of each.
+// Some values are compared by eq, and None elements are all equal to each other.
+// Undefined and null e are kept as they are.
+func (e Elastic[T]) Dedup(eq func(a, b T) bool) Elastic[T] {
+	if !e.IsDefined() {
+		return e
+	}
+	var opts option.Options[T]
+	for _, opt := range e.inner().Value() {
+		if !slices.ContainsFunc(opts, func(o option.Option[T]) bool { return o.EqualFunc(opt, eq) }) {
+			opts = append(opts, opt)
+		}
+	}
+	return FromOptions(opts...)
+}
+
+// NoneOrder specifies where [Elastic.SortFunc] places None elements.
+//
+// The zero value is NoneFirst, the order of [option.Options.SortFunc] and [option.Option.CompareFunc].
+type NoneOrder int
+
+const (
+	// NoneFirst places None elements before some values.
+	NoneFirst NoneOrder = iota
+	// NoneLast places None elements after some values.
+	NoneLast
+)
+
+// SortFunc returns a new Elastic[T] whose values are e's sorted by cmp,
+// in the same way as [slices.SortStableFunc].
+// None elements are placed as specified by none.
+// Undefined and null e are kept as they are.
+func (e Elastic[T]) SortFunc(cmp func(a, b T) int, none NoneOrder) Elastic[T] {
+	if !e.IsDefined() {
+		return e
+	}
+	noneCmp := -1
+	if none == NoneLast {
+		noneCmp = 1
+	}
+	opts := slices.Clone(e.inner().Value())
+	slices.SortStableFunc(opts, func(a, b option.Option[T]) int {
+		switch {
+		case a.IsNone() && b.IsNone():
+			return 0
+		case a.IsNone():
+			return noneCmp
+		case b.IsNone():
+			return -noneCmp
+		default:
+			return cmp(a.Value(), b.Value())
+		}
+	})
+	return FromOptions(opts...)
+}
+
+// Concat concatenates values of es into one Elastic[T].
+//
+// If any of es is defined, the returned value is defined and has values of defined ones in order;
+// null and undefined ones contribute nothing.
+// Otherwise it is null if any of es is null, undefined if all of es are undefined or es is empty.
+func Concat[T any](es ...Elastic[T]) Elastic[T] {
+	var (
+		opts    option.Options[T]
+		defined bool
+		null    bool
+	)
+	for _, e := range es {
+		switch {
+		case e.IsDefined():
+			defined = true
+			opts = append(opts, e.inner().Value()...)
+		case e.IsNull():
+			null = true
+		}
+	}
+	switch {
+	case defined:
+		return FromOptions(opts...)
+	case null:
+		return Null[T]()
+	default:
+		return Undefined[T]()
+	}
+}
+
+func someOptions[T any](vs []T) option.Options[T] {
+	opts := make(option.Options[T], len(vs))
+	for i, v := range vs {
+		opts[i] = option.Some(v)
+	}
+	return opts
+}
+
 // MarshalJSON implements json.Marshaler.
 func (u Elastic[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(u.inner())
 }
 
+// AppendJSON appends JSON encoding of e to dst and returns the extended buffer.
+// The appended bytes are same as what MarshalJSON returns.
+// See [option.Option.AppendJSON] for details.
+func (e Elastic[T]) AppendJSON(dst []byte) ([]byte, error) {
+	opts := e.inner().Value()
+	if !e.IsDefined() || opts == nil {
+		return append(dst, "null"...), nil
+	}
+	orig := len(dst)
+	dst = append(dst, '[')
+	for i, o := range opts {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		var err error
+		dst, err = o.AppendJSON(dst)
+		if err != nil {
+			return dst[:orig], err
+		}
+	}
+	return append(dst, ']'), nil
+}
+
+// JSONStyle controls the shape of JSON produced by [Elastic.MarshalJSONStyle].
+// The zero value produces the same output as MarshalJSON.
+type JSONStyle struct {
+	// EmptyAsNull marshals a defined Elastic with no element as null instead of [].
+	EmptyAsNull bool
+	// SingleAsValue marshals a defined Elastic with exactly one some element as the bare value
+	// instead of a one-element array.
+	// A single None element is still marshaled as [null], since bare null means null Elastic.
+	SingleAsValue bool
+}
+
+// MarshalJSONStyle is like MarshalJSON but shapes the output as specified by style.
+// Every shape it produces is accepted by UnmarshalJSON,
+// which decodes it back into e except that EmptyAsNull turns an empty Elastic into null.
+// SingleAsValue round-trips only if the JSON form of T is neither an array nor null,
+// since UnmarshalJSON reads a bare array as elements and a bare null as null Elastic;
+// e.g. a single []any{1, 2} is marshaled as [1,2] and read back as two elements.
+//
+// To omit a defined but empty Elastic from the output entirely,
+// convert it by [Elastic.EmptyAsUndefined] and attach the omitting option to the field.
+func (e Elastic[T]) MarshalJSONStyle(style JSONStyle) ([]byte, error) {
+	if e.IsDefined() {
+		switch opts := e.inner().Value(); {
+		case len(opts) == 0 && style.EmptyAsNull:
+			return []byte(`null`), nil
+		case len(opts) == 1 && opts[0].IsSome() && style.SingleAsValue:
+			return opts[0].MarshalJSON()
+		}
+	}
+	return e.MarshalJSON()
+}
+
+// EmptyAsUndefined returns undefined Elastic[T] if e is defined but has no element.
+// Otherwise it returns e as it is.
+func (e Elastic[T]) EmptyAsUndefined() Elastic[T] {
+	if e.IsDefined() && e.Len() == 0 {
+		return Undefined[T]()
+	}
+	return e
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (e *Elastic[T]) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
@@ -142,6 +416,46 @@ func Map[T, U any](e Elastic[T], f func(t T) U) Elastic[U] {
 	}
 }
 
+// FilterMap filters and maps values of e in one pass, in the same way as [option.FilterMapOptions].
+// Some values for which f reports false are removed; null values remain as null.
+// Undefined and null e are kept as they are.
+func FilterMap[T, U any](e Elastic[T], f func(t T) (U, bool)) Elastic[U] {
+	switch {
+	case e.IsUndefined():
+		return Undefined[U]()
+	case e.IsNull():
+		return Null[U]()
+	default:
+		return FromOptions(option.FilterMapOptions(e.Unwrap().Value(), f)...)
+	}
+}
+
+// MapIndexed returns a new Elastic[T] whose some values are mapped by f,
+// which also receives the index of each value in e's values.
+// None elements remain as None.
+// Undefined and null e are kept as they are.
+func (e Elastic[T]) MapIndexed(f func(i int, t T) T) Elastic[T] {
+	if !e.IsDefined() {
+		return e
+	}
+	src := e.inner().Value()
+	opts := make(option.Options[T], len(src))
+	for i, opt := range src {
+		opts[i] = option.Map(opt, func(t T) T { return f(i, t) })
+	}
+	return FromOptions(opts...)
+}
+
+// Reduce folds elements of e's values into an accumulated value, starting from init and applying f in order.
+// If e is not defined, it returns init.
+func Reduce[T, A any](e Elastic[T], init A, f func(acc A, opt option.Option[T]) A) A {
+	acc := init
+	for _, opt := range e.inner().Value() {
+		acc = f(acc, opt)
+	}
+	return acc
+}
+
 func mapSeq[T, U any](f func(T) U, seq iter.Seq[option.Option[T]]) iter.Seq[option.Option[U]] {
 	return func(yield func(option.Option[U]) bool) {
 		for opt := range seq {
@@ -152,6 +466,78 @@ func mapSeq[T, U any](f func(T) U, seq iter.Seq[option.Option[T]]) iter.Seq[opti
 	}
 }
 
+// FlattenOption converts Elastic[option.Option[T]] into Elastic[T].
+//
+// Undefined and null are kept as they are.
+// Elements of a defined Elastic become None if they are None or some of None,
+// otherwise some of the inner value.
+func FlattenOption[T any](e Elastic[option.Option[T]]) Elastic[T] {
+	switch {
+	case e.IsUndefined():
+		return Undefined[T]()
+	case e.IsNull():
+		return Null[T]()
+	default:
+		opts := e.Unwrap().Value()
+		flattened := make(option.Options[T], len(opts))
+		for i, opt := range opts {
+			flattened[i] = option.Flatten(opt)
+		}
+		return FromOptions(flattened...)
+	}
+}
+
+// Flatten converts nested Elastic[Elastic[T]] into Elastic[T] by concatenating values of inner ones.
+//
+// Undefined and null are kept as they are.
+// For a defined e, values of defined inner elements are spliced in order,
+// None elements and null inner elements become a single None,
+// and undefined inner elements are dropped.
+func Flatten[T any](e Elastic[Elastic[T]]) Elastic[T] {
+	switch {
+	case e.IsUndefined():
+		return Undefined[T]()
+	case e.IsNull():
+		return Null[T]()
+	default:
+		var flattened option.Options[T]
+		for _, opt := range e.inner().Value() {
+			inner := opt.Value()
+			switch {
+			case opt.IsNone() || inner.IsNull():
+				flattened = append(flattened, option.None[T]())
+			case inner.IsDefined():
+				flattened = append(flattened, inner.inner().Value()...)
+			}
+		}
+		return FromOptions(flattened...)
+	}
+}
+
+// FlattenOptions converts Elastic[option.Options[T]] into Elastic[T] by concatenating inner options.
+//
+// Undefined and null are kept as they are.
+// For a defined e, inner options of some elements are spliced in order,
+// and None elements become a single None.
+func FlattenOptions[T any](e Elastic[option.Options[T]]) Elastic[T] {
+	switch {
+	case e.IsUndefined():
+		return Undefined[T]()
+	case e.IsNull():
+		return Null[T]()
+	default:
+		var flattened option.Options[T]
+		for _, opt := range e.inner().Value() {
+			if opt.IsNone() {
+				flattened = append(flattened, option.None[T]())
+			} else {
+				flattened = append(flattened, opt.Value()...)
+			}
+		}
+		return FromOptions(flattened...)
+	}
+}
+
 // State returns e's value state.
 func (e Elastic[T]) State() und.State {
 	switch {
@@ -163,3 +549,9 @@ func (e Elastic[T]) State() und.State {
 		return und.StateDefined
 	}
 }
+
+// AnyValue implements und.UndStater.
+// It returns e's values as option.Options[T], which is nil unless e is defined.
+func (e Elastic[T]) AnyValue() any {
+	return e.Unwrap().Value()
+}
@@ -1,8 +1,11 @@
 package elastic
 
 import (
+	"cmp"
+	"strconv"
 	"testing"
 
+	"github.com/ngicks/und/option"
 	"gotest.tools/v3/assert"
 )
 
@@ -65,3 +68,275 @@ func TestUnd_new_functions(t *testing.T) {
 		assert.Equal(t, true, e.IsDefined())
 	})
 }
+
+func TestFlattenOption(t *testing.T) {
+	assert.Assert(t, FlattenOption(Undefined[option.Option[int]]()).IsUndefined())
+	assert.Assert(t, FlattenOption(Null[option.Option[int]]()).IsNull())
+
+	flattened := FlattenOption(FromOptions(
+		option.None[option.Option[int]](),
+		option.Some(option.None[int]()),
+		option.Some(option.Some(5)),
+	))
+	assert.Assert(t, Equal(flattened, FromOptions(option.None[int](), option.None[int](), option.Some(5))))
+}
+
+func TestFilterMap(t *testing.T) {
+	atoi := func(s string) (int, bool) {
+		i, err := strconv.Atoi(s)
+		return i, err == nil
+	}
+	assert.Assert(t, FilterMap(Undefined[string](), atoi).IsUndefined())
+	assert.Assert(t, FilterMap(Null[string](), atoi).IsNull())
+
+	mapped := FilterMap(FromOptions(option.Some("1"), option.None[string](), option.Some("foo")), atoi)
+	assert.Assert(t, Equal(mapped, FromOptions(option.Some(1), option.None[int]())))
+
+	mapped = FilterMap(FromValue("foo"), atoi)
+	assert.Assert(t, mapped.IsDefined())
+	assert.Equal(t, mapped.Len(), 0)
+}
+
+func TestElastic_modify(t *testing.T) {
+	some := option.Some[int]
+	none := option.None[int]()
+
+	orig := FromOptions(some(1), none, some(3))
+
+	assert.Assert(t, Equal(orig.Append(4, 5), FromOptions(some(1), none, some(3), some(4), some(5))))
+	assert.Assert(t, Equal(orig.AppendOptions(none), FromOptions(some(1), none, some(3), none)))
+	assert.Assert(t, Equal(orig.Prepend(0), FromOptions(some(0), some(1), none, some(3))))
+	assert.Assert(t, Equal(orig.Insert(1, 7, 8), FromOptions(some(1), some(7), some(8), none, some(3))))
+	assert.Assert(t, Equal(orig.Insert(3, 7), FromOptions(some(1), none, some(3), some(7))))
+	assert.Assert(t, Equal(orig.InsertOptions(0, none), FromOptions(none, some(1), none, some(3))))
+	assert.Assert(t, Equal(orig.Delete(1), FromOptions(some(1), some(3))))
+	assert.Assert(t, Equal(orig.Delete(2), FromOptions(some(1), none)))
+	assert.Assert(t, Equal(orig.Set(1, some(2)), FromOptions(some(1), some(2), some(3))))
+
+	// orig is not mutated.
+	assert.Assert(t, Equal(orig, FromOptions(some(1), none, some(3))))
+
+	// appending to a value with spare capacity must not share its backing array.
+	base := FromOptions(append(make(option.Options[int], 0, 8), some(1))...)
+	a, b := base.Append(2), base.Append(3)
+	assert.Assert(t, Equal(a, FromValues(1, 2)))
+	assert.Assert(t, Equal(b, FromValues(1, 3)))
+
+	for _, e := range []Elastic[int]{Undefined[int](), Null[int]()} {
+		assert.Assert(t, Equal(e.Append(1), FromValues(1)))
+		assert.Assert(t, Equal(e.Prepend(1), FromValues(1)))
+		assert.Assert(t, Equal(e.Insert(0, 1), FromValues(1)))
+		assert.Assert(t, Equal(e.AppendOptions(), FromOptions[int]()))
+	}
+
+	for _, f := range []func(){
+		func() { orig.Insert(4, 0) },
+		func() { orig.Insert(-1, 0) },
+		func() { orig.Delete(3) },
+		func() { orig.Set(3, none) },
+		func() { Undefined[int]().Delete(0) },
+	} {
+		func() {
+			defer func() { assert.Assert(t, recover() != nil) }()
+			f()
+		}()
+	}
+}
+
+func TestElastic_Get(t *testing.T) {
+	e := FromOptions(option.Some(1), option.None[int](), option.Some(3))
+
+	for i, expected := range []option.Option[int]{option.Some(1), option.None[int](), option.Some(3)} {
+		opt, ok := e.Get(i)
+		assert.Assert(t, ok)
+		assert.Assert(t, option.Equal(opt, expected))
+	}
+	for _, i := range []int{-1, 3} {
+		opt, ok := e.Get(i)
+		assert.Assert(t, !ok)
+		assert.Assert(t, opt.IsNone())
+	}
+
+	first, ok := e.First()
+	assert.Assert(t, ok)
+	assert.Equal(t, 1, first.Value())
+	last, ok := e.Last()
+	assert.Assert(t, ok)
+	assert.Equal(t, 3, last.Value())
+
+	for _, e := range []Elastic[int]{Undefined[int](), Null[int](), FromOptions[int]()} {
+		_, ok := e.Get(0)
+		assert.Assert(t, !ok)
+		_, ok = e.First()
+		assert.Assert(t, !ok)
+		_, ok = e.Last()
+		assert.Assert(t, !ok)
+	}
+}
+
+func TestElastic_Compact_Filter(t *testing.T) {
+	some := option.Some[int]
+	none := option.None[int]()
+	isOdd := func(i int) bool { return i%2 == 1 }
+
+	e := FromOptions(some(1), none, some(2), none, some(3))
+	assert.Assert(t, Equal(e.Compact(), FromValues(1, 2, 3)))
+	assert.Assert(t, Equal(e.Filter(isOdd), FromOptions(some(1), none, none, some(3))))
+	assert.Assert(t, Equal(e.Filter(isOdd).Compact(), FromValues(1, 3)))
+	assert.Assert(t, Equal(e, FromOptions(some(1), none, some(2), none, some(3))))
+
+	compacted := FromOptions(none).Compact()
+	assert.Assert(t, compacted.IsDefined())
+	assert.Equal(t, 0, compacted.Len())
+
+	assert.Assert(t, Undefined[int]().Compact().IsUndefined())
+	assert.Assert(t, Null[int]().Compact().IsNull())
+	assert.Assert(t, Undefined[int]().Filter(isOdd).IsUndefined())
+	assert.Assert(t, Null[int]().Filter(isOdd).IsNull())
+}
+
+func TestElastic_Dedup_SortFunc(t *testing.T) {
+	some := option.Some[int]
+	none := option.None[int]()
+	eq := func(a, b int) bool { return a == b }
+
+	e := FromOptions(some(3), none, some(1), some(3), none, some(2), some(1))
+	assert.Assert(t, Equal(e.Dedup(eq), FromOptions(some(3), none, some(1), some(2))))
+	assert.Assert(t, Equal(e.SortFunc(cmp.Compare[int], NoneLast), FromOptions(some(1), some(1), some(2), some(3), some(3), none, none)))
+	assert.Assert(t, Equal(e.SortFunc(cmp.Compare[int], NoneFirst), FromOptions(none, none, some(1), some(1), some(2), some(3), some(3))))
+	assert.Assert(t, Equal(e.Dedup(eq).SortFunc(cmp.Compare[int], NoneLast), FromOptions(some(1), some(2), some(3), none)))
+	// the zero NoneOrder sorts as option.Options.SortFunc does.
+	var zero NoneOrder
+	assert.Assert(t, Equal(e.SortFunc(cmp.Compare[int], zero), FromOptions(e.Unwrap().Value().SortFunc(cmp.Compare[int])...)))
+	// e is not mutated.
+	assert.Assert(t, Equal(e, FromOptions(some(3), none, some(1), some(3), none, some(2), some(1))))
+
+	assert.Assert(t, Undefined[int]().Dedup(eq).IsUndefined())
+	assert.Assert(t, Null[int]().Dedup(eq).IsNull())
+	assert.Assert(t, Undefined[int]().SortFunc(cmp.Compare[int], NoneLast).IsUndefined())
+	assert.Assert(t, Null[int]().SortFunc(cmp.Compare[int], NoneLast).IsNull())
+	assert.Assert(t, FromOptions[int]().Dedup(eq).IsDefined())
+}
+
+func TestConcat(t *testing.T) {
+	some := option.Some[int]
+	none := option.None[int]()
+	u, n := Undefined[int](), Null[int]()
+
+	assert.Assert(t, Concat[int]().IsUndefined())
+	assert.Assert(t, Concat(u, u).IsUndefined())
+	assert.Assert(t, Concat(u, n, u).IsNull())
+	assert.Assert(t, Equal(Concat(n, FromOptions[int](), u), FromOptions[int]()))
+	assert.Assert(t, Equal(
+		Concat(FromValues(1, 2), n, FromOptions(none), u, FromValue(3)),
+		FromOptions(some(1), some(2), none, some(3)),
+	))
+}
+
+func TestElastic_MarshalJSONStyle(t *testing.T) {
+	none := option.None[int]()
+	for _, tc := range []struct {
+		e        Elastic[int]
+		style    JSONStyle
+		expected string
+	}{
+		{Undefined[int](), JSONStyle{EmptyAsNull: true, SingleAsValue: true}, `null`},
+		{Null[int](), JSONStyle{EmptyAsNull: true, SingleAsValue: true}, `null`},
+		{FromOptions[int](), JSONStyle{}, `[]`},
+		{FromOptions[int](), JSONStyle{EmptyAsNull: true}, `null`},
+		{FromValue(1), JSONStyle{}, `[1]`},
+		{FromValue(1), JSONStyle{SingleAsValue: true}, `1`},
+		{FromOptions(none), JSONStyle{SingleAsValue: true}, `[null]`},
+		{FromValues(1, 2), JSONStyle{EmptyAsNull: true, SingleAsValue: true}, `[1,2]`},
+	} {
+		bin, err := tc.e.MarshalJSONStyle(tc.style)
+		assert.NilError(t, err)
+		assert.Equal(t, tc.expected, string(bin), "style = %+v", tc.style)
+
+		var decoded Elastic[int]
+		assert.NilError(t, decoded.UnmarshalJSON(bin))
+		if tc.e.IsDefined() && tc.e.Len() > 0 {
+			assert.Assert(t, Equal(tc.e, decoded))
+		}
+	}
+
+	// A single value whose JSON form is an array does not round-trip with SingleAsValue.
+	bin, err := FromValue[any]([]any{1, 2}).MarshalJSONStyle(JSONStyle{SingleAsValue: true})
+	assert.NilError(t, err)
+	assert.Equal(t, `[1,2]`, string(bin))
+	var decoded Elastic[any]
+	assert.NilError(t, decoded.UnmarshalJSON(bin))
+	assert.Equal(t, 2, decoded.Len())
+
+	assert.Assert(t, FromOptions[int]().EmptyAsUndefined().IsUndefined())
+	assert.Assert(t, Null[int]().EmptyAsUndefined().IsNull())
+	assert.Assert(t, Equal(FromValue(1).EmptyAsUndefined(), FromValue(1)))
+}
+
+func TestElastic_MapIndexed_Reduce(t *testing.T) {
+	some := option.Some[int]
+	none := option.None[int]()
+
+	e := FromOptions(some(1), none, some(3))
+	mapped := e.MapIndexed(func(i, v int) int { return i*10 + v })
+	assert.Assert(t, Equal(mapped, FromOptions(some(1), none, some(23))))
+	assert.Assert(t, Equal(e, FromOptions(some(1), none, some(3))))
+	assert.Assert(t, Undefined[int]().MapIndexed(func(i, v int) int { return v }).IsUndefined())
+	assert.Assert(t, Null[int]().MapIndexed(func(i, v int) int { return v }).IsNull())
+
+	sum := func(acc int, opt option.Option[int]) int { return acc + opt.Value() }
+	assert.Equal(t, 104, Reduce(e, 100, sum))
+	assert.Equal(t, 100, Reduce(Undefined[int](), 100, sum))
+	assert.Equal(t, 100, Reduce(Null[int](), 100, sum))
+
+	countNone := func(acc int, opt option.Option[int]) int {
+		if opt.IsNone() {
+			acc++
+		}
+		return acc
+	}
+	assert.Equal(t, 1, Reduce(e, 0, countNone))
+}
+
+func TestEqualDeep(t *testing.T) {
+	l := FromOptions(option.Some([]int{1, 2}), option.None[[]int]())
+	assert.Assert(t, EqualDeep(l, FromOptions(option.Some([]int{1, 2}), option.None[[]int]())))
+	assert.Assert(t, !EqualDeep(l, FromOptions(option.Some([]int{1, 3}), option.None[[]int]())))
+	assert.Assert(t, !EqualDeep(l, FromOptions(option.Some([]int{1, 2}))))
+	assert.Assert(t, !EqualDeep(l, Null[[]int]()))
+	assert.Assert(t, EqualDeep(Null[map[string]int](), Null[map[string]int]()))
+	assert.Assert(t, !EqualDeep(Null[map[string]int](), Undefined[map[string]int]()))
+}
+
+func TestFlatten(t *testing.T) {
+	some := option.Some[int]
+	none := option.None[int]()
+
+	assert.Assert(t, Flatten(Undefined[Elastic[int]]()).IsUndefined())
+	assert.Assert(t, Flatten(Null[Elastic[int]]()).IsNull())
+	flattened := Flatten(FromOptions(
+		option.Some(FromValues(1, 2)),
+		option.None[Elastic[int]](),
+		option.Some(Undefined[int]()),
+		option.Some(Null[int]()),
+		option.Some(FromOptions(none, some(3))),
+		option.Some(FromOptions[int]()),
+	))
+	assert.Assert(t, Equal(flattened, FromOptions(some(1), some(2), none, none, none, some(3))))
+	assert.Assert(t, Flatten(FromOptions[Elastic[int]]()).IsDefined())
+}
+
+func TestFlattenOptions(t *testing.T) {
+	some := option.Some[int]
+	none := option.None[int]()
+
+	assert.Assert(t, FlattenOptions(Undefined[option.Options[int]]()).IsUndefined())
+	assert.Assert(t, FlattenOptions(Null[option.Options[int]]()).IsNull())
+	flattened := FlattenOptions(FromOptions(
+		option.Some(option.Options[int]{some(1), none}),
+		option.None[option.Options[int]](),
+		option.Some(option.Options[int]{}),
+		option.Some(option.Options[int]{some(2)}),
+	))
+	assert.Assert(t, Equal(flattened, FromOptions(some(1), none, none, some(2))))
+}
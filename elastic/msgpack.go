@@ -0,0 +1,37 @@
+package elastic
+
+import (
+	"encoding/json"
+
+	"github.com/ngicks/und/internal/transcode"
+)
+
+// portable methods that can be copied from github.com/ngicks/und/elastic into github.com/ngicks/und/sliceund/elastic
+
+// MarshalMsgpack implements the marshaler interface of github.com/vmihailenco/msgpack/v5.
+//
+// MessagePack has no undefined; both null and undefined are encoded as nil.
+// Undefined fields are omitted if `msgpack:",omitempty"` option is attached to those fields,
+// since Elastic[T] implements IsZero.
+// A defined Elastic[T] is encoded as an array of its values, the MessagePack counterpart of what MarshalJSON returns.
+func (e Elastic[T]) MarshalMsgpack() ([]byte, error) {
+	bin, err := e.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return transcode.JSONToMsgpack(nil, bin)
+}
+
+// UnmarshalMsgpack implements the unmarshaler interface of github.com/vmihailenco/msgpack/v5.
+//
+// github.com/vmihailenco/msgpack/v5 does not call UnmarshalMsgpack for nil but zeroes e instead,
+// so nil is decoded as undefined just like a missing key; null does not survive a round trip.
+// Other data is transcoded into JSON, then decoded as UnmarshalJSON does;
+// like UnmarshalJSON, it accepts either an array of (null | T) or a single T.
+func (e *Elastic[T]) UnmarshalMsgpack(data []byte) error {
+	bin, err := transcode.MsgpackToJSON(nil, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bin, e)
+}
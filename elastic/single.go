@@ -0,0 +1,100 @@
+package elastic
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMultipleValues is returned when Single[T] is unmarshaled from more than one value.
+	ErrMultipleValues = errors.New("multiple values")
+)
+
+var (
+	_ json.Marshaler   = Single[any]{}
+	_ json.Unmarshaler = (*Single[any])(nil)
+	_ xml.Unmarshaler  = (*Single[any])(nil)
+)
+
+// Single is Elastic[T] which rejects more than one value on unmarshaling.
+//
+// It is for fields mapped as single-valued, where a JSON array with multiple elements
+// indicates a broken document rather than something to be silently truncated to its first element.
+// Unmarshaling such an array fails with an error wrapping [ErrMultipleValues].
+// A single value, an array with at most one element, null and absence are accepted as Elastic[T] accepts them.
+// The rule applies to every unmarshaler Elastic[T] implements, e.g. UnmarshalXML, UnmarshalCBOR and UnmarshalGQL.
+// Other methods are promoted from the embedded Elastic[T];
+// undefined fields are omitted by `json:",omitzero"`.
+type Single[T any] struct {
+	Elastic[T]
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It returns an error wrapping [ErrMultipleValues] if data is an array with more than one element.
+func (s *Single[T]) UnmarshalJSON(data []byte) error {
+	var e Elastic[T]
+	if err := e.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalYAML implements the yaml unmarshaler interface in the same way as [Elastic.UnmarshalYAML].
+// It returns an error wrapping [ErrMultipleValues] if the node is a sequence with more than one element.
+func (s *Single[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var e Elastic[T]
+	if err := e.UnmarshalYAML(unmarshal); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalXML implements xml.Unmarshaler in the same way as [Elastic.UnmarshalXML].
+// It returns an error wrapping [ErrMultipleValues] if the element repeats.
+func (s *Single[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	e := s.Elastic
+	if err := e.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalCBOR implements the unmarshaler interface of github.com/fxamacker/cbor/v2 in the same way as [Elastic.UnmarshalCBOR].
+// It returns an error wrapping [ErrMultipleValues] if data is an array with more than one element.
+func (s *Single[T]) UnmarshalCBOR(data []byte) error {
+	var e Elastic[T]
+	if err := e.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalMsgpack implements the unmarshaler interface of github.com/vmihailenco/msgpack/v5 in the same way as [Elastic.UnmarshalMsgpack].
+// It returns an error wrapping [ErrMultipleValues] if data is an array with more than one element.
+func (s *Single[T]) UnmarshalMsgpack(data []byte) error {
+	var e Elastic[T]
+	if err := e.UnmarshalMsgpack(data); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalGQL implements the unmarshaler interface of github.com/99designs/gqlgen in the same way as [Elastic.UnmarshalGQL].
+// It returns an error wrapping [ErrMultipleValues] if v is a slice with more than one element.
+func (s *Single[T]) UnmarshalGQL(v any) error {
+	var e Elastic[T]
+	if err := e.UnmarshalGQL(v); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+func (s *Single[T]) set(e Elastic[T]) error {
+	if e.Len() > 1 {
+		return fmt.Errorf("%w: %T has %d values", ErrMultipleValues, *s, e.Len())
+	}
+	s.Elastic = e
+	return nil
+}
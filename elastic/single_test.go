@@ -0,0 +1,44 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und/option"
+	"gotest.tools/v3/assert"
+)
+
+func TestSingle(t *testing.T) {
+	type sample struct {
+		V Single[int] `json:"v,omitzero"`
+	}
+
+	for _, tc := range []struct {
+		input    string
+		expected Elastic[int]
+	}{
+		{`{}`, Undefined[int]()},
+		{`{"v":null}`, Null[int]()},
+		{`{"v":5}`, FromValue(5)},
+		{`{"v":[5]}`, FromValue(5)},
+		{`{"v":[null]}`, FromOptions(option.None[int]())},
+		{`{"v":[]}`, FromOptions[int]()},
+	} {
+		var s sample
+		assert.NilError(t, json.Unmarshal([]byte(tc.input), &s), "input = %s", tc.input)
+		assert.Assert(t, Equal(s.V.Elastic, tc.expected), "input = %s", tc.input)
+	}
+
+	s := sample{V: Single[int]{FromValue(1)}}
+	err := json.Unmarshal([]byte(`{"v":[5,null]}`), &s)
+	assert.ErrorIs(t, err, ErrMultipleValues)
+	assert.ErrorContains(t, err, "elastic.Single[int] has 2 values")
+	assert.Assert(t, Equal(s.V.Elastic, FromValue(1)))
+
+	bin, err := json.Marshal(s)
+	assert.NilError(t, err)
+	assert.Equal(t, `{"v":[1]}`, string(bin))
+	bin, err = json.Marshal(sample{})
+	assert.NilError(t, err)
+	assert.Equal(t, `{}`, string(bin))
+}
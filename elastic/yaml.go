@@ -0,0 +1,46 @@
+package elastic
+
+import "github.com/ngicks/und/option"
+
+// portable methods that can be copied from github.com/ngicks/und/elastic into github.com/ngicks/und/sliceund/elastic
+
+// MarshalYAML implements the yaml marshaler interface
+// of gopkg.in/yaml.v3 (also gopkg.in/yaml.v2 and github.com/goccy/go-yaml).
+//
+// A defined Elastic[T] is always marshaled as a sequence.
+// Both null and undefined are marshaled as null.
+func (e Elastic[T]) MarshalYAML() (any, error) {
+	return e.Unwrap().MarshalYAML()
+}
+
+// UnmarshalYAML implements the yaml unmarshaler interface
+// of gopkg.in/yaml.v2 (also gopkg.in/yaml.v3 as an obsolete unmarshaler, and github.com/goccy/go-yaml).
+//
+// Like UnmarshalJSON, it accepts either a sequence of (null | T) or a single T.
+// Be cautious that gopkg.in/yaml.v2 and gopkg.in/yaml.v3 do not call UnmarshalYAML for null;
+// the field is left untouched and stays undefined.
+// Thus with those decoders an explicit null can not be told apart from a missing key.
+func (e *Elastic[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	// Decoding into []*T rather than option.Options[T]:
+	// yaml decoders drop null sequence elements if they can not be stored into the element type.
+	var ps *[]*T
+	err := unmarshal(&ps)
+	// might be T is []U, and this fails
+	// since it should've been [[...data...],[...data...]]
+	if err == nil {
+		if ps == nil {
+			*e = Null[T]()
+		} else {
+			*e = FromPointers(*ps...)
+		}
+		return nil
+	}
+
+	var opt option.Option[T]
+	err = opt.UnmarshalYAML(unmarshal)
+	if err != nil {
+		return err
+	}
+	*e = FromOptions(opt)
+	return nil
+}
@@ -0,0 +1,51 @@
+package und_test
+
+import (
+	"flag"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestFlag(t *testing.T) {
+	parse := func(null string, args ...string) (und.Und[int], error) {
+		var u und.Und[int]
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		f := und.NewFlag(&u, strconv.Atoi)
+		f.Null = null
+		fs.Var(f, "n", "")
+		err := fs.Parse(args)
+		return u, err
+	}
+
+	for _, tc := range []struct {
+		null     string
+		args     []string
+		expected und.Und[int]
+	}{
+		{"", nil, und.Undefined[int]()},
+		{"", []string{"-n", ""}, und.Null[int]()},
+		{"", []string{"-n=12"}, und.Defined(12)},
+		{"null", []string{"-n", "null"}, und.Null[int]()},
+		{"null", []string{"-n", "-1"}, und.Defined(-1)},
+	} {
+		u, err := parse(tc.null, tc.args...)
+		assert.NilError(t, err)
+		assert.Assert(t, und.Equal(u, tc.expected), "args = %#v", tc.args)
+	}
+
+	_, err := parse("null", "-n", "")
+	assert.Assert(t, err != nil)
+
+	u := und.Null[int]()
+	f := und.NewFlag(&u, strconv.Atoi)
+	f.Null = "null"
+	assert.Equal(t, f.String(), "null")
+	u = und.Defined(5)
+	assert.Equal(t, f.String(), "5")
+	assert.Assert(t, und.Equal(f.Get().(und.Und[int]), u))
+}
@@ -0,0 +1,29 @@
+package und
+
+// GetMap gets a value associated with key.
+// If key has a value, the Und is defined wrapping the value, even if the value is zero.
+// Otherwise it returns undefined Und.
+//
+// GetMap is like option.GetMap but for Und.
+func GetMap[M ~map[K]V, K comparable, V any](m M, key K) Und[V] {
+	v, ok := m[key]
+	if !ok {
+		return Undefined[V]()
+	}
+	return Defined(v)
+}
+
+// GetMapPointer gets a value associated with key from a map of pointers.
+// It returns undefined Und if m has no value for key, null Und if the value is nil,
+// otherwise defined Und wrapping the pointed value.
+func GetMapPointer[M ~map[K]*V, K comparable, V any](m M, key K) Und[V] {
+	v, ok := m[key]
+	switch {
+	case !ok:
+		return Undefined[V]()
+	case v == nil:
+		return Null[V]()
+	default:
+		return Defined(*v)
+	}
+}
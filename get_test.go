@@ -0,0 +1,21 @@
+package und_test
+
+import (
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestGetMap(t *testing.T) {
+	m := map[string]int{"foo": 0, "bar": 2}
+	assert.Assert(t, und.Equal(und.GetMap(m, "foo"), und.Defined(0)))
+	assert.Assert(t, und.Equal(und.GetMap(m, "bar"), und.Defined(2)))
+	assert.Assert(t, und.GetMap(m, "baz").IsUndefined())
+
+	two := 2
+	mp := map[string]*int{"foo": nil, "bar": &two}
+	assert.Assert(t, und.GetMapPointer(mp, "foo").IsNull())
+	assert.Assert(t, und.Equal(und.GetMapPointer(mp, "bar"), und.Defined(2)))
+	assert.Assert(t, und.GetMapPointer(mp, "baz").IsUndefined())
+}
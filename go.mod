@@ -4,6 +4,19 @@ go 1.23
 
 toolchain go1.23.0
 
-require gotest.tools/v3 v3.5.1
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/invopop/jsonschema v0.13.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	gotest.tools/v3 v3.5.1
+)
 
-require github.com/google/go-cmp v0.5.9 // indirect
+require (
+	github.com/bahlo/generic-list-go v0.2.0 // indirect
+	github.com/buger/jsonparser v1.1.1 // indirect
+	github.com/google/go-cmp v0.5.9 // indirect
+	github.com/mailru/easyjson v0.7.7 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
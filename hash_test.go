@@ -0,0 +1,28 @@
+package und_test
+
+import (
+	"hash/maphash"
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func hashString(h *maphash.Hash, s string) {
+	_, _ = h.WriteString(s)
+}
+
+func TestUnd_HashFunc(t *testing.T) {
+	seed := maphash.MakeSeed()
+
+	undefined := und.Undefined[string]().HashFunc(seed, hashString)
+	null := und.Null[string]().HashFunc(seed, hashString)
+	zero := und.Defined("").HashFunc(seed, hashString)
+	foo := und.Defined("foo").HashFunc(seed, hashString)
+
+	assert.Assert(t, undefined != null)
+	assert.Assert(t, null != zero)
+	assert.Assert(t, undefined != zero)
+	assert.Assert(t, zero != foo)
+	assert.Equal(t, foo, und.Defined("foo").HashFunc(seed, hashString))
+}
@@ -0,0 +1,32 @@
+package option
+
+import (
+	"encoding/json"
+
+	"github.com/ngicks/und/internal/transcode"
+)
+
+// MarshalCBOR implements the marshaler interface of github.com/fxamacker/cbor/v2.
+//
+// o is encoded as the CBOR counterpart of what MarshalJSON returns:
+// None is encoded as null, and some value as its JSON representation transcoded into CBOR.
+func (o Option[T]) MarshalCBOR() ([]byte, error) {
+	bin, err := o.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return transcode.JSONToCBOR(nil, bin)
+}
+
+// UnmarshalCBOR implements the unmarshaler interface of github.com/fxamacker/cbor/v2.
+//
+// data is transcoded into JSON, then decoded as UnmarshalJSON does.
+// Both null and undefined become None.
+// Byte strings are decoded as base64 encoded strings, and tags are ignored.
+func (o *Option[T]) UnmarshalCBOR(data []byte) error {
+	bin, err := transcode.CBORToJSON(nil, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bin, o)
+}
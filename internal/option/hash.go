@@ -0,0 +1,27 @@
+package option
+
+import "hash/maphash"
+
+// HashFunc returns a hash value of o, seeded by seed.
+// The hash value takes o's state (some or none) into account.
+//
+// hashT must write t into h. It is only called if o is some.
+// Equal values must be written identically by hashT, as is always the case for hash functions.
+func (o Option[T]) HashFunc(seed maphash.Seed, hashT func(h *maphash.Hash, t T)) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	o.WriteHash(&h, hashT)
+	return h.Sum64()
+}
+
+// WriteHash writes o's state and, if o is some, its value by calling hashT, into h.
+//
+// WriteHash is useful for composing a hash value of a type that contains Option[T].
+func (o Option[T]) WriteHash(h *maphash.Hash, hashT func(h *maphash.Hash, t T)) {
+	if o.IsNone() {
+		_ = h.WriteByte(0)
+		return
+	}
+	_ = h.WriteByte(1)
+	hashT(h, o.v)
+}
@@ -0,0 +1,12 @@
+package option
+
+// JSONSchemaAlias implements the alias interface of github.com/invopop/jsonschema.
+//
+// Reflectors use the type of the returned value, a *T, in place of Option[T],
+// so generated schemas describe T rather than an opaque object.
+// Only the type is replaced; the reflector decides nullability per field from struct tags,
+// which a type can not override without returning a *jsonschema.Schema, and this module does not depend on that package.
+// Mark fields with `jsonschema:"nullable"` to allow null.
+func (o Option[T]) JSONSchemaAlias() any {
+	return new(T)
+}
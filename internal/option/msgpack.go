@@ -0,0 +1,31 @@
+package option
+
+import (
+	"encoding/json"
+
+	"github.com/ngicks/und/internal/transcode"
+)
+
+// MarshalMsgpack implements the marshaler interface of github.com/vmihailenco/msgpack/v5.
+//
+// o is encoded as the MessagePack counterpart of what MarshalJSON returns:
+// None is encoded as nil, and some value as its JSON representation transcoded into MessagePack.
+func (o Option[T]) MarshalMsgpack() ([]byte, error) {
+	bin, err := o.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return transcode.JSONToMsgpack(nil, bin)
+}
+
+// UnmarshalMsgpack implements the unmarshaler interface of github.com/vmihailenco/msgpack/v5.
+//
+// data is transcoded into JSON, then decoded as UnmarshalJSON does.
+// Binaries are decoded as base64 encoded strings, and timestamps as RFC 3339 strings.
+func (o *Option[T]) UnmarshalMsgpack(data []byte) error {
+	bin, err := transcode.MsgpackToJSON(nil, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bin, o)
+}
@@ -1,10 +1,12 @@
 package option
 
 import (
+	"cmp"
 	"database/sql"
 	"encoding/json"
 	"encoding/xml"
 	"log/slog"
+	"reflect"
 )
 
 var (
@@ -62,6 +64,9 @@ func WrapPointer[T any](t *T) Option[*T] {
 	return None[*T]()
 }
 
+// IsZero is an alias for IsNone.
+// For Go 1.24 or later version, None Option[T] struct fields are omitted by [json.Marshal] (or similar functions)
+// if `json:",omitzero"` option is attached to those fields.
 func (o Option[T]) IsZero() bool {
 	return o.IsNone()
 }
@@ -93,6 +98,22 @@ func (o Option[T]) Get() (T, bool) {
 	return o.Value(), o.IsSome()
 }
 
+// ValueOr returns o's value if o is some, otherwise def.
+func (o Option[T]) ValueOr(def T) T {
+	if o.IsSome() {
+		return o.v
+	}
+	return def
+}
+
+// ValueOrElse returns o's value if o is some, otherwise calls f and returns the result.
+func (o Option[T]) ValueOrElse(f func() T) T {
+	if o.IsSome() {
+		return o.v
+	}
+	return f()
+}
+
 // Pointer transforms o to *T, the plain conventional Go representation of an optional value.
 // The value is copied by assignment before returned from Pointer.
 func (o Option[T]) Pointer() *T {
@@ -103,6 +124,19 @@ func (o Option[T]) Pointer() *T {
 	return &t
 }
 
+// Ref returns a pointer to o's internal value, or nil if o is None.
+// Unlike [Option.Pointer], the value is not copied.
+//
+// The returned pointer aliases o: writes through it modify o,
+// and it no longer points to o's value after o is reassigned, e.g. o is set to None or other Some.
+// Ref is meant for large T where copying made by Pointer is expensive.
+func (o *Option[T]) Ref() *T {
+	if o.IsNone() {
+		return nil
+	}
+	return &o.v
+}
+
 // CloneFunc clones o using the cloneT function.
 func (o Option[T]) CloneFunc(cloneT func(T) T) Option[T] {
 	return o.Map(func(t T) T {
@@ -126,6 +160,37 @@ func Equal[T comparable](l, r Option[T]) bool {
 	return l.EqualFunc(r, func(i, j T) bool { return i == j })
 }
 
+// EqualDeep is like [Equal] but tests equality of values by [reflect.DeepEqual].
+// Unlike [Equal], EqualDeep accepts any T, including uncomparable ones, and never panics.
+//
+// Be cautious that reflect.DeepEqual does not respect Equal methods that T may implement.
+func EqualDeep[T any](l, r Option[T]) bool {
+	return l.EqualFunc(r, func(i, j T) bool { return reflect.DeepEqual(i, j) })
+}
+
+// CompareFunc compares o and other.
+// None is considered less than any some value, and 2 None values are equal.
+// If both are some, it returns the result of cmp called with their values.
+//
+// The result is -1, 0 or +1 in the same meaning as [cmp.Compare] if cmp does so.
+func (o Option[T]) CompareFunc(other Option[T], cmp func(i, j T) int) int {
+	switch {
+	case o.IsNone() && other.IsNone():
+		return 0
+	case o.IsNone():
+		return -1
+	case other.IsNone():
+		return +1
+	}
+	return cmp(o.v, other.v)
+}
+
+// Compare compares l and r.
+// None sorts before any some value. Values of some options are compared by [cmp.Compare].
+func Compare[T cmp.Ordered](l, r Option[T]) int {
+	return l.CompareFunc(r, cmp.Compare[T])
+}
+
 func (o Option[T]) MarshalJSON() ([]byte, error) {
 	if o.IsNone() {
 		// same as bytes.Clone.
@@ -162,7 +227,14 @@ func (o Option[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return e.EncodeElement(o.Value(), start)
 }
 
+// UnmarshalXML implements xml.Unmarshaler.
+// An element with xsi:nil="true" attribute is unmarshaled as none.
 func (o *Option[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if isXMLNil(start) {
+		*o = None[T]()
+		return d.Skip()
+	}
+
 	var t T
 	err := d.DecodeElement(&t, &start)
 	if err != nil {
@@ -175,6 +247,21 @@ func (o *Option[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	return nil
 }
 
+const xmlSchemaInstance = "http://www.w3.org/2001/XMLSchema-instance"
+
+// isXMLNil reports whether start has xsi:nil="true" attribute.
+// The prefix is also accepted without the namespace declaration.
+func isXMLNil(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" &&
+			(attr.Name.Space == xmlSchemaInstance || attr.Name.Space == "xsi") &&
+			(attr.Value == "true" || attr.Value == "1") {
+			return true
+		}
+	}
+	return false
+}
+
 // LogValue implements slog.LogValuer
 func (o Option[T]) LogValue() slog.Value {
 	if o.IsNone() {
@@ -241,6 +328,42 @@ func Map[T, U any](o Option[T], f func(T) U) Option[U] {
 	return None[U]()
 }
 
+// FilterMap filters and maps o in one pass.
+// It returns Some[U] wrapping the value f returned if o is some and f reports true.
+// Otherwise it returns None[U].
+func FilterMap[T, U any](o Option[T], f func(T) (U, bool)) Option[U] {
+	if o.IsNone() {
+		return None[U]()
+	}
+	if u, ok := f(o.Value()); ok {
+		return Some(u)
+	}
+	return None[U]()
+}
+
+// MapErr is like [Map] but f may fail.
+// If o is some, it calls f with o's value and returns Some[U] wrapping the result, or the error f returned.
+// Otherwise it returns None[U] without calling f.
+func MapErr[T, U any](o Option[T], f func(T) (U, error)) (Option[U], error) {
+	if o.IsNone() {
+		return None[U](), nil
+	}
+	u, err := f(o.Value())
+	if err != nil {
+		return None[U](), err
+	}
+	return Some(u), nil
+}
+
+// AndThenErr calls f with value of o if o is some, otherwise returns None[U].
+// Unlike [Option.AndThen], f can change the type and may fail.
+func AndThenErr[T, U any](o Option[T], f func(T) (Option[U], error)) (Option[U], error) {
+	if o.IsNone() {
+		return None[U](), nil
+	}
+	return f(o.Value())
+}
+
 // Map returns Option[T] whose inner value is o's value mapped by f if o is some.
 // Otherwise it returns None[T].
 func (o Option[T]) Map(f func(v T) T) Option[T] {
@@ -316,3 +439,17 @@ func (o Option[T]) Xor(u Option[T]) Option[T] {
 	}
 	return None[T]()
 }
+
+// FirstSome returns the first some Option in opts.
+// If opts has no some Option, it returns None[T].
+//
+// FirstSome is like [cmp.Or] but for Option[T].
+// It is equivalent to chaining [Option.Or] over opts without intermediate values.
+func FirstSome[T any](opts ...Option[T]) Option[T] {
+	for _, o := range opts {
+		if o.IsSome() {
+			return o
+		}
+	}
+	return None[T]()
+}
@@ -0,0 +1,161 @@
+package option
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errTOMLNull = errors.New("TOML has no null")
+
+// MarshalTOML implements the marshaler interface of github.com/BurntSushi/toml.
+//
+// The value is encoded as a TOML value. Supported types are
+// booleans, integers, floats, strings, time.Time (as an offset date-time),
+// types implementing encoding.TextMarshaler (as strings),
+// and arrays, slices and string-keyed maps (as inline tables) of those.
+// Structs are not supported; use maps to encode tables.
+//
+// TOML has no null; MarshalTOML returns an error for None.
+// Attach `toml:",omitempty"` option to fields so that None (the zero value) is omitted.
+func (o Option[T]) MarshalTOML() ([]byte, error) {
+	if o.IsNone() {
+		return nil, errTOMLNull
+	}
+	return appendTOML(nil, reflect.ValueOf(o.v))
+}
+
+// UnmarshalTOML implements the unmarshaler interface of github.com/BurntSushi/toml.
+//
+// data is a value decoded by the decoder, e.g. int64, string or map[string]any.
+// data is stored as it is if it is assignable to T, otherwise it is converted through JSON.
+// Thus tables can be decoded into structs, whose keys are matched by JSON field names.
+// A missing key does not call UnmarshalTOML and leaves o untouched.
+func (o *Option[T]) UnmarshalTOML(data any) error {
+	t, err := fromAny[T](data)
+	if err != nil {
+		return err
+	}
+	*o = Some(t)
+	return nil
+}
+
+var (
+	timeTy          = reflect.TypeFor[time.Time]()
+	textMarshalerTy = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func appendTOML(dst []byte, rv reflect.Value) ([]byte, error) {
+	if !rv.IsValid() {
+		return dst, errTOMLNull
+	}
+	if rv.Type() == timeTy {
+		return rv.Interface().(time.Time).AppendFormat(dst, time.RFC3339Nano), nil
+	}
+	if rv.Type().Implements(textMarshalerTy) {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return dst, errTOMLNull
+		}
+		text, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return dst, err
+		}
+		return appendTOMLString(dst, string(text)), nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.AppendBool(dst, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(dst, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return dst, fmt.Errorf("%d overflows TOML integer", rv.Uint())
+		}
+		return strconv.AppendUint(dst, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return appendTOMLFloat(dst, rv.Float(), rv.Type().Bits()), nil
+	case reflect.String:
+		return appendTOMLString(dst, rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return dst, errTOMLNull
+		}
+		dst = append(dst, '[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				dst = append(dst, ", "...)
+			}
+			var err error
+			dst, err = appendTOML(dst, rv.Index(i))
+			if err != nil {
+				return dst, err
+			}
+		}
+		return append(dst, ']'), nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return dst, fmt.Errorf("TOML table keys must be strings, but is %s", rv.Type().Key())
+		}
+		if rv.IsNil() {
+			return dst, errTOMLNull
+		}
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(i, j reflect.Value) int { return strings.Compare(i.String(), j.String()) })
+		dst = append(dst, '{')
+		for i, k := range keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(dst, ' ')
+			dst = appendTOMLString(dst, k.String())
+			dst = append(dst, " = "...)
+			var err error
+			dst, err = appendTOML(dst, rv.MapIndex(k))
+			if err != nil {
+				return dst, err
+			}
+		}
+		if len(keys) > 0 {
+			dst = append(dst, ' ')
+		}
+		return append(dst, '}'), nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return dst, errTOMLNull
+		}
+		return appendTOML(dst, rv.Elem())
+	}
+	return dst, fmt.Errorf("unsupported type for TOML value: %s", rv.Type())
+}
+
+func appendTOMLFloat(dst []byte, f float64, bits int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(dst, "nan"...)
+	case math.IsInf(f, 1):
+		return append(dst, "inf"...)
+	case math.IsInf(f, -1):
+		return append(dst, "-inf"...)
+	}
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, f, 'g', -1, bits)
+	// TOML floats must have a fractional part or an exponent.
+	if !slices.ContainsFunc(dst[start:], func(b byte) bool { return b == '.' || b == 'e' }) {
+		dst = append(dst, ".0"...)
+	}
+	return dst
+}
+
+// appendTOMLString appends s as a TOML basic string.
+// JSON strings are valid TOML basic strings.
+func appendTOMLString(dst []byte, s string) []byte {
+	bin, _ := json.Marshal(s)
+	return append(dst, bin...)
+}
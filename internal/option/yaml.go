@@ -0,0 +1,28 @@
+package option
+
+// MarshalYAML implements the yaml marshaler interface
+// of gopkg.in/yaml.v3 (also gopkg.in/yaml.v2 and github.com/goccy/go-yaml).
+//
+// None is marshaled as null.
+func (o Option[T]) MarshalYAML() (any, error) {
+	if o.IsNone() {
+		return nil, nil
+	}
+	return o.v, nil
+}
+
+// UnmarshalYAML implements the yaml unmarshaler interface
+// of gopkg.in/yaml.v2 (also gopkg.in/yaml.v3 as an obsolete unmarshaler, and github.com/goccy/go-yaml).
+//
+// Be cautious that gopkg.in/yaml.v2 and gopkg.in/yaml.v3 do not call UnmarshalYAML for null;
+// the field is left untouched and stays None.
+func (o *Option[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	// decoding into a pointer so that null can be told apart from values.
+	var p *T
+	err := unmarshal(&p)
+	if err != nil {
+		return err
+	}
+	*o = FromPointer(p)
+	return nil
+}
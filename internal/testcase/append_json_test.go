@@ -0,0 +1,65 @@
+package testcase_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+type jsonAppender interface {
+	json.Marshaler
+	AppendJSON(dst []byte) ([]byte, error)
+}
+
+var (
+	_ jsonAppender = option.Option[any]{}
+	_ jsonAppender = und.Und[any]{}
+	_ jsonAppender = sliceund.Und[any]{}
+	_ jsonAppender = elastic.Elastic[any]{}
+	_ jsonAppender = sliceelastic.Elastic[any]{}
+)
+
+func TestAppendJSON(t *testing.T) {
+	opts := []option.Option[string]{option.None[string](), option.Some("foo"), option.Some("<escaped>")}
+	for _, a := range []jsonAppender{
+		und.Undefined[string](),
+		und.Null[string](),
+		und.Defined("foo"),
+		sliceund.Undefined[string](),
+		sliceund.Null[string](),
+		sliceund.Defined("foo"),
+		elastic.Undefined[string](),
+		elastic.Null[string](),
+		elastic.FromOptions[string](),
+		elastic.FromOptions(opts...),
+		elastic.FromUnd(und.Defined(option.Options[string](nil))),
+		sliceelastic.Undefined[string](),
+		sliceelastic.Null[string](),
+		sliceelastic.FromOptions[string](),
+		sliceelastic.FromOptions(opts...),
+	} {
+		expected, err := a.MarshalJSON()
+		assert.NilError(t, err)
+		bin, err := a.AppendJSON([]byte(`prefix`))
+		assert.NilError(t, err)
+		assert.Equal(t, string(bin), "prefix"+string(expected))
+	}
+
+	for _, a := range []jsonAppender{
+		und.Defined(math.NaN()),
+		sliceund.Defined(math.NaN()),
+		elastic.FromOptions(option.Some(1.0), option.Some(math.NaN())),
+		sliceelastic.FromOptions(option.Some(1.0), option.Some(math.NaN())),
+	} {
+		bin, err := a.AppendJSON([]byte(`prefix`))
+		assert.Assert(t, err != nil)
+		assert.Equal(t, string(bin), "prefix")
+	}
+}
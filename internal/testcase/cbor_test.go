@@ -0,0 +1,121 @@
+package testcase_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+// cborMarshaler and cborUnmarshaler are same as interfaces defined in github.com/fxamacker/cbor/v2.
+type cborMarshaler interface {
+	MarshalCBOR() ([]byte, error)
+}
+
+type cborUnmarshaler interface {
+	UnmarshalCBOR([]byte) error
+}
+
+var (
+	_ cborMarshaler = option.Option[any]{}
+	_ cborMarshaler = und.Und[any]{}
+	_ cborMarshaler = sliceund.Und[any]{}
+	_ cborMarshaler = elastic.Elastic[any]{}
+	_ cborMarshaler = sliceelastic.Elastic[any]{}
+)
+
+var (
+	_ cborUnmarshaler = (*option.Option[any])(nil)
+	_ cborUnmarshaler = (*und.Und[any])(nil)
+	_ cborUnmarshaler = (*sliceund.Und[any])(nil)
+	_ cborUnmarshaler = (*elastic.Elastic[any])(nil)
+	_ cborUnmarshaler = (*sliceelastic.Elastic[any])(nil)
+)
+
+func TestCBOR(t *testing.T) {
+	type sample struct {
+		A und.Und[int]          `json:"a,omitzero"`
+		B sliceund.Und[string]  `json:"b,omitempty"`
+		C option.Option[[]byte] `json:"c"`
+	}
+	for _, tc := range []struct {
+		m        cborMarshaler
+		expected string
+	}{
+		{option.Some(5), "05"},
+		{option.None[int](), "f6"},
+		{und.Defined(-1), "20"},
+		{und.Null[int](), "f6"},
+		{und.Undefined[int](), "f7"},
+		{sliceund.Defined("a"), "6161"},
+		{sliceund.Null[string](), "f6"},
+		{sliceund.Undefined[string](), "f7"},
+		{elastic.FromOptions(option.Some(1), option.None[int]()), "8201f6"},
+		{elastic.Null[int](), "f6"},
+		{elastic.Undefined[int](), "f7"},
+		{sliceelastic.FromValue(1), "8101"},
+		{sliceelastic.Null[int](), "f6"},
+		{sliceelastic.Undefined[int](), "f7"},
+		// {"a":1,"c":"AQI="}
+		{und.Defined(sample{A: und.Defined(1), C: option.Some([]byte{1, 2})}), "a26161016163644151493d"},
+	} {
+		bin, err := tc.m.MarshalCBOR()
+		assert.NilError(t, err)
+		assert.Equal(t, tc.expected, hex.EncodeToString(bin), "value = %#v", tc.m)
+	}
+
+	decode := func(s string) []byte {
+		bin, _ := hex.DecodeString(s)
+		return bin
+	}
+
+	var s valueSet[int]
+	for _, u := range []cborUnmarshaler{&s.Opt, &s.Und, &s.SliceUnd} {
+		assert.NilError(t, u.UnmarshalCBOR(decode("05")))
+	}
+	assert.Assert(t, option.Equal(s.Opt, option.Some(5)))
+	assert.Assert(t, und.Equal(s.Und, und.Defined(5)))
+	assert.Assert(t, sliceund.Equal(s.SliceUnd, sliceund.Defined(5)))
+
+	for _, u := range []cborUnmarshaler{&s.Opt, &s.Und, &s.SliceUnd} {
+		assert.NilError(t, u.UnmarshalCBOR(decode("f6")))
+	}
+	assert.Assert(t, s.Opt.IsNone())
+	assert.Assert(t, s.Und.IsNull())
+	assert.Assert(t, s.SliceUnd.IsNull())
+
+	s = valueSet[int]{Opt: option.Some(1), Und: und.Defined(1), SliceUnd: sliceund.Defined(1)}
+	for _, u := range []cborUnmarshaler{&s.Opt, &s.Und, &s.SliceUnd} {
+		assert.NilError(t, u.UnmarshalCBOR(decode("f7")))
+	}
+	assert.Assert(t, s.Opt.IsNone())
+	assert.Assert(t, s.Und.IsUndefined())
+	assert.Assert(t, s.SliceUnd.IsUndefined())
+
+	var (
+		e  elastic.Elastic[int]
+		se sliceelastic.Elastic[int]
+	)
+	assert.NilError(t, e.UnmarshalCBOR(decode("8201f6")))
+	assert.Assert(t, elastic.Equal(e, elastic.FromOptions(option.Some(1), option.None[int]())))
+	assert.NilError(t, se.UnmarshalCBOR(decode("01")))
+	assert.Assert(t, sliceelastic.Equal(se, sliceelastic.FromValue(1)))
+	assert.NilError(t, e.UnmarshalCBOR(decode("f7")))
+	assert.Assert(t, e.IsUndefined())
+	assert.NilError(t, se.UnmarshalCBOR(decode("f6")))
+	assert.Assert(t, se.IsNull())
+
+	var decoded und.Und[sample]
+	assert.NilError(t, decoded.UnmarshalCBOR(decode("a26161016163644151493d")))
+	assert.Assert(t, und.Equal(decoded.Value().A, und.Defined(1)))
+	assert.Assert(t, decoded.Value().B.IsUndefined())
+	assert.DeepEqual(t, []byte{1, 2}, decoded.Value().C.Value())
+
+	assert.Assert(t, s.Und.UnmarshalCBOR(decode("6161")) != nil)
+	assert.Assert(t, s.Und.UnmarshalCBOR(decode("0500")) != nil)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ngicks/und/option"
 	"github.com/ngicks/und/sliceund"
 	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
 )
 
 func TestCloner(t *testing.T) {
@@ -97,3 +98,68 @@ func testDifference[T any](a, b T) (bool, unsafe.Pointer, unsafe.Pointer) {
 	bp := reflect.ValueOf(b).UnsafePointer()
 	return ap != bp, ap, bp
 }
+
+// clonedSlice is a slice which implements option.Cloner.
+type clonedSlice []int
+
+func (s clonedSlice) Clone() clonedSlice {
+	return slices.Clone(s)
+}
+
+var (
+	_ option.Cloner[option.Option[any]]        = option.Option[any]{}
+	_ option.Cloner[option.Options[any]]       = option.Options[any]{}
+	_ option.Cloner[und.Und[any]]              = und.Und[any]{}
+	_ option.Cloner[sliceund.Und[any]]         = sliceund.Und[any]{}
+	_ option.Cloner[elastic.Elastic[any]]      = elastic.Elastic[any]{}
+	_ option.Cloner[sliceelastic.Elastic[any]] = sliceelastic.Elastic[any]{}
+	_ option.Cloner[clonedSlice]               = clonedSlice{}
+)
+
+func TestClone(t *testing.T) {
+	sameArray := func(l, r clonedSlice) bool {
+		return unsafe.SliceData(l) == unsafe.SliceData(r)
+	}
+
+	o := option.Some(clonedSlice{1, 2})
+	assert.Assert(t, !sameArray(o.Value(), o.Clone().Value()))
+	assert.DeepEqual(t, o.Value(), o.Clone().Value())
+
+	u := und.Defined(clonedSlice{1, 2})
+	assert.Assert(t, !sameArray(u.Value(), u.Clone().Value()))
+	assert.Assert(t, u.Clone().IsDefined())
+	assert.Assert(t, und.Null[clonedSlice]().Clone().IsNull())
+	assert.Assert(t, und.Undefined[clonedSlice]().Clone().IsUndefined())
+
+	su := sliceund.Defined(clonedSlice{1, 2})
+	assert.Assert(t, !sameArray(su.Value(), su.Clone().Value()))
+	assert.Assert(t, &su[0] != &su.Clone()[0])
+	assert.Assert(t, sliceund.Null[clonedSlice]().Clone().IsNull())
+	assert.Assert(t, sliceund.Undefined[clonedSlice]().Clone().IsUndefined())
+
+	// nested und types are cloned recursively since those implement option.Cloner.
+	nested := und.Defined(sliceund.Defined(clonedSlice{1, 2}))
+	assert.Assert(t, !sameArray(nested.Value().Value(), nested.Clone().Value().Value()))
+
+	opts := []option.Option[clonedSlice]{option.Some(clonedSlice{1, 2}), option.None[clonedSlice]()}
+	clonedOpts := option.CloneOptions(opts)
+	assert.Assert(t, !sameArray(opts[0].Value(), clonedOpts[0].Value()))
+	assert.Assert(t, clonedOpts[1].IsNone())
+	assert.Assert(t, option.CloneOptions([]option.Option[int](nil)) == nil)
+
+	e := elastic.FromValues(clonedSlice{1}, clonedSlice{2})
+	ec := e.Clone()
+	assert.Assert(t, !sameArray(e.Values()[0], ec.Values()[0]))
+	assert.DeepEqual(t, e.Values(), ec.Values())
+	assert.Assert(t, elastic.Null[int]().Clone().IsNull())
+
+	se := sliceelastic.FromValues(clonedSlice{1}, clonedSlice{2})
+	sec := se.Clone()
+	assert.Assert(t, !sameArray(se.Values()[1], sec.Values()[1]))
+	assert.DeepEqual(t, se.Values(), sec.Values())
+	assert.Assert(t, sliceelastic.Undefined[int]().Clone().IsUndefined())
+
+	// non Cloner values are copied by assignment.
+	plain := und.Defined([]int{1})
+	assert.Assert(t, unsafe.SliceData(plain.Value()) == unsafe.SliceData(plain.Clone().Value()))
+}
@@ -0,0 +1,195 @@
+package testcase_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/und/elastic"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+// Only sliceund/elastic converts its Elastic[T] from and to elastic.Elastic[T],
+// by the function FromElastic and the method Elastic.
+const (
+	sliceElasticFromElastic = "FromElastic"
+	sliceElasticToElastic   = "Elastic"
+)
+
+// normalizeElasticSig makes signatures of elastic and sliceund/elastic comparable
+// by replacing the internal Und type of sliceund/elastic with that of elastic.
+func normalizeElasticSig(sig string) string {
+	return strings.ReplaceAll(sig, "sliceund.Und[", "und.Und[")
+}
+
+func elasticMethods(rt reflect.Type) map[string]string {
+	methods := make(map[string]string, rt.NumMethod())
+	for i := range rt.NumMethod() {
+		m := rt.Method(i)
+		// drop the receiver; it differs by definition.
+		in := make([]reflect.Type, m.Type.NumIn()-1)
+		for j := range in {
+			in[j] = m.Type.In(j + 1)
+		}
+		out := make([]reflect.Type, m.Type.NumOut())
+		for j := range out {
+			out[j] = m.Type.Out(j)
+		}
+		methods[m.Name] = normalizeElasticSig(reflect.FuncOf(in, out, m.Type.IsVariadic()).String())
+	}
+	return methods
+}
+
+// TestElastic_methodParity asserts elastic.Elastic[T] and sliceund/elastic.Elastic[T] have same exported methods
+// so that the two variants can not silently drift apart.
+func TestElastic_methodParity(t *testing.T) {
+	for _, pair := range [][2]reflect.Type{
+		{reflect.TypeFor[elastic.Elastic[int]](), reflect.TypeFor[sliceelastic.Elastic[int]]()},
+		{reflect.TypeFor[*elastic.Elastic[int]](), reflect.TypeFor[*sliceelastic.Elastic[int]]()},
+	} {
+		methods, sliceMethods := elasticMethods(pair[0]), elasticMethods(pair[1])
+		delete(sliceMethods, sliceElasticToElastic)
+		assert.DeepEqual(t, methods, sliceMethods)
+	}
+}
+
+func exportedDecls(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	fset := token.NewFileSet()
+	decls := map[string]string{}
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	for _, ent := range entries {
+		name := ent.Name()
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		assert.NilError(t, err)
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil || !d.Name.IsExported() {
+					continue
+				}
+				var buf strings.Builder
+				assert.NilError(t, printer.Fprint(&buf, fset, d.Type))
+				decls[d.Name.Name] = normalizeElasticSig(buf.String())
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch sp := spec.(type) {
+					case *ast.TypeSpec:
+						if sp.Name.IsExported() {
+							decls[sp.Name.Name] = d.Tok.String()
+						}
+					case *ast.ValueSpec:
+						for _, n := range sp.Names {
+							if n.IsExported() {
+								decls[n.Name] = d.Tok.String()
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return decls
+}
+
+// TestElastic_packageParity asserts elastic and sliceund/elastic export same functions, types, variables and constants,
+// and that portable files are identical in both packages.
+func TestElastic_packageParity(t *testing.T) {
+	const (
+		dir      = "../../elastic"
+		sliceDir = "../../sliceund/elastic"
+	)
+	decls, sliceDecls := exportedDecls(t, dir), exportedDecls(t, sliceDir)
+	delete(sliceDecls, sliceElasticFromElastic)
+	assert.DeepEqual(t, decls, sliceDecls)
+
+	const portable = "// portable methods that can be copied from github.com/ngicks/und/elastic into github.com/ngicks/und/sliceund/elastic"
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	var found int
+	for _, ent := range entries {
+		src, err := os.ReadFile(filepath.Join(dir, ent.Name()))
+		assert.NilError(t, err)
+		if !bytes.Contains(src, []byte(portable)) {
+			continue
+		}
+		found++
+		sliceSrc, err := os.ReadFile(filepath.Join(sliceDir, ent.Name()))
+		assert.NilError(t, err)
+		assert.Assert(t, bytes.Equal(src, sliceSrc), "%s differs between elastic and sliceund/elastic", ent.Name())
+	}
+	assert.Assert(t, found > 0)
+}
+
+// singleUnmarshalInputs calls each unmarshaler of Single[T] with input having 2 values.
+// Add an entry here when adding an unmarshaler to Elastic[T], along with the override on Single[T].
+var singleUnmarshalInputs = map[string]func(v any) error{
+	"UnmarshalJSON": func(v any) error {
+		return v.(json.Unmarshaler).UnmarshalJSON([]byte(`[1,2]`))
+	},
+	"UnmarshalYAML": func(v any) error {
+		return v.(interface {
+			UnmarshalYAML(func(any) error) error
+		}).UnmarshalYAML(func(v any) error { return json.Unmarshal([]byte(`[1,2]`), v) })
+	},
+	"UnmarshalXML": func(v any) error {
+		return xml.Unmarshal([]byte(`<v><e>1</e><e>2</e></v>`), &struct {
+			E any `xml:"e"`
+		}{E: v})
+	},
+	"UnmarshalCBOR": func(v any) error {
+		return v.(cborUnmarshaler).UnmarshalCBOR([]byte{0x82, 0x01, 0x02})
+	},
+	"UnmarshalMsgpack": func(v any) error {
+		return v.(interface{ UnmarshalMsgpack([]byte) error }).UnmarshalMsgpack([]byte{0x92, 0x01, 0x02})
+	},
+	"UnmarshalGQL": func(v any) error {
+		return v.(interface{ UnmarshalGQL(any) error }).UnmarshalGQL([]any{1, 2})
+	},
+}
+
+func TestSingle_unmarshalers(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		elastic any
+		single  func() any
+		errMult error
+	}{
+		{"elastic", new(elastic.Elastic[int]), func() any { return new(elastic.Single[int]) }, elastic.ErrMultipleValues},
+		{"sliceelastic", new(sliceelastic.Elastic[int]), func() any { return new(sliceelastic.Single[int]) }, sliceelastic.ErrMultipleValues},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rt := reflect.TypeOf(tc.elastic)
+			for i := range rt.NumMethod() {
+				name := rt.Method(i).Name
+				if !strings.HasPrefix(name, "Unmarshal") {
+					continue
+				}
+				unmarshal, ok := singleUnmarshalInputs[name]
+				assert.Assert(t, ok, "Single must override %s", name)
+
+				e := reflect.New(rt.Elem()).Interface()
+				assert.NilError(t, unmarshal(e), "Elastic.%s", name)
+				assert.Equal(t, 2, e.(interface{ Len() int }).Len(), "Elastic.%s", name)
+
+				err := unmarshal(tc.single())
+				assert.Assert(t, errors.Is(err, tc.errMult), "Single.%s: err = %v", name, err)
+			}
+		})
+	}
+}
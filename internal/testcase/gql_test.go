@@ -0,0 +1,102 @@
+package testcase_test
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+// gqlMarshaler and gqlUnmarshaler are same as interfaces defined in github.com/99designs/gqlgen/graphql.
+// gqlgen itself is not a dependency, so methods are called directly;
+// presence of fields in input objects is up to gqlgen and not tested here.
+type gqlMarshaler interface {
+	MarshalGQL(w io.Writer)
+}
+
+type gqlUnmarshaler interface {
+	UnmarshalGQL(v any) error
+}
+
+var (
+	_ gqlMarshaler = option.Option[any]{}
+	_ gqlMarshaler = und.Und[any]{}
+	_ gqlMarshaler = sliceund.Und[any]{}
+	_ gqlMarshaler = elastic.Elastic[any]{}
+	_ gqlMarshaler = sliceelastic.Elastic[any]{}
+)
+
+var (
+	_ gqlUnmarshaler = (*option.Option[any])(nil)
+	_ gqlUnmarshaler = (*und.Und[any])(nil)
+	_ gqlUnmarshaler = (*sliceund.Und[any])(nil)
+	_ gqlUnmarshaler = (*elastic.Elastic[any])(nil)
+	_ gqlUnmarshaler = (*sliceelastic.Elastic[any])(nil)
+)
+
+func TestGQL(t *testing.T) {
+	for _, tc := range []struct {
+		m        gqlMarshaler
+		expected string
+	}{
+		{option.Some(5), `5`},
+		{option.None[int](), `null`},
+		{option.Some(func() {}), `null`},
+		{und.Defined("a"), `"a"`},
+		{und.Null[int](), `null`},
+		{und.Undefined[int](), `null`},
+		{sliceund.Defined(1.5), `1.5`},
+		{sliceund.Undefined[int](), `null`},
+		{elastic.FromOptions(option.Some(1), option.None[int]()), `[1,null]`},
+		{elastic.Undefined[int](), `null`},
+		{sliceelastic.FromValue(1), `[1]`},
+		{sliceelastic.Null[int](), `null`},
+	} {
+		var sb strings.Builder
+		tc.m.MarshalGQL(&sb)
+		assert.Equal(t, tc.expected, sb.String())
+	}
+
+	var s valueSet[int]
+	unmarshalers := func() []gqlUnmarshaler {
+		return []gqlUnmarshaler{&s.Opt, &s.Und, &s.SliceUnd, &s.Ela, &s.SliceEla}
+	}
+	for _, v := range []any{5, int64(5), json.Number("5")} {
+		s = valueSet[int]{}
+		for _, u := range unmarshalers() {
+			assert.NilError(t, u.UnmarshalGQL(v))
+		}
+		assert.Assert(t, option.Equal(s.Opt, option.Some(5)))
+		assert.Assert(t, und.Equal(s.Und, und.Defined(5)))
+		assert.Assert(t, sliceund.Equal(s.SliceUnd, sliceund.Defined(5)))
+		assert.Assert(t, elastic.Equal(s.Ela, elastic.FromValue(5)))
+		assert.Assert(t, sliceelastic.Equal(s.SliceEla, sliceelastic.FromValue(5)))
+	}
+
+	for _, u := range unmarshalers() {
+		assert.NilError(t, u.UnmarshalGQL(nil))
+	}
+	assert.Assert(t, s.Opt.IsNone())
+	assert.Assert(t, s.Und.IsNull())
+	assert.Assert(t, s.SliceUnd.IsNull())
+	assert.Assert(t, s.Ela.IsNull())
+	assert.Assert(t, s.SliceEla.IsNull())
+
+	assert.NilError(t, s.Ela.UnmarshalGQL([]any{json.Number("1"), nil}))
+	assert.Assert(t, elastic.Equal(s.Ela, elastic.FromOptions(option.Some(1), option.None[int]())))
+
+	var m und.Und[map[string]string]
+	assert.NilError(t, m.UnmarshalGQL(map[string]any{"a": "b"}))
+	assert.DeepEqual(t, map[string]string{"a": "b"}, m.Value())
+
+	for _, u := range unmarshalers() {
+		assert.Assert(t, u.UnmarshalGQL("foo") != nil)
+	}
+}
@@ -0,0 +1,70 @@
+package testcase_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/invopop/jsonschema"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+// jsonSchemaAliaser is same as the interface defined in github.com/invopop/jsonschema.
+type jsonSchemaAliaser interface {
+	JSONSchemaAlias() any
+}
+
+func TestJSONSchemaAlias(t *testing.T) {
+	for _, tc := range []struct {
+		a        jsonSchemaAliaser
+		expected reflect.Type
+	}{
+		{option.Option[int]{}, reflect.TypeFor[*int]()},
+		{und.Und[time.Time]{}, reflect.TypeFor[*time.Time]()},
+		{sliceund.Und[string]{}, reflect.TypeFor[*string]()},
+		{und.Und[any]{}, reflect.TypeFor[*any]()},
+		{elastic.Elastic[int]{}, reflect.TypeFor[*[]int]()},
+		{sliceelastic.Elastic[int]{}, reflect.TypeFor[*[]int]()},
+	} {
+		// reflectors call it on a pointer to a new zero value.
+		alias := reflect.New(reflect.TypeOf(tc.a)).Interface().(jsonSchemaAliaser).JSONSchemaAlias()
+		assert.Equal(t, tc.expected, reflect.TypeOf(alias))
+	}
+}
+
+func TestJSONSchemaReflect(t *testing.T) {
+	type target struct {
+		Untagged und.Und[int]
+		OmitZero und.Und[int]              `json:",omitzero"`
+		Und      und.Und[int]              `json:",omitempty" jsonschema:"nullable"`
+		SliceUnd sliceund.Und[string]      `json:",omitempty" jsonschema:"nullable"`
+		Opt      option.Option[string]     `json:",omitempty" jsonschema:"nullable"`
+		Ela      elastic.Elastic[int]      `json:",omitempty" jsonschema:"nullable"`
+		SliceEla sliceelastic.Elastic[int] `json:",omitempty"`
+	}
+	s := (&jsonschema.Reflector{DoNotReference: true}).Reflect(&target{})
+
+	schemaOf := func(name string) string {
+		t.Helper()
+		p, ok := s.Properties.Get(name)
+		assert.Assert(t, ok, "%s", name)
+		bin, err := json.Marshal(p)
+		assert.NilError(t, err)
+		return string(bin)
+	}
+	// Without tags the field is described as T but is neither nullable nor optional.
+	assert.Equal(t, `{"type":"integer"}`, schemaOf("Untagged"))
+	assert.Equal(t, `{"type":"integer"}`, schemaOf("OmitZero"))
+	assert.Equal(t, `{"oneOf":[{"type":"integer"},{"type":"null"}]}`, schemaOf("Und"))
+	assert.Equal(t, `{"oneOf":[{"type":"string"},{"type":"null"}]}`, schemaOf("SliceUnd"))
+	assert.Equal(t, `{"oneOf":[{"type":"string"},{"type":"null"}]}`, schemaOf("Opt"))
+	assert.Equal(t, `{"oneOf":[{"items":{"type":"integer"},"type":"array"},{"type":"null"}]}`, schemaOf("Ela"))
+	assert.Equal(t, `{"items":{"type":"integer"},"type":"array"}`, schemaOf("SliceEla"))
+	assert.DeepEqual(t, []string{"Untagged", "OmitZero"}, s.Required)
+}
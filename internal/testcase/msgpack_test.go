@@ -0,0 +1,105 @@
+package testcase_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"github.com/vmihailenco/msgpack/v5"
+	"gotest.tools/v3/assert"
+)
+
+var (
+	_ msgpack.Marshaler = option.Option[any]{}
+	_ msgpack.Marshaler = und.Und[any]{}
+	_ msgpack.Marshaler = sliceund.Und[any]{}
+	_ msgpack.Marshaler = elastic.Elastic[any]{}
+	_ msgpack.Marshaler = sliceelastic.Elastic[any]{}
+)
+
+var (
+	_ msgpack.Unmarshaler = (*option.Option[any])(nil)
+	_ msgpack.Unmarshaler = (*und.Und[any])(nil)
+	_ msgpack.Unmarshaler = (*sliceund.Und[any])(nil)
+	_ msgpack.Unmarshaler = (*elastic.Elastic[any])(nil)
+	_ msgpack.Unmarshaler = (*sliceelastic.Elastic[any])(nil)
+)
+
+func TestMsgpack(t *testing.T) {
+	for _, tc := range []struct {
+		m        msgpack.Marshaler
+		expected string
+	}{
+		{option.Some(5), "05"},
+		{option.None[int](), "c0"},
+		{und.Defined(-1), "ff"},
+		{und.Null[int](), "c0"},
+		{und.Undefined[int](), "c0"},
+		{sliceund.Defined("a"), "a161"},
+		{sliceund.Null[string](), "c0"},
+		{sliceund.Undefined[string](), "c0"},
+		{elastic.FromOptions(option.Some(1), option.None[int]()), "9201c0"},
+		{elastic.Null[int](), "c0"},
+		{elastic.Undefined[int](), "c0"},
+		{sliceelastic.FromValue(1), "9101"},
+		{sliceelastic.Undefined[int](), "c0"},
+		{und.Defined(map[string]int{"a": 1}), "81a16101"},
+	} {
+		bin, err := tc.m.MarshalMsgpack()
+		assert.NilError(t, err)
+		assert.Equal(t, tc.expected, hex.EncodeToString(bin), "value = %#v", tc.m)
+	}
+
+	roundTrip := func(v valueSet[int]) valueSet[int] {
+		t.Helper()
+		bin, err := msgpack.Marshal(v)
+		assert.NilError(t, err)
+		var out valueSet[int]
+		assert.NilError(t, msgpack.Unmarshal(bin, &out))
+		return out
+	}
+
+	defined := valueSet[int]{
+		Opt:      option.Some(5),
+		Und:      und.Defined(5),
+		SliceUnd: sliceund.Defined(5),
+		Ela:      elastic.FromOptions(option.Some(1), option.None[int]()),
+		SliceEla: sliceelastic.FromValue(5),
+	}
+	defined.EqualFunc(t, roundTrip(defined), func(i, j int) bool { return i == j })
+
+	// The decoder zeroes fields for nil without calling UnmarshalMsgpack,
+	// so null comes back as undefined.
+	null := roundTrip(valueSet[int]{
+		Opt:      option.None[int](),
+		Und:      und.Null[int](),
+		SliceUnd: sliceund.Null[int](),
+		Ela:      elastic.Null[int](),
+		SliceEla: sliceelastic.Null[int](),
+	})
+	assert.Assert(t, null.Opt.IsNone())
+	assert.Assert(t, null.Und.IsUndefined())
+	assert.Assert(t, null.SliceUnd.IsUndefined())
+	assert.Assert(t, null.Ela.IsUndefined())
+	assert.Assert(t, null.SliceEla.IsUndefined())
+
+	type omitempty struct {
+		Und      und.Und[int]              `msgpack:",omitempty"`
+		SliceUnd sliceund.Und[int]         `msgpack:",omitempty"`
+		Ela      elastic.Elastic[int]      `msgpack:",omitempty"`
+		SliceEla sliceelastic.Elastic[int] `msgpack:",omitempty"`
+	}
+	bin, err := msgpack.Marshal(omitempty{})
+	assert.NilError(t, err)
+	assert.Equal(t, "80", hex.EncodeToString(bin))
+	kept := omitempty{Und: und.Defined(1)}
+	assert.NilError(t, msgpack.Unmarshal(bin, &kept))
+	assert.Assert(t, und.Equal(kept.Und, und.Defined(1)))
+
+	var u und.Und[int]
+	assert.Assert(t, msgpack.Unmarshal([]byte{0xa1, 0x61}, &u) != nil)
+}
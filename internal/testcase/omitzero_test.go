@@ -0,0 +1,101 @@
+//go:build go1.24
+
+package testcase_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+// isZeroer is the interface which `json:",omitzero"` option of encoding/json consults since Go 1.24.
+type isZeroer interface {
+	IsZero() bool
+}
+
+var (
+	_ isZeroer = option.Option[any]{}
+	_ isZeroer = und.Und[any]{}
+	_ isZeroer = sliceund.Und[any]{}
+	_ isZeroer = elastic.Elastic[any]{}
+	_ isZeroer = sliceelastic.Elastic[any]{}
+)
+
+type omitZero struct {
+	Opt      option.Option[int]        `json:"opt,omitzero"`
+	Und      und.Und[int]              `json:"und,omitzero"`
+	SliceUnd sliceund.Und[int]         `json:"slice_und,omitzero"`
+	Ela      elastic.Elastic[int]      `json:"ela,omitzero"`
+	SliceEla sliceelastic.Elastic[int] `json:"slice_ela,omitzero"`
+	Ptr      *und.Und[int]             `json:"ptr,omitzero"`
+	Nested   struct{ A und.Und[int] }  `json:"nested,omitzero"`
+	Inner    struct {
+		A und.Und[int] `json:"a,omitzero"`
+	} `json:"inner"`
+}
+
+func TestOmitZero(t *testing.T) {
+	type testCase struct {
+		name     string
+		v        omitZero
+		expected string
+	}
+	var nullOmitZero omitZero
+	nullOmitZero.Opt = option.None[int]()
+	nullOmitZero.Und = und.Null[int]()
+	nullOmitZero.SliceUnd = sliceund.Null[int]()
+	nullOmitZero.Ela = elastic.Null[int]()
+	nullOmitZero.SliceEla = sliceelastic.Null[int]()
+	nullOmitZero.Inner.A = und.Null[int]()
+
+	var definedOmitZero omitZero
+	definedOmitZero.Opt = option.Some(1)
+	definedOmitZero.Und = und.Defined(2)
+	definedOmitZero.SliceUnd = sliceund.Defined(3)
+	definedOmitZero.Ela = elastic.FromOptions(option.Some(4), option.None[int]())
+	definedOmitZero.SliceEla = sliceelastic.FromOptions(option.None[int]())
+	definedOmitZero.Ptr = new(und.Und[int])
+	definedOmitZero.Nested.A = und.Defined(5)
+	definedOmitZero.Inner.A = und.Defined(6)
+
+	for _, tc := range []testCase{
+		{
+			name:     "undefined",
+			expected: `{"inner":{}}`,
+		},
+		{
+			// None option.Option[T] is its zero value, thus omitted as well as undefined.
+			name:     "null",
+			v:        nullOmitZero,
+			expected: `{"und":null,"slice_und":null,"ela":null,"slice_ela":null,"inner":{"a":null}}`,
+		},
+		{
+			// *und.Und[T] also has IsZero in its method set,
+			// thus non-nil pointers to undefined values are omitted too.
+			name: "defined",
+			v:    definedOmitZero,
+			expected: `{"opt":1,"und":2,"slice_und":3,"ela":[4,null],"slice_ela":[null],` +
+				`"nested":{"A":5},"inner":{"a":6}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bin, err := json.Marshal(tc.v)
+			assert.NilError(t, err)
+			assert.Equal(t, tc.expected, string(bin))
+
+			var decoded omitZero
+			assert.NilError(t, json.Unmarshal(bin, &decoded))
+			assert.Assert(t, und.Equal(tc.v.Und, decoded.Und))
+			assert.Assert(t, sliceund.Equal(tc.v.SliceUnd, decoded.SliceUnd))
+			assert.Assert(t, elastic.Equal(tc.v.Ela, decoded.Ela))
+			assert.Assert(t, sliceelastic.Equal(tc.v.SliceEla, decoded.SliceEla))
+			assert.Assert(t, und.Equal(tc.v.Inner.A, decoded.Inner.A))
+		})
+	}
+}
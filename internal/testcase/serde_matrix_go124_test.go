@@ -0,0 +1,23 @@
+//go:build go1.24
+
+package testcase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/undtesting"
+)
+
+func TestSerdeMatrix_omitzero(t *testing.T) {
+	undtesting.AssertUndSerde[und.Und[string]](t, "foo", eqString)
+	undtesting.AssertElasticSerde[elastic.Elastic[string]](t, "foo", eqString)
+
+	undtesting.AssertUndSerde[und.Und[time.Time]](t, serdeMatrixTime, eqTime)
+	undtesting.AssertElasticSerde[elastic.Elastic[time.Time]](t, serdeMatrixTime, eqTime)
+
+	undtesting.AssertUndSerde[und.Und[serdeMatrixValue]](t, serdeMatrixField, serdeMatrixValue.Equal)
+	undtesting.AssertElasticSerde[elastic.Elastic[serdeMatrixValue]](t, serdeMatrixField, serdeMatrixValue.Equal)
+}
@@ -0,0 +1,43 @@
+package testcase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"github.com/ngicks/und/undtesting"
+)
+
+type serdeMatrixValue struct {
+	Foo string
+	Bar und.Und[int]          `json:",omitzero"`
+	Baz elastic.Elastic[bool] `json:",omitzero"`
+}
+
+func (v serdeMatrixValue) Equal(u serdeMatrixValue) bool {
+	return v.Foo == u.Foo && und.Equal(v.Bar, u.Bar) && elastic.Equal(v.Baz, u.Baz)
+}
+
+var (
+	serdeMatrixTime  = time.Date(2024, 2, 29, 23, 59, 59, 123456789, time.FixedZone("", 9*60*60))
+	serdeMatrixField = serdeMatrixValue{Foo: "foo", Bar: und.Defined(5), Baz: elastic.FromValues(true, false)}
+)
+
+func eqString(i, j string) bool  { return i == j }
+func eqTime(i, j time.Time) bool { return i.Equal(j) }
+
+// TestSerdeMatrix covers sliceund variants, whose undefined values are omitted by omitempty.
+// und.Und and elastic.Elastic are covered in serde_matrix_go124_test.go since they need omitzero.
+func TestSerdeMatrix(t *testing.T) {
+	undtesting.AssertUndSerde[sliceund.Und[string]](t, "foo", eqString)
+	undtesting.AssertElasticSerde[sliceelastic.Elastic[string]](t, "foo", eqString)
+
+	undtesting.AssertUndSerde[sliceund.Und[time.Time]](t, serdeMatrixTime, eqTime)
+	undtesting.AssertElasticSerde[sliceelastic.Elastic[time.Time]](t, serdeMatrixTime, eqTime)
+
+	undtesting.AssertUndSerde[sliceund.Und[serdeMatrixValue]](t, serdeMatrixField, serdeMatrixValue.Equal)
+	undtesting.AssertElasticSerde[sliceelastic.Elastic[serdeMatrixValue]](t, serdeMatrixField, serdeMatrixValue.Equal)
+}
@@ -0,0 +1,93 @@
+package testcase_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"gotest.tools/v3/assert"
+)
+
+var (
+	_ toml.Marshaler = option.Option[any]{}
+	_ toml.Marshaler = und.Und[any]{}
+	_ toml.Marshaler = sliceund.Und[any]{}
+)
+
+var (
+	_ toml.Unmarshaler = (*option.Option[any])(nil)
+	_ toml.Unmarshaler = (*und.Und[any])(nil)
+	_ toml.Unmarshaler = (*sliceund.Und[any])(nil)
+)
+
+type tomlPoint struct {
+	X int    `json:"x"`
+	Y string `json:"y"`
+}
+
+type tomlSample struct {
+	Int      und.Und[int]             `toml:"int,omitempty"`
+	Str      und.Und[string]          `toml:"str,omitempty"`
+	SliceUnd sliceund.Und[string]     `toml:"slice_und,omitempty"`
+	Opt      option.Option[string]    `toml:"opt,omitempty"`
+	Map      und.Und[map[string]int]  `toml:"map,omitempty"`
+	Strs     sliceund.Und[[]string]   `toml:"strs,omitempty"`
+	Point    und.Und[tomlPoint]       `toml:"point,omitempty"`
+	Missing  und.Und[int]             `toml:"missing,omitempty"`
+	MissingO option.Option[tomlPoint] `toml:"missing_o,omitempty"`
+}
+
+func TestToml(t *testing.T) {
+	var buf bytes.Buffer
+	err := toml.NewEncoder(&buf).Encode(tomlSample{
+		Int:      und.Defined(5),
+		Str:      und.Defined("a \"quoted\"\nstring"),
+		SliceUnd: sliceund.Defined("b"),
+		Opt:      option.Some("c"),
+		Map:      und.Defined(map[string]int{"k": 1, "j": 2}),
+		Strs:     sliceund.Defined([]string{"d", "e"}),
+	})
+	assert.NilError(t, err)
+	// undefined and none fields are omitted.
+	assert.Equal(
+		t,
+		`int = 5
+str = "a \"quoted\"\nstring"
+slice_und = "b"
+opt = "c"
+map = { "j" = 2, "k" = 1 }
+strs = ["d", "e"]
+`,
+		buf.String(),
+	)
+
+	var decoded tomlSample
+	_, err = toml.Decode(buf.String()+"\n[point]\nx = 1\ny = \"z\"\n", &decoded)
+	assert.NilError(t, err)
+	assert.Assert(t, und.Equal(decoded.Int, und.Defined(5)))
+	assert.Assert(t, und.Equal(decoded.Str, und.Defined("a \"quoted\"\nstring")))
+	assert.Assert(t, sliceund.Equal(decoded.SliceUnd, sliceund.Defined("b")))
+	assert.Assert(t, option.Equal(decoded.Opt, option.Some("c")))
+	assert.DeepEqual(t, map[string]int{"k": 1, "j": 2}, decoded.Map.Value())
+	assert.DeepEqual(t, []string{"d", "e"}, decoded.Strs.Value())
+	// tables are decoded into structs.
+	assert.Assert(t, und.Equal(decoded.Point, und.Defined(tomlPoint{1, "z"})))
+	// missing keys leave fields undefined.
+	assert.Assert(t, decoded.Missing.IsUndefined())
+	assert.Assert(t, decoded.MissingO.IsNone())
+
+	// TOML has no null.
+	for _, v := range []any{
+		struct{ U und.Und[int] }{und.Null[int]()},
+		struct{ U sliceund.Und[int] }{sliceund.Null[int]()},
+		struct{ O option.Option[int] }{option.None[int]()},
+	} {
+		assert.ErrorContains(t, toml.NewEncoder(&bytes.Buffer{}).Encode(v), "TOML has no null")
+	}
+	// structs can be decoded from tables but not encoded.
+	err = toml.NewEncoder(&bytes.Buffer{}).Encode(struct{ P und.Und[tomlPoint] }{und.Defined(tomlPoint{})})
+	assert.ErrorContains(t, err, "unsupported type")
+}
@@ -0,0 +1,70 @@
+package testcase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/patch"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+// presence is a third party optional type which only implements und.UndStater.
+type presence[T any] struct {
+	state und.State
+	v     T
+}
+
+var _ und.UndStater = presence[any]{}
+
+func (p presence[T]) State() und.State {
+	if p.state == 0 {
+		return und.StateUndefined
+	}
+	return p.state
+}
+
+func (p presence[T]) AnyValue() any { return p.v }
+func (p presence[T]) Value() T      { return p.v }
+
+func present[T any](v T) presence[T]   { return presence[T]{und.StateDefined, v} }
+func nullPresence[T any]() presence[T] { return presence[T]{state: und.StateNull} }
+
+type undStaterSample struct {
+	Foo presence[string] `und:"required"`
+	Bar presence[int]    `und:"null"`
+}
+
+func TestUndStater(t *testing.T) {
+	t.Run("validate", func(t *testing.T) {
+		assert.NilError(t, validate.UndValidate(undStaterSample{Foo: present("foo"), Bar: nullPresence[int]()}))
+		err := validate.UndValidate(undStaterSample{Foo: nullPresence[string](), Bar: nullPresence[int]()})
+		assert.ErrorContains(t, err, "Foo")
+		err = validate.UndValidate(undStaterSample{Foo: present("foo")})
+		var vErr *validate.ValidationError
+		assert.Assert(t, errors.As(err, &vErr))
+		assert.Equal(t, vErr.Pointer(), "/Bar")
+		assert.Equal(t, validate.ReportState(present(1)), "defined")
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		base := undStaterSample{Foo: present("foo"), Bar: present(1)}
+		merged := patch.Merge(base, undStaterSample{Bar: nullPresence[int]()})
+		assert.Equal(t, merged, undStaterSample{Foo: present("foo"), Bar: nullPresence[int]()})
+		diff := patch.Diff(base, undStaterSample{Foo: present("foo"), Bar: present(2)})
+		assert.Equal(t, diff, undStaterSample{Bar: present(2)})
+		squashed := patch.MergeN(patch.TombstoneDrop, undStaterSample{Foo: present("foo"), Bar: nullPresence[int]()})
+		assert.Equal(t, squashed, undStaterSample{Foo: present("foo")})
+	})
+
+	t.Run("Columns", func(t *testing.T) {
+		columns, err := und.Columns([]undStaterSample{{Foo: present("foo")}, {Foo: nullPresence[string]()}, {}})
+		assert.NilError(t, err)
+		foo, err := und.Column[string](columns["Foo"])
+		assert.NilError(t, err)
+		assert.Assert(t, und.Equal(foo[0], und.Defined("foo")))
+		assert.Assert(t, foo[1].IsNull())
+		assert.Assert(t, foo[2].IsUndefined())
+	})
+}
@@ -0,0 +1,102 @@
+package testcase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+var errSelfValidating = errors.New("self validating")
+
+// selfValidating implements validate.UndValidator by itself, without und struct tags.
+type selfValidating struct {
+	Valid bool
+}
+
+func (s selfValidating) UndValidate() error {
+	if !s.Valid {
+		return errSelfValidating
+	}
+	return nil
+}
+
+type selfValidatingPointer struct {
+	Valid bool
+}
+
+func (s *selfValidatingPointer) UndValidate() error {
+	if !s.Valid {
+		return errSelfValidating
+	}
+	return nil
+}
+
+func TestUndValidate_passthrough(t *testing.T) {
+	for _, v := range []validate.UndValidator{
+		option.Some(selfValidating{}),
+		und.Defined(selfValidating{}),
+		sliceund.Defined(selfValidating{}),
+		option.Some(&selfValidatingPointer{}),
+		und.Defined(&selfValidatingPointer{}),
+		sliceund.Defined(&selfValidatingPointer{}),
+	} {
+		assert.ErrorIs(t, v.UndValidate(), errSelfValidating)
+	}
+	for _, v := range []validate.UndValidator{
+		option.Some(selfValidating{Valid: true}),
+		und.Defined(selfValidating{Valid: true}),
+		sliceund.Defined(selfValidating{Valid: true}),
+		option.None[selfValidating](),
+		und.Null[selfValidating](),
+		sliceund.Undefined[selfValidating](),
+		option.Some[*selfValidatingPointer](nil),
+		und.Defined[*selfValidatingPointer](nil),
+		sliceund.Defined[*selfValidatingPointer](nil),
+	} {
+		assert.NilError(t, v.UndValidate())
+	}
+}
+
+func TestUndValidate_elasticIndex(t *testing.T) {
+	type sample struct {
+		Foo und.Und[int] `und:"required"`
+	}
+	invalid := sample{}
+	valid := sample{Foo: und.Defined(1)}
+
+	for _, v := range []validate.UndValidator{
+		option.Options[sample]{option.Some(valid), option.None[sample](), option.Some(invalid)},
+		elastic.FromOptions(option.Some(valid), option.None[sample](), option.Some(invalid)),
+		sliceelastic.FromOptions(option.Some(valid), option.None[sample](), option.Some(invalid)),
+	} {
+		err := v.UndValidate()
+		var vErr *validate.ValidationError
+		assert.Assert(t, errors.As(err, &vErr))
+		assert.Equal(t, "/2/Foo", vErr.Pointer())
+		assert.ErrorContains(t, err, "validation failed at [2].Foo: ")
+	}
+
+	for _, v := range []validate.UndValidator{
+		elastic.FromValues(selfValidating{true}, selfValidating{false}),
+		sliceelastic.FromValues(selfValidating{true}, selfValidating{false}),
+	} {
+		err := v.UndValidate()
+		assert.ErrorIs(t, err, errSelfValidating)
+		assert.ErrorContains(t, err, "validation failed at [1]: ")
+	}
+
+	for _, v := range []validate.UndValidator{
+		elastic.FromValues(1, 2),
+		elastic.FromOptions(option.Some(valid), option.None[sample]()),
+		sliceelastic.FromOptions(option.Some(valid), option.None[sample]()),
+	} {
+		assert.NilError(t, v.UndValidate())
+	}
+}
@@ -163,3 +163,81 @@ func TestXmlMarshaler_nested(t *testing.T) {
 	}
 
 }
+
+func TestXmlMarshaler_xsiNil(t *testing.T) {
+	for _, bin := range []string{
+		`<test xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><opt xsi:nil="true"></opt><und xsi:nil="true"/>` +
+			`<sliceund xsi:nil="1"></sliceund><ela xsi:nil="true"/><sliceela>1</sliceela><sliceela xsi:nil="true"/></test>`,
+		// undeclared prefix
+		`<test><opt xsi:nil="true"></opt><und xsi:nil="true"/>` +
+			`<sliceund xsi:nil="1"></sliceund><ela xsi:nil="true"/><sliceela>1</sliceela><sliceela xsi:nil="true"/></test>`,
+	} {
+		var s xmlMarshaler[int]
+		err := xml.Unmarshal([]byte(bin), &s)
+		assert.NilError(t, err)
+		valueSet[int]{
+			option.None[int](),
+			und.Null[int](),
+			sliceund.Null[int](),
+			elastic.FromOptions(option.None[int]()),
+			sliceelastic.FromOptions(option.Some(1), option.None[int]()),
+		}.EqualFunc(t, s.into(), func(i, j int) bool { return i == j })
+	}
+}
+
+type xmlNullStyled[T any] struct {
+	Und   und.Und[T]
+	Style und.XMLNullStyle
+}
+
+func (x xmlNullStyled[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return x.Und.MarshalXMLNull(e, start, x.Style)
+}
+
+type sliceXmlNullStyled[T any] struct {
+	Und   sliceund.Und[T]
+	Style und.XMLNullStyle
+}
+
+func (x sliceXmlNullStyled[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return x.Und.MarshalXMLNull(e, start, x.Style)
+}
+
+func TestXmlMarshaler_nullStyle(t *testing.T) {
+	type target struct {
+		XMLName xml.Name `xml:"test"`
+		V       xml.Marshaler
+	}
+	type decoded struct {
+		XMLName xml.Name     `xml:"test"`
+		V       und.Und[int] `xml:"V"`
+	}
+	for _, tc := range []struct {
+		style und.XMLNullStyle
+		null  string
+	}{
+		{und.XMLNullOmit, `<test></test>`},
+		{und.XMLNullNil, `<test><V xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></V></test>`},
+		{und.XMLNullEmpty, `<test><V></V></test>`},
+	} {
+		for _, v := range []struct {
+			m        xml.Marshaler
+			expected string
+		}{
+			{xmlNullStyled[int]{und.Undefined[int](), tc.style}, `<test></test>`},
+			{xmlNullStyled[int]{und.Null[int](), tc.style}, tc.null},
+			{xmlNullStyled[int]{und.Defined(5), tc.style}, `<test><V>5</V></test>`},
+			{sliceXmlNullStyled[int]{sliceund.Undefined[int](), tc.style}, `<test></test>`},
+			{sliceXmlNullStyled[int]{sliceund.Null[int](), tc.style}, tc.null},
+			{sliceXmlNullStyled[int]{sliceund.Defined(5), tc.style}, `<test><V>5</V></test>`},
+		} {
+			bin, err := xml.Marshal(target{V: v.m})
+			assert.NilError(t, err)
+			assert.Equal(t, string(bin), v.expected)
+		}
+	}
+
+	var d decoded
+	assert.NilError(t, xml.Unmarshal([]byte(`<test><V xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></V></test>`), &d))
+	assert.Assert(t, d.V.IsNull())
+}
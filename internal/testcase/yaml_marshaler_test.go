@@ -0,0 +1,137 @@
+package testcase_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+// yamlMarshaler and yamlUnmarshaler are same as interfaces defined in gopkg.in/yaml.v2.
+type yamlMarshaler interface {
+	MarshalYAML() (any, error)
+}
+
+type yamlUnmarshaler interface {
+	UnmarshalYAML(unmarshal func(any) error) error
+}
+
+var (
+	_ yamlMarshaler = option.Option[any]{}
+	_ yamlMarshaler = und.Und[any]{}
+	_ yamlMarshaler = sliceund.Und[any]{}
+	_ yamlMarshaler = elastic.Elastic[any]{}
+	_ yamlMarshaler = sliceelastic.Elastic[any]{}
+)
+
+var (
+	_ yamlUnmarshaler = (*option.Option[any])(nil)
+	_ yamlUnmarshaler = (*und.Und[any])(nil)
+	_ yamlUnmarshaler = (*sliceund.Und[any])(nil)
+	_ yamlUnmarshaler = (*elastic.Elastic[any])(nil)
+	_ yamlUnmarshaler = (*sliceelastic.Elastic[any])(nil)
+)
+
+// fakeYamlUnmarshal emulates the unmarshal function passed by yaml decoders.
+// JSON is a subset of YAML, so just decoding it as JSON is sufficient for the test.
+// Do not pass a bare null: yaml decoders never call unmarshalers for it.
+func fakeYamlUnmarshal(data string) func(any) error {
+	return func(v any) error {
+		return json.Unmarshal([]byte(data), v)
+	}
+}
+
+func TestYamlMarshaler(t *testing.T) {
+	t.Run("MarshalYAML", func(t *testing.T) {
+		for _, tc := range []struct {
+			m        yamlMarshaler
+			expected any
+		}{
+			{option.None[int](), nil},
+			{option.Some(1), 1},
+			{und.Undefined[int](), nil},
+			{und.Null[int](), nil},
+			{und.Defined(1), 1},
+			{sliceund.Undefined[int](), nil},
+			{sliceund.Null[int](), nil},
+			{sliceund.Defined(1), 1},
+		} {
+			v, err := tc.m.MarshalYAML()
+			assert.NilError(t, err)
+			assert.Equal(t, v, tc.expected)
+		}
+
+		for _, m := range []yamlMarshaler{
+			elastic.Undefined[int](),
+			elastic.Null[int](),
+			sliceelastic.Undefined[int](),
+			sliceelastic.Null[int](),
+		} {
+			v, err := m.MarshalYAML()
+			assert.NilError(t, err)
+			assert.Assert(t, v == nil)
+		}
+
+		opts := option.Options[int]{option.Some(1), option.None[int]()}
+		for _, m := range []yamlMarshaler{
+			elastic.FromOptions(opts...),
+			sliceelastic.FromOptions(opts...),
+		} {
+			v, err := m.MarshalYAML()
+			assert.NilError(t, err)
+			assert.Assert(t, option.EqualOptions(opts, v.(option.Options[int])))
+		}
+	})
+
+	t.Run("UnmarshalYAML", func(t *testing.T) {
+		for _, tc := range []struct {
+			input  string
+			values valueSet[int]
+		}{
+			{
+				`5`,
+				valueSet[int]{
+					option.Some(5),
+					und.Defined(5),
+					sliceund.Defined(5),
+					elastic.FromValue(5),
+					sliceelastic.FromValue(5),
+				},
+			},
+		} {
+			var s valueSet[int]
+			for _, u := range []yamlUnmarshaler{&s.Opt, &s.Und, &s.SliceUnd, &s.Ela, &s.SliceEla} {
+				assert.NilError(t, u.UnmarshalYAML(fakeYamlUnmarshal(tc.input)))
+			}
+			tc.values.EqualFunc(t, s, func(i, j int) bool { return i == j })
+		}
+
+		var (
+			e  elastic.Elastic[int]
+			se sliceelastic.Elastic[int]
+		)
+		assert.NilError(t, e.UnmarshalYAML(fakeYamlUnmarshal(`[null,1,2]`)))
+		assert.NilError(t, se.UnmarshalYAML(fakeYamlUnmarshal(`[null,1,2]`)))
+		expected := []option.Option[int]{option.None[int](), option.Some(1), option.Some(2)}
+		assert.Assert(t, elastic.Equal(e, elastic.FromOptions(expected...)))
+		assert.Assert(t, sliceelastic.Equal(se, sliceelastic.FromOptions(expected...)))
+
+		var (
+			es  elastic.Elastic[[]int]
+			ses sliceelastic.Elastic[[]int]
+		)
+		assert.NilError(t, es.UnmarshalYAML(fakeYamlUnmarshal(`[1,2]`)))
+		assert.NilError(t, ses.UnmarshalYAML(fakeYamlUnmarshal(`[1,2]`)))
+		assert.DeepEqual(t, [][]int{{1, 2}}, es.Values())
+		assert.DeepEqual(t, [][]int{{1, 2}}, ses.Values())
+
+		var u und.Und[int]
+		assert.Assert(t, u.UnmarshalYAML(fakeYamlUnmarshal(`"foo"`)) != nil)
+		assert.Assert(t, u.IsUndefined())
+	})
+}
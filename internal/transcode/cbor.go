@@ -0,0 +1,474 @@
+// Package transcode converts JSON texts to and from binary formats sharing the JSON data model.
+//
+// und types support those formats through their JSON representation:
+// values are marshaled as JSON, then transcoded, and vice versa.
+// This keeps the module free from third party encoders
+// while every format agrees with encoding/json on how T is represented.
+package transcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	// CBORNull is the CBOR simple value null.
+	CBORNull = 0xf6
+	// CBORUndefined is the CBOR simple value undefined.
+	CBORUndefined = 0xf7
+)
+
+const maxDepth = 10000
+
+var (
+	// ErrSyntax is returned when input is not a well-formed data item of its format.
+	ErrSyntax = errors.New("syntax error")
+	// ErrUnsupported is returned when input is well-formed but has no JSON representation,
+	// e.g. NaN or a map keyed by arrays.
+	ErrUnsupported = errors.New("unsupported")
+)
+
+// IsCBORUndefined reports whether data is the CBOR simple value undefined.
+func IsCBORUndefined(data []byte) bool {
+	return len(data) == 1 && data[0] == CBORUndefined
+}
+
+// JSONToCBOR appends data, a JSON text, to dst as a CBOR (RFC 8949) data item.
+//
+// Numbers are encoded as integers if those are integers representable in 64 bits,
+// as bignums (tags 2 and 3) if those are larger integers,
+// as float64 if float64 holds them exactly, and as decimal fractions (tag 4) otherwise.
+// Lengths of arrays and maps are always definite, and members of objects keep their order.
+func JSONToCBOR(dst, data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dst, err := appendCBOR(dst, dec)
+	if err != nil {
+		return dst, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return dst, fmt.Errorf("%w: trailing data after JSON value", ErrSyntax)
+	}
+	return dst, nil
+}
+
+func appendCBOR(dst []byte, dec *json.Decoder) ([]byte, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return dst, err
+	}
+	switch t := tok.(type) {
+	case nil:
+		return append(dst, CBORNull), nil
+	case bool:
+		if t {
+			return append(dst, 0xf5), nil
+		}
+		return append(dst, 0xf4), nil
+	case json.Number:
+		return appendCBORNumber(dst, t)
+	case string:
+		dst = appendCBORHead(dst, 3, uint64(len(t)))
+		return append(dst, t...), nil
+	case json.Delim:
+		var (
+			items []byte
+			n     uint64
+		)
+		for dec.More() {
+			if t == '{' {
+				// keys are always string tokens.
+				items, err = appendCBOR(items, dec)
+				if err != nil {
+					return dst, err
+				}
+			}
+			items, err = appendCBOR(items, dec)
+			if err != nil {
+				return dst, err
+			}
+			n++
+		}
+		if _, err := dec.Token(); err != nil { // closing delim
+			return dst, err
+		}
+		major := byte(4)
+		if t == '{' {
+			major = 5
+		}
+		dst = appendCBORHead(dst, major, n)
+		return append(dst, items...), nil
+	}
+	return dst, fmt.Errorf("%w: unknown token %v", ErrSyntax, tok)
+}
+
+func appendCBORNumber(dst []byte, n json.Number) ([]byte, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		if i < 0 {
+			return appendCBORHead(dst, 1, uint64(-1-i)), nil
+		}
+		return appendCBORHead(dst, 0, uint64(i)), nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return appendCBORHead(dst, 0, u), nil
+	}
+	if isInteger(n) {
+		i, ok := new(big.Int).SetString(string(n), 10)
+		if !ok {
+			return dst, fmt.Errorf("%w: number %s", ErrUnsupported, n)
+		}
+		return appendCBORInteger(dst, i), nil
+	}
+	if f, ok := exactFloat(n); ok {
+		dst = append(dst, 0xfb)
+		return binary.BigEndian.AppendUint64(dst, math.Float64bits(f)), nil
+	}
+	d, err := parseDecimal(n)
+	if err != nil {
+		return dst, err
+	}
+	mant, _ := new(big.Int).SetString("0"+d.digits, 10)
+	if d.neg {
+		mant.Neg(mant)
+	}
+	dst = appendCBORHead(dst, 6, 4)
+	dst = appendCBORHead(dst, 4, 2)
+	dst = appendCBORInteger(dst, big.NewInt(int64(d.exp)))
+	return appendCBORInteger(dst, mant), nil
+}
+
+// appendCBORInteger appends i as an integer, or as a bignum if i does not fit in 64 bits.
+func appendCBORInteger(dst []byte, i *big.Int) []byte {
+	switch {
+	case i.IsUint64():
+		return appendCBORHead(dst, 0, i.Uint64())
+	case i.Sign() < 0 && i.IsInt64():
+		return appendCBORHead(dst, 1, uint64(-1-i.Int64()))
+	}
+	tag, content := uint64(2), i
+	if i.Sign() < 0 {
+		// tag 3 holds -1-n.
+		tag, content = 3, new(big.Int).Sub(new(big.Int).Neg(i), big.NewInt(1))
+	}
+	b := content.Bytes()
+	dst = appendCBORHead(dst, 6, tag)
+	dst = appendCBORHead(dst, 2, uint64(len(b)))
+	return append(dst, b...)
+}
+
+func appendCBORHead(dst []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(dst, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(dst, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, major|27), n)
+	}
+}
+
+// CBORToJSON appends data, a single CBOR data item, to dst as a JSON text.
+//
+// undefined is converted to null, byte strings to base64 encoded strings as encoding/json does for []byte,
+// bignums (tags 2 and 3) and decimal fractions (tag 4) to numbers,
+// and other tagged data items to their content, ignoring the tag.
+// Map keys must be text strings or integers; integer keys are converted to strings.
+// data must not have trailing bytes after the data item.
+func CBORToJSON(dst, data []byte) ([]byte, error) {
+	d := &cborDecoder{data: data}
+	dst, err := d.appendJSON(dst, 0)
+	if err != nil {
+		return dst, err
+	}
+	if d.off != len(d.data) {
+		return dst, fmt.Errorf("%w: trailing bytes after CBOR data item at offset %d", ErrSyntax, d.off)
+	}
+	return dst, nil
+}
+
+type cborDecoder struct {
+	data []byte
+	off  int
+}
+
+const cborIndefinite = 31
+
+func (d *cborDecoder) readByte() (byte, error) {
+	if d.off >= len(d.data) {
+		return 0, fmt.Errorf("%w: unexpected end of CBOR input", ErrSyntax)
+	}
+	b := d.data[d.off]
+	d.off++
+	return b, nil
+}
+
+func (d *cborDecoder) read(n uint64) ([]byte, error) {
+	if uint64(len(d.data)-d.off) < n {
+		return nil, fmt.Errorf("%w: unexpected end of CBOR input", ErrSyntax)
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// readHead reads the initial byte and the argument of a data item.
+// For indefinite lengths, ai is cborIndefinite and arg is 0.
+func (d *cborDecoder) readHead() (major, ai byte, arg uint64, err error) {
+	ib, err := d.readByte()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, ai = ib>>5, ib&0x1f
+	switch {
+	case ai < 24:
+		return major, ai, uint64(ai), nil
+	case ai <= 27:
+		b, err := d.read(1 << (ai - 24))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, c := range b {
+			arg = arg<<8 | uint64(c)
+		}
+		return major, ai, arg, nil
+	case ai == cborIndefinite && (major >= 2 && major <= 5 || major == 7):
+		return major, ai, 0, nil
+	}
+	return 0, 0, 0, fmt.Errorf("%w: malformed CBOR initial byte 0x%x at offset %d", ErrSyntax, ib, d.off-1)
+}
+
+func (d *cborDecoder) isBreak() bool {
+	if d.off < len(d.data) && d.data[d.off] == 0xff {
+		d.off++
+		return true
+	}
+	return false
+}
+
+func (d *cborDecoder) appendJSON(dst []byte, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return dst, fmt.Errorf("%w: CBOR nested too deeply", ErrUnsupported)
+	}
+	major, ai, arg, err := d.readHead()
+	if err != nil {
+		return dst, err
+	}
+	switch major {
+	case 0:
+		return strconv.AppendUint(dst, arg, 10), nil
+	case 1:
+		if arg <= math.MaxInt64 {
+			return strconv.AppendInt(dst, -1-int64(arg), 10), nil
+		}
+		n := new(big.Int).SetUint64(arg)
+		return n.Neg(n.Add(n, big.NewInt(1))).Append(dst, 10), nil
+	case 2, 3:
+		s, err := d.readString(major, ai, arg)
+		if err != nil {
+			return dst, err
+		}
+		if major == 2 {
+			dst = append(dst, '"')
+			dst = base64.StdEncoding.AppendEncode(dst, s)
+			return append(dst, '"'), nil
+		}
+		if !utf8.Valid(s) {
+			return dst, fmt.Errorf("%w: invalid UTF-8 in CBOR text string", ErrSyntax)
+		}
+		bin, _ := json.Marshal(string(s))
+		return append(dst, bin...), nil
+	case 4, 5:
+		open, close := byte('['), byte(']')
+		if major == 5 {
+			open, close = '{', '}'
+		}
+		dst = append(dst, open)
+		for i := uint64(0); ai == cborIndefinite || i < arg; i++ {
+			if ai == cborIndefinite && d.isBreak() {
+				break
+			}
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			if major == 5 {
+				dst, err = d.appendKey(dst)
+				if err != nil {
+					return dst, err
+				}
+				dst = append(dst, ':')
+			}
+			dst, err = d.appendJSON(dst, depth+1)
+			if err != nil {
+				return dst, err
+			}
+		}
+		return append(dst, close), nil
+	case 6:
+		switch arg {
+		case 2, 3:
+			i, err := d.readBignum(arg)
+			if err != nil {
+				return dst, err
+			}
+			return i.Append(dst, 10), nil
+		case 4:
+			return d.appendDecimalFraction(dst)
+		}
+		return d.appendJSON(dst, depth+1)
+	default: // 7
+		return d.appendSimple(dst, ai, arg)
+	}
+}
+
+// readBignum reads the content of a bignum tagged with tag.
+func (d *cborDecoder) readBignum(tag uint64) (*big.Int, error) {
+	major, ai, arg, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+	if major != 2 {
+		return nil, fmt.Errorf("%w: CBOR bignum must be a byte string", ErrSyntax)
+	}
+	b, err := d.readString(major, ai, arg)
+	if err != nil {
+		return nil, err
+	}
+	i := new(big.Int).SetBytes(b)
+	if tag == 3 {
+		i.Sub(i.Neg(i), big.NewInt(1))
+	}
+	return i, nil
+}
+
+// readInteger reads an integer, or a bignum if allowBignum is true.
+func (d *cborDecoder) readInteger(allowBignum bool) (*big.Int, error) {
+	major, _, arg, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case major == 0:
+		return new(big.Int).SetUint64(arg), nil
+	case major == 1:
+		i := new(big.Int).SetUint64(arg)
+		return i.Sub(i.Neg(i), big.NewInt(1)), nil
+	case major == 6 && allowBignum && (arg == 2 || arg == 3):
+		return d.readBignum(arg)
+	}
+	return nil, fmt.Errorf("%w: malformed CBOR decimal fraction", ErrSyntax)
+}
+
+// appendDecimalFraction reads the content of a decimal fraction, [exponent, mantissa], as a JSON number.
+func (d *cborDecoder) appendDecimalFraction(dst []byte) ([]byte, error) {
+	major, ai, arg, err := d.readHead()
+	if err != nil {
+		return dst, err
+	}
+	if major != 4 || ai == cborIndefinite || arg != 2 {
+		return dst, fmt.Errorf("%w: malformed CBOR decimal fraction", ErrSyntax)
+	}
+	exp, err := d.readInteger(false)
+	if err != nil {
+		return dst, err
+	}
+	if !exp.IsInt64() {
+		return dst, fmt.Errorf("%w: CBOR decimal fraction exponent %s", ErrUnsupported, exp)
+	}
+	mant, err := d.readInteger(true)
+	if err != nil {
+		return dst, err
+	}
+	neg := mant.Sign() < 0
+	return appendDecimal(dst, neg, mant.Abs(mant).String(), exp.Int64()), nil
+}
+
+func (d *cborDecoder) readString(major, ai byte, arg uint64) ([]byte, error) {
+	if ai != cborIndefinite {
+		return d.read(arg)
+	}
+	var s []byte
+	for !d.isBreak() {
+		chunkMajor, chunkAi, chunkArg, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if chunkMajor != major || chunkAi == cborIndefinite {
+			return nil, fmt.Errorf("%w: malformed chunk of indefinite length CBOR string", ErrSyntax)
+		}
+		chunk, err := d.read(chunkArg)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, chunk...)
+	}
+	return s, nil
+}
+
+func (d *cborDecoder) appendKey(dst []byte) ([]byte, error) {
+	if d.off < len(d.data) {
+		switch d.data[d.off] >> 5 {
+		case 0, 1:
+			dst = append(dst, '"')
+			dst, err := d.appendJSON(dst, 0)
+			return append(dst, '"'), err
+		case 3:
+			return d.appendJSON(dst, 0)
+		}
+	}
+	return dst, fmt.Errorf("%w: CBOR map key must be a text string or an integer", ErrUnsupported)
+}
+
+func (d *cborDecoder) appendSimple(dst []byte, ai byte, arg uint64) ([]byte, error) {
+	var f float64
+	switch ai {
+	case 20:
+		return append(dst, "false"...), nil
+	case 21:
+		return append(dst, "true"...), nil
+	case 22, 23:
+		return append(dst, "null"...), nil
+	case 25:
+		f = float16ToFloat64(uint16(arg))
+	case 26:
+		f = float64(math.Float32frombits(uint32(arg)))
+	case 27:
+		f = math.Float64frombits(arg)
+	default:
+		return dst, fmt.Errorf("%w: CBOR simple value with additional information %d", ErrUnsupported, ai)
+	}
+	return appendJSONFloat(dst, f)
+}
+
+func float16ToFloat64(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	default:
+		return sign * math.Ldexp(mant+1024, exp-25)
+	}
+}
@@ -0,0 +1,156 @@
+package transcode
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// examples from RFC 8949 Appendix A.
+var cborExamples = []struct {
+	json string
+	cbor string
+}{
+	{`0`, "00"},
+	{`23`, "17"},
+	{`24`, "1818"},
+	{`1000`, "1903e8"},
+	{`1000000`, "1a000f4240"},
+	{`1000000000000`, "1b000000e8d4a51000"},
+	{`18446744073709551615`, "1bffffffffffffffff"},
+	{`18446744073709551616`, "c249010000000000000000"},
+	{`-18446744073709551617`, "c349010000000000000000"},
+	{`-1`, "20"},
+	{`-1000`, "3903e7"},
+	{`1.1`, "fb3ff199999999999a"},
+	{`-4.1`, "fbc010666666666666"},
+	{`false`, "f4"},
+	{`true`, "f5"},
+	{`null`, "f6"},
+	{`""`, "60"},
+	{`"IETF"`, "6449455446"},
+	{`"ü"`, "62c3bc"},
+	{`[]`, "80"},
+	{`[1,2,3]`, "83010203"},
+	{`[1,[2,3],[4,5]]`, "8301820203820405"},
+	{`{}`, "a0"},
+	{`{"a":1,"b":[2,3]}`, "a26161016162820203"},
+}
+
+func TestJSONToCBOR(t *testing.T) {
+	for _, ex := range cborExamples {
+		out, err := JSONToCBOR([]byte{0xaa}, []byte(ex.json))
+		assert.NilError(t, err)
+		assert.Equal(t, "aa"+ex.cbor, hex.EncodeToString(out), "json = %s", ex.json)
+	}
+
+	// members keep their order.
+	out, err := JSONToCBOR(nil, []byte(`{"b":1,"a":2}`))
+	assert.NilError(t, err)
+	assert.Equal(t, "a2616201616102", hex.EncodeToString(out))
+
+	for _, input := range []string{``, `[`, `{"a"}`, `1 2`, `nul`} {
+		_, err := JSONToCBOR(nil, []byte(input))
+		assert.Assert(t, err != nil, "input = %q", input)
+	}
+}
+
+func TestCBORToJSON(t *testing.T) {
+	for _, ex := range cborExamples {
+		data, _ := hex.DecodeString(ex.cbor)
+		out, err := CBORToJSON([]byte("x"), data)
+		assert.NilError(t, err)
+		assert.Equal(t, "x"+ex.json, string(out), "cbor = %s", ex.cbor)
+	}
+
+	for _, tc := range []struct {
+		cbor string
+		json string
+	}{
+		{"f7", `null`},
+		{"f90000", `0`},
+		{"f93c00", `1`},
+		{"f9c400", `-4`},
+		{"f90001", `5.960464477539063e-08`},
+		{"fa47c35000", `100000`},
+		{"3bffffffffffffffff", `-18446744073709551616`},
+		{"4401020304", `"AQIDBA=="`},
+		{"c074323031332d30332d32315432303a30343a30305a", `"2013-03-21T20:04:00Z"`},
+		{"5f42010243030405ff", `"AQIDBAU="`},
+		{"7f657374726561646d696e67ff", `"streaming"`},
+		{"9fff", `[]`},
+		{"9f018202039f0405ffff", `[1,[2,3],[4,5]]`},
+		{"bf61610161629f0203ffff", `{"a":1,"b":[2,3]}`},
+		{"a201020304", `{"1":2,"3":4}`},
+		{"a1206161", `{"-1":"a"}`},
+		{"826161f7", `["a",null]`},
+		{"c48221196ab3", `273.15`},
+		{"c4822003", `0.3`},
+		{"c482381ec249010000000000000000", `0.0000000000018446744073709551616`},
+		{"c482181903", `3e25`},
+		{"c4820a03", `30000000000`},
+		{"c2420001", `1`},
+	} {
+		data, _ := hex.DecodeString(tc.cbor)
+		out, err := CBORToJSON(nil, data)
+		assert.NilError(t, err, "cbor = %s", tc.cbor)
+		assert.Equal(t, tc.json, string(out), "cbor = %s", tc.cbor)
+	}
+
+	for _, tc := range []struct {
+		cbor string
+		err  error
+	}{
+		{"", ErrSyntax},
+		{"18", ErrSyntax},
+		{"62c3", ErrSyntax},
+		{"8301", ErrSyntax},
+		{"0001", ErrSyntax},
+		{"1c", ErrSyntax},
+		{"62c328", ErrSyntax},
+		{"5f6161ff", ErrSyntax},
+		{"f97e00", ErrUnsupported},
+		{"fb7ff0000000000000", ErrUnsupported},
+		{"a1f401", ErrUnsupported},
+		{"e0", ErrUnsupported},
+		{"c201", ErrSyntax},
+		{"c48101", ErrSyntax},
+		{"c482c2410101", ErrSyntax},
+	} {
+		data, _ := hex.DecodeString(tc.cbor)
+		_, err := CBORToJSON(nil, data)
+		assert.ErrorIs(t, err, tc.err, "cbor = %s", tc.cbor)
+	}
+}
+
+func TestCBOR_numberRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		json string
+		cbor string // hex of the head of the encoded item.
+	}{
+		{`1180591620717411303425`, "c2"},
+		{`-1180591620717411303425`, "c3"},
+		{`100000000000000000000`, "c2"},
+		{`0.1`, "fb"},
+		{`1e+300`, "fb"},
+		{`1.25`, "fb"},
+		{`0.12345678901234567890123`, "c4"},
+		{`-123456789012345678901.5`, "c4"},
+		{`1e400`, "c4"},
+	} {
+		data, err := JSONToCBOR(nil, []byte(tc.json))
+		assert.NilError(t, err, "json = %s", tc.json)
+		assert.Equal(t, tc.cbor, hex.EncodeToString(data[:1]), "json = %s", tc.json)
+		out, err := CBORToJSON(nil, data)
+		assert.NilError(t, err, "json = %s", tc.json)
+		assert.Equal(t, tc.json, string(out))
+	}
+}
+
+func TestIsCBORUndefined(t *testing.T) {
+	assert.Assert(t, IsCBORUndefined([]byte{CBORUndefined}))
+	assert.Assert(t, !IsCBORUndefined([]byte{CBORNull}))
+	assert.Assert(t, !IsCBORUndefined([]byte{CBORUndefined, 0}))
+	assert.Assert(t, !IsCBORUndefined(nil))
+}
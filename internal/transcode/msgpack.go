@@ -0,0 +1,400 @@
+package transcode
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// MsgpackNil is the MessagePack nil.
+const MsgpackNil = 0xc0
+
+const msgpackTimestampExt = -1
+
+// JSONToMsgpack appends data, a JSON text, to dst as a MessagePack object.
+//
+// Numbers are encoded as integers in the smallest format if those are integers representable in 64 bits,
+// as float64 otherwise. Members of objects keep their order.
+func JSONToMsgpack(dst, data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dst, err := appendMsgpack(dst, dec)
+	if err != nil {
+		return dst, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return dst, fmt.Errorf("%w: trailing data after JSON value", ErrSyntax)
+	}
+	return dst, nil
+}
+
+func appendMsgpack(dst []byte, dec *json.Decoder) ([]byte, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return dst, err
+	}
+	switch t := tok.(type) {
+	case nil:
+		return append(dst, MsgpackNil), nil
+	case bool:
+		if t {
+			return append(dst, 0xc3), nil
+		}
+		return append(dst, 0xc2), nil
+	case json.Number:
+		return appendMsgpackNumber(dst, t)
+	case string:
+		return appendMsgpackString(dst, t), nil
+	case json.Delim:
+		var (
+			items []byte
+			n     uint64
+		)
+		for dec.More() {
+			if t == '{' {
+				items, err = appendMsgpack(items, dec)
+				if err != nil {
+					return dst, err
+				}
+			}
+			items, err = appendMsgpack(items, dec)
+			if err != nil {
+				return dst, err
+			}
+			n++
+		}
+		if _, err := dec.Token(); err != nil {
+			return dst, err
+		}
+		if n > math.MaxUint32 {
+			return dst, fmt.Errorf("%w: too many elements for MessagePack", ErrUnsupported)
+		}
+		if t == '{' {
+			dst = appendMsgpackLen(dst, n, 0x80, 0xde)
+		} else {
+			dst = appendMsgpackLen(dst, n, 0x90, 0xdc)
+		}
+		return append(dst, items...), nil
+	}
+	return dst, fmt.Errorf("%w: unknown token %v", ErrSyntax, tok)
+}
+
+// appendMsgpackLen appends the header of an array or a map, whose fix format is fix and 16-bit format is b16.
+// The 32-bit format always follows the 16-bit one.
+func appendMsgpackLen(dst []byte, n uint64, fix, b16 byte) []byte {
+	switch {
+	case n < 16:
+		return append(dst, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, b16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, b16+1), uint32(n))
+	}
+}
+
+func appendMsgpackString(dst []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n < 32:
+		dst = append(dst, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		dst = append(dst, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xda), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xdb), uint32(n))
+	}
+	return append(dst, s...)
+}
+
+func appendMsgpackNumber(dst []byte, n json.Number) ([]byte, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		if i >= 0 {
+			return appendMsgpackUint(dst, uint64(i)), nil
+		}
+		switch {
+		case i >= -32:
+			return append(dst, byte(i)), nil
+		case i >= math.MinInt8:
+			return append(dst, 0xd0, byte(i)), nil
+		case i >= math.MinInt16:
+			return binary.BigEndian.AppendUint16(append(dst, 0xd1), uint16(i)), nil
+		case i >= math.MinInt32:
+			return binary.BigEndian.AppendUint32(append(dst, 0xd2), uint32(i)), nil
+		default:
+			return binary.BigEndian.AppendUint64(append(dst, 0xd3), uint64(i)), nil
+		}
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return appendMsgpackUint(dst, u), nil
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return dst, fmt.Errorf("%w: number %s: %w", ErrUnsupported, n, err)
+	}
+	return binary.BigEndian.AppendUint64(append(dst, 0xcb), math.Float64bits(f)), nil
+}
+
+func appendMsgpackUint(dst []byte, u uint64) []byte {
+	switch {
+	case u < 128:
+		return append(dst, byte(u))
+	case u <= math.MaxUint8:
+		return append(dst, 0xcc, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xcd), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xce), uint32(u))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xcf), u)
+	}
+}
+
+// MsgpackToJSON appends data, a single MessagePack object, to dst as a JSON text.
+//
+// Binaries are converted to base64 encoded strings as encoding/json does for []byte,
+// and timestamps (extension type -1) to RFC 3339 strings as encoding/json does for time.Time.
+// Other extension types are not supported.
+// Map keys must be strings or integers; integer keys are converted to strings.
+// data must not have trailing bytes after the object.
+func MsgpackToJSON(dst, data []byte) ([]byte, error) {
+	d := &msgpackDecoder{data: data}
+	dst, err := d.appendJSON(dst, 0)
+	if err != nil {
+		return dst, err
+	}
+	if d.off != len(d.data) {
+		return dst, fmt.Errorf("%w: trailing bytes after MessagePack object at offset %d", ErrSyntax, d.off)
+	}
+	return dst, nil
+}
+
+type msgpackDecoder struct {
+	data []byte
+	off  int
+}
+
+func (d *msgpackDecoder) read(n uint64) ([]byte, error) {
+	if uint64(len(d.data)-d.off) < n {
+		return nil, fmt.Errorf("%w: unexpected end of MessagePack input", ErrSyntax)
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+func (d *msgpackDecoder) readUint(size int) (uint64, error) {
+	b, err := d.read(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+func (d *msgpackDecoder) appendJSON(dst []byte, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return dst, fmt.Errorf("%w: MessagePack nested too deeply", ErrUnsupported)
+	}
+	b, err := d.read(1)
+	if err != nil {
+		return dst, err
+	}
+	switch c := b[0]; {
+	case c <= 0x7f:
+		return strconv.AppendUint(dst, uint64(c), 10), nil
+	case c >= 0xe0:
+		return strconv.AppendInt(dst, int64(int8(c)), 10), nil
+	case c&0xf0 == 0x80:
+		return d.appendMap(dst, uint64(c&0x0f), depth)
+	case c&0xf0 == 0x90:
+		return d.appendArray(dst, uint64(c&0x0f), depth)
+	case c&0xe0 == 0xa0:
+		return d.appendString(dst, uint64(c&0x1f))
+	}
+
+	switch c := b[0]; c {
+	case MsgpackNil:
+		return append(dst, "null"...), nil
+	case 0xc2:
+		return append(dst, "false"...), nil
+	case 0xc3:
+		return append(dst, "true"...), nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.readUint(1 << (c - 0xc4))
+		if err != nil {
+			return dst, err
+		}
+		bin, err := d.read(n)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, '"')
+		dst = base64.StdEncoding.AppendEncode(dst, bin)
+		return append(dst, '"'), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.readUint(1 << (c - 0xc7))
+		if err != nil {
+			return dst, err
+		}
+		return d.appendExt(dst, n)
+	case 0xca:
+		u, err := d.readUint(4)
+		if err != nil {
+			return dst, err
+		}
+		return appendJSONFloat(dst, float64(math.Float32frombits(uint32(u))))
+	case 0xcb:
+		u, err := d.readUint(8)
+		if err != nil {
+			return dst, err
+		}
+		return appendJSONFloat(dst, math.Float64frombits(u))
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := d.readUint(1 << (c - 0xcc))
+		if err != nil {
+			return dst, err
+		}
+		return strconv.AppendUint(dst, u, 10), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		u, err := d.readUint(size)
+		if err != nil {
+			return dst, err
+		}
+		// sign-extend
+		shift := 64 - 8*size
+		return strconv.AppendInt(dst, int64(u<<shift)>>shift, 10), nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.appendExt(dst, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.readUint(1 << (c - 0xd9))
+		if err != nil {
+			return dst, err
+		}
+		return d.appendString(dst, n)
+	case 0xdc, 0xdd:
+		n, err := d.readUint(2 << (c - 0xdc))
+		if err != nil {
+			return dst, err
+		}
+		return d.appendArray(dst, n, depth)
+	case 0xde, 0xdf:
+		n, err := d.readUint(2 << (c - 0xde))
+		if err != nil {
+			return dst, err
+		}
+		return d.appendMap(dst, n, depth)
+	}
+	return dst, fmt.Errorf("%w: MessagePack format 0x%x at offset %d is never used", ErrSyntax, b[0], d.off-1)
+}
+
+func (d *msgpackDecoder) appendString(dst []byte, n uint64) ([]byte, error) {
+	s, err := d.read(n)
+	if err != nil {
+		return dst, err
+	}
+	if !utf8.Valid(s) {
+		return dst, fmt.Errorf("%w: invalid UTF-8 in MessagePack string", ErrSyntax)
+	}
+	bin, _ := json.Marshal(string(s))
+	return append(dst, bin...), nil
+}
+
+func (d *msgpackDecoder) appendArray(dst []byte, n uint64, depth int) ([]byte, error) {
+	var err error
+	dst = append(dst, '[')
+	for i := uint64(0); i < n; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst, err = d.appendJSON(dst, depth+1)
+		if err != nil {
+			return dst, err
+		}
+	}
+	return append(dst, ']'), nil
+}
+
+func (d *msgpackDecoder) appendMap(dst []byte, n uint64, depth int) ([]byte, error) {
+	var err error
+	dst = append(dst, '{')
+	for i := uint64(0); i < n; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst, err = d.appendKey(dst)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, ':')
+		dst, err = d.appendJSON(dst, depth+1)
+		if err != nil {
+			return dst, err
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+func (d *msgpackDecoder) appendKey(dst []byte) ([]byte, error) {
+	if d.off < len(d.data) {
+		switch c := d.data[d.off]; {
+		case c&0xe0 == 0xa0, c >= 0xd9 && c <= 0xdb:
+			return d.appendJSON(dst, 0)
+		case c <= 0x7f, c >= 0xe0, c >= 0xcc && c <= 0xd3:
+			dst = append(dst, '"')
+			dst, err := d.appendJSON(dst, 0)
+			return append(dst, '"'), err
+		}
+	}
+	return dst, fmt.Errorf("%w: MessagePack map key must be a string or an integer", ErrUnsupported)
+}
+
+func (d *msgpackDecoder) appendExt(dst []byte, n uint64) ([]byte, error) {
+	ty, err := d.read(1)
+	if err != nil {
+		return dst, err
+	}
+	body, err := d.read(n)
+	if err != nil {
+		return dst, err
+	}
+	if int8(ty[0]) != msgpackTimestampExt {
+		return dst, fmt.Errorf("%w: MessagePack extension type %d", ErrUnsupported, int8(ty[0]))
+	}
+	var (
+		sec  int64
+		nsec int64
+	)
+	switch len(body) {
+	case 4:
+		sec = int64(binary.BigEndian.Uint32(body))
+	case 8:
+		u := binary.BigEndian.Uint64(body)
+		nsec, sec = int64(u>>34), int64(u&(1<<34-1))
+	case 12:
+		nsec, sec = int64(binary.BigEndian.Uint32(body)), int64(binary.BigEndian.Uint64(body[4:]))
+	default:
+		return dst, fmt.Errorf("%w: MessagePack timestamp of %d bytes", ErrSyntax, len(body))
+	}
+	dst = append(dst, '"')
+	dst = time.Unix(sec, nsec).UTC().AppendFormat(dst, time.RFC3339Nano)
+	return append(dst, '"'), nil
+}
+
+func appendJSONFloat(dst []byte, f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return dst, fmt.Errorf("%w: float %v", ErrUnsupported, f)
+	}
+	return strconv.AppendFloat(dst, f, 'g', -1, 64), nil
+}
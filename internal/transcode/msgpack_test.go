@@ -0,0 +1,111 @@
+package transcode
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var msgpackExamples = []struct {
+	json    string
+	msgpack string
+}{
+	{`0`, "00"},
+	{`127`, "7f"},
+	{`128`, "cc80"},
+	{`256`, "cd0100"},
+	{`65536`, "ce00010000"},
+	{`4294967296`, "cf0000000100000000"},
+	{`18446744073709551615`, "cfffffffffffffffff"},
+	{`-1`, "ff"},
+	{`-32`, "e0"},
+	{`-33`, "d0df"},
+	{`-129`, "d1ff7f"},
+	{`-32769`, "d2ffff7fff"},
+	{`-2147483649`, "d3ffffffff7fffffff"},
+	{`1.5`, "cb3ff8000000000000"},
+	{`false`, "c2"},
+	{`true`, "c3"},
+	{`null`, "c0"},
+	{`""`, "a0"},
+	{`"abc"`, "a3616263"},
+	{`[]`, "90"},
+	{`[1,[2,3]]`, "9201920203"},
+	{`{}`, "80"},
+	{`{"b":1,"a":[true]}`, "82a16201a16191c3"},
+}
+
+func TestJSONToMsgpack(t *testing.T) {
+	for _, ex := range msgpackExamples {
+		out, err := JSONToMsgpack([]byte{0xaa}, []byte(ex.json))
+		assert.NilError(t, err)
+		assert.Equal(t, "aa"+ex.msgpack, hex.EncodeToString(out), "json = %s", ex.json)
+	}
+
+	out, err := JSONToMsgpack(nil, []byte(`"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"`))
+	assert.NilError(t, err)
+	assert.Equal(t, "d921", hex.EncodeToString(out[:2]))
+	out, err = JSONToMsgpack(nil, []byte(`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]`))
+	assert.NilError(t, err)
+	assert.Equal(t, "dc0010", hex.EncodeToString(out[:3]))
+
+	for _, input := range []string{``, `[`, `{"a"}`, `1 2`} {
+		_, err := JSONToMsgpack(nil, []byte(input))
+		assert.Assert(t, err != nil, "input = %q", input)
+	}
+}
+
+func TestMsgpackToJSON(t *testing.T) {
+	for _, ex := range msgpackExamples {
+		data, _ := hex.DecodeString(ex.msgpack)
+		out, err := MsgpackToJSON([]byte("x"), data)
+		assert.NilError(t, err)
+		assert.Equal(t, "x"+ex.json, string(out), "msgpack = %s", ex.msgpack)
+	}
+
+	for _, tc := range []struct {
+		msgpack string
+		json    string
+	}{
+		{"ca3fc00000", `1.5`},
+		{"d0ff", `-1`},
+		{"d3ffffffffffffffff", `-1`},
+		{"d903616263", `"abc"`},
+		{"da0003616263", `"abc"`},
+		{"c40401020304", `"AQIDBA=="`},
+		{"dc0002c0c3", `[null,true]`},
+		{"de0001a161c2", `{"a":false}`},
+		{"8201020304", `{"1":2,"3":4}`},
+		{"81ffa161", `{"-1":"a"}`},
+		{"d6ff00000000", `"1970-01-01T00:00:00Z"`},
+		{"d7ff0000000400000001", `"1970-01-01T00:00:01.000000001Z"`},
+		{"c70cff000000010000000000000002", `"1970-01-01T00:00:02.000000001Z"`},
+	} {
+		data, _ := hex.DecodeString(tc.msgpack)
+		out, err := MsgpackToJSON(nil, data)
+		assert.NilError(t, err, "msgpack = %s", tc.msgpack)
+		assert.Equal(t, tc.json, string(out), "msgpack = %s", tc.msgpack)
+	}
+
+	for _, tc := range []struct {
+		msgpack string
+		err     error
+	}{
+		{"", ErrSyntax},
+		{"c1", ErrSyntax},
+		{"cc", ErrSyntax},
+		{"a2c3", ErrSyntax},
+		{"a2c328", ErrSyntax},
+		{"9201", ErrSyntax},
+		{"0001", ErrSyntax},
+		{"d6ff0000", ErrSyntax},
+		{"d501ffff", ErrUnsupported},
+		{"cb7ff0000000000000", ErrUnsupported},
+		{"81c301", ErrUnsupported},
+	} {
+		data, _ := hex.DecodeString(tc.msgpack)
+		_, err := MsgpackToJSON(nil, data)
+		assert.ErrorIs(t, err, tc.err, "msgpack = %s", tc.msgpack)
+	}
+}
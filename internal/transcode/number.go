@@ -0,0 +1,99 @@
+package transcode
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decimal is a JSON number split into its digits and exponent: the value is ±digits × 10^exp.
+type decimal struct {
+	neg    bool
+	digits string
+	exp    int
+}
+
+// parseDecimal splits n into a decimal, keeping digits as written except for leading zeros.
+// It fails only if the exponent overflows int.
+func parseDecimal(n json.Number) (decimal, error) {
+	s := string(n)
+	var d decimal
+	if strings.HasPrefix(s, "-") {
+		d.neg = true
+		s = s[1:]
+	}
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(strings.TrimPrefix(s[i+1:], "+"))
+		if err != nil {
+			return d, fmt.Errorf("%w: number %s: %w", ErrUnsupported, n, err)
+		}
+		d.exp = exp
+		s = s[:i]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	d.digits = strings.TrimLeft(intPart+frac, "0")
+	d.exp -= len(frac)
+	if d.digits == "" {
+		d = decimal{}
+	}
+	return d, nil
+}
+
+// normalize moves trailing zeros of d.digits into d.exp, so that equal values have equal decimals.
+func (d decimal) normalize() decimal {
+	trimmed := strings.TrimRight(d.digits, "0")
+	d.exp += len(d.digits) - len(trimmed)
+	d.digits = trimmed
+	return d
+}
+
+// isInteger reports whether n is written as an integer, without a fraction nor an exponent.
+func isInteger(n json.Number) bool {
+	return !strings.ContainsAny(string(n), ".eE")
+}
+
+// exactFloat returns n as float64 if converting it back to JSON, as CBORToJSON and MsgpackToJSON do,
+// results in the same value.
+func exactFloat(n json.Number) (float64, bool) {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return 0, false
+	}
+	d, err := parseDecimal(n)
+	if err != nil {
+		return 0, false
+	}
+	fd, _ := parseDecimal(json.Number(strconv.FormatFloat(f, 'e', -1, 64)))
+	return f, d.normalize() == fd.normalize()
+}
+
+// appendDecimal appends ±mant × 10^exp to dst as a JSON number.
+// Plain notation is used unless it needs more than 20 padding zeros.
+func appendDecimal(dst []byte, neg bool, mant string, exp int64) []byte {
+	mant = strings.TrimLeft(mant, "0")
+	if mant == "" {
+		return append(dst, '0')
+	}
+	if neg {
+		dst = append(dst, '-')
+	}
+	const maxPadding = 20
+	l := int64(len(mant))
+	switch {
+	case exp >= 0 && exp <= maxPadding:
+		dst = append(dst, mant...)
+		return append(dst, strings.Repeat("0", int(exp))...)
+	case exp < 0 && exp > -l:
+		dst = append(dst, mant[:l+exp]...)
+		dst = append(dst, '.')
+		return append(dst, mant[l+exp:]...)
+	case exp < 0 && exp >= -l-maxPadding:
+		dst = append(dst, "0."...)
+		dst = append(dst, strings.Repeat("0", int(-exp-l))...)
+		return append(dst, mant...)
+	}
+	dst = append(dst, mant...)
+	dst = append(dst, 'e')
+	return strconv.AppendInt(dst, exp, 10)
+}
@@ -0,0 +1,118 @@
+// Package undreflect implements reflection helpers shared among packages
+// which handle und types generically, e.g. undenv, undform, undconfig and undgorm.
+//
+// und types are recognized by interfaces rather than by concrete types,
+// so that third party types implementing und.UndStater, validate.UndLike or validate.OptionLike are treated alike.
+package undreflect
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ngicks/und/internal/undstate"
+	"github.com/ngicks/und/undtag"
+)
+
+var (
+	UndStaterTy       = reflect.TypeFor[undstate.UndStater]()
+	UndLikeTy         = reflect.TypeFor[undtag.UndLike]()
+	OptionLikeTy      = reflect.TypeFor[undtag.OptionLike]()
+	ElasticLikeTy     = reflect.TypeFor[undtag.ElasticLike]()
+	JSONUnmarshalerTy = reflect.TypeFor[json.Unmarshaler]()
+	TextUnmarshalerTy = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// HasState reports whether rt implements und.UndStater, validate.UndLike or validate.OptionLike,
+// that is, whether [StateOf] can tell the state of its values.
+func HasState(rt reflect.Type) bool {
+	return rt.Implements(UndStaterTy) || rt.Implements(UndLikeTy) || rt.Implements(OptionLikeTy)
+}
+
+// IsUndType reports whether rt is an und type which can be decoded from JSON,
+// i.e. rt satisfies [HasState] and implements json.Unmarshaler via its pointer.
+func IsUndType(rt reflect.Type) bool {
+	return HasState(rt) && reflect.PointerTo(rt).Implements(JSONUnmarshalerTy)
+}
+
+// ValueType returns the type of the value of und types, e.g. T of und.Und[T],
+// which is the return type of the Value method.
+// It returns nil if rt has no such method.
+func ValueType(rt reflect.Type) reflect.Type {
+	m, ok := rt.MethodByName("Value")
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 1 {
+		return nil
+	}
+	return m.Type.Out(0)
+}
+
+// StateOf returns the state of v.
+// Values of validate.OptionLike are null if none, defined otherwise.
+// ok is false if v is neither of und.UndStater, validate.UndLike nor validate.OptionLike, or is a nil pointer.
+func StateOf(v any) (s undstate.State, ok bool) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return 0, false
+	}
+	switch x := v.(type) {
+	case undstate.UndStater:
+		return x.State(), true
+	case undtag.UndLike:
+		switch {
+		case x.IsDefined():
+			return undstate.StateDefined, true
+		case x.IsNull():
+			return undstate.StateNull, true
+		default:
+			return undstate.StateUndefined, true
+		}
+	case undtag.OptionLike:
+		if x.IsNone() {
+			return undstate.StateNull, true
+		}
+		return undstate.StateDefined, true
+	}
+	return 0, false
+}
+
+// WalkTagged calls fn for each exported field of the struct rv whose tag tagName is neither empty nor "-",
+// with the tag value as name.
+// Fields tagged "-" are skipped, as encoding/json does.
+// Fields without the tag are skipped too, except for nested structs which are not und types and non-nil pointers to structs,
+// which are walked recursively.
+//
+// Errors returned by fn are prefixed with the field name, and with names of enclosing fields separated by dots,
+// e.g. "Outer.Inner: err".
+func WalkTagged(rv reflect.Value, tagName string, fn func(ft reflect.StructField, fv reflect.Value, name string) error) error {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		ft := rt.Field(i)
+		if !ft.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+
+		name, ok := ft.Tag.Lookup(tagName)
+		if name == "-" {
+			continue
+		}
+		if !ok || name == "" {
+			switch {
+			case fv.Kind() == reflect.Struct && !IsUndType(ft.Type):
+				if err := WalkTagged(fv, tagName, fn); err != nil {
+					return fmt.Errorf("%s.%w", ft.Name, err)
+				}
+			case fv.Kind() == reflect.Pointer && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct:
+				if err := WalkTagged(fv.Elem(), tagName, fn); err != nil {
+					return fmt.Errorf("%s.%w", ft.Name, err)
+				}
+			}
+			continue
+		}
+
+		if err := fn(ft, fv, name); err != nil {
+			return fmt.Errorf("%s: %w", ft.Name, err)
+		}
+	}
+	return nil
+}
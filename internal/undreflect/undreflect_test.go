@@ -0,0 +1,77 @@
+package undreflect_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"gotest.tools/v3/assert"
+)
+
+func TestStateOf(t *testing.T) {
+	for _, tc := range []struct {
+		v     any
+		state und.State
+		ok    bool
+	}{
+		{und.Undefined[int](), und.StateUndefined, true},
+		{sliceund.Null[int](), und.StateNull, true},
+		{elastic.FromValue(1), und.StateDefined, true},
+		{option.None[int](), und.StateNull, true},
+		{option.Some(1), und.StateDefined, true},
+		{(*und.Und[int])(nil), 0, false},
+		{1, 0, false},
+		{nil, 0, false},
+	} {
+		s, ok := undreflect.StateOf(tc.v)
+		assert.Equal(t, tc.state, s, "%#v", tc.v)
+		assert.Equal(t, tc.ok, ok, "%#v", tc.v)
+	}
+}
+
+func TestValueType(t *testing.T) {
+	assert.Equal(t, reflect.TypeFor[string](), undreflect.ValueType(reflect.TypeFor[und.Und[string]]()))
+	assert.Equal(t, reflect.TypeFor[int](), undreflect.ValueType(reflect.TypeFor[elastic.Elastic[int]]()))
+	assert.Assert(t, undreflect.ValueType(reflect.TypeFor[int]()) == nil)
+}
+
+func TestWalkTagged(t *testing.T) {
+	type inner struct {
+		B und.Und[int] `t:"b"`
+	}
+	type target struct {
+		A       und.Und[int] `t:"a"`
+		Skipped und.Und[int] `t:"-"`
+		Nested  inner
+		Ignored inner  `t:"-"`
+		IgnPtr  *inner `t:"-"`
+		Ptr     *inner
+		NilPtr  *inner
+		Und     und.Und[inner]
+		private und.Und[int] `t:"private"`
+	}
+	v := target{Ptr: &inner{}, IgnPtr: &inner{}}
+
+	var names []string
+	err := undreflect.WalkTagged(reflect.ValueOf(&v).Elem(), "t", func(ft reflect.StructField, fv reflect.Value, name string) error {
+		names = append(names, name)
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"a", "b", "b"}, names)
+
+	sentinel := errors.New("sentinel")
+	err = undreflect.WalkTagged(reflect.ValueOf(&v).Elem(), "t", func(ft reflect.StructField, fv reflect.Value, name string) error {
+		if name == "b" {
+			return sentinel
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, sentinel)
+	assert.Error(t, err, "Nested.B: sentinel")
+}
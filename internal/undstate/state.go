@@ -0,0 +1,98 @@
+// Package undstate defines the state of und types.
+//
+// It is separated from the und package so that packages imported by und, e.g. validate,
+// can refer to the state without an import cycle.
+// The und package re-exports everything as aliases; see und.State for the documentation.
+package undstate
+
+import (
+	"encoding"
+	"fmt"
+	"strconv"
+)
+
+var (
+	_ fmt.Stringer             = State(0)
+	_ encoding.TextMarshaler   = State(0)
+	_ encoding.TextUnmarshaler = (*State)(nil)
+)
+
+// State is the state of und types: undefined, null or defined.
+//
+// States are bit flags so that multiple states can be combined into a mask, e.g. StateUndefined | StateNull.
+type State int
+
+const (
+	StateUndefined = State(1 << iota)
+	StateNull
+	StateDefined
+)
+
+// String implements fmt.Stringer.
+// It returns "undefined", "null" or "defined", or "State(n)" for other values.
+func (s State) String() string {
+	switch s {
+	case StateUndefined:
+		return "undefined"
+	case StateNull:
+		return "null"
+	case StateDefined:
+		return "defined"
+	}
+	return "State(" + strconv.Itoa(int(s)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns an error for values other than StateUndefined, StateNull and StateDefined.
+func (s State) MarshalText() ([]byte, error) {
+	if !s.IsValid() {
+		return nil, fmt.Errorf("und: invalid State %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts "undefined", "null" and "defined".
+func (s *State) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "undefined":
+		*s = StateUndefined
+	case "null":
+		*s = StateNull
+	case "defined":
+		*s = StateDefined
+	default:
+		return fmt.Errorf("und: unknown State %q", text)
+	}
+	return nil
+}
+
+// IsValid reports whether s is exactly one of StateUndefined, StateNull or StateDefined.
+func (s State) IsValid() bool {
+	return s == StateUndefined || s == StateNull || s == StateDefined
+}
+
+// Is reports whether s is any of states.
+// Each of states may be a mask of combined states.
+func (s State) Is(states ...State) bool {
+	for _, state := range states {
+		if s&state != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// UndStater is implemented by types which report their state as [State],
+// e.g. Und[T], sliceund.Und[T] and elastic types.
+//
+// Third party optional types, e.g. presence wrappers of generated code, can implement UndStater
+// so that validate.UndValidate, validate.UndCheck and functions of the patch package
+// treat them like Und[T] without conversion.
+type UndStater interface {
+	// State returns the state of the value.
+	State() State
+	// AnyValue returns the underlying value as any.
+	// The returned value is only meaningful if State returns StateDefined.
+	AnyValue() any
+}
@@ -0,0 +1,95 @@
+package und
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FromJSONPointers looks up values pointed by pointers in the raw JSON document data,
+// without decoding the entire document into Go values.
+//
+// pointers must be [RFC 6901] JSON Pointers, e.g. "/foo/0/bar".
+// The returned map has an entry for each pointer:
+// it is undefined if the document has nothing at the location, null if the value is JSON null,
+// and defined with the raw JSON value otherwise.
+//
+// FromJSONPointers returns an error if data is not valid JSON or any of pointers is malformed.
+//
+// [RFC 6901]: https://www.rfc-editor.org/rfc/rfc6901
+func FromJSONPointers(data []byte, pointers ...string) (map[string]Und[json.RawMessage], error) {
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("FromJSONPointers: invalid JSON document")
+	}
+	l := jsonPointerLookup{
+		objects: make(map[string]map[string]json.RawMessage),
+		arrays:  make(map[string][]json.RawMessage),
+	}
+	values := make(map[string]Und[json.RawMessage], len(pointers))
+	for _, p := range pointers {
+		u, err := l.lookup(data, p)
+		if err != nil {
+			return nil, err
+		}
+		values[p] = u
+	}
+	return values, nil
+}
+
+// jsonPointerLookup caches decoded containers keyed by their JSON pointer
+// so that multiple pointers sharing prefixes do not decode same values repeatedly.
+type jsonPointerLookup struct {
+	objects map[string]map[string]json.RawMessage
+	arrays  map[string][]json.RawMessage
+}
+
+func (l jsonPointerLookup) lookup(data []byte, pointer string) (Und[json.RawMessage], error) {
+	if pointer != "" && pointer[0] != '/' {
+		return Undefined[json.RawMessage](), fmt.Errorf("FromJSONPointers: malformed pointer %q: must be empty or start with /", pointer)
+	}
+
+	cur := json.RawMessage(bytes.TrimSpace(data))
+	prefix := ""
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		key := strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		var (
+			next json.RawMessage
+			ok   bool
+		)
+		switch cur[0] {
+		case '{':
+			obj, has := l.objects[prefix]
+			if !has {
+				if err := json.Unmarshal(cur, &obj); err != nil {
+					return Undefined[json.RawMessage](), err
+				}
+				l.objects[prefix] = obj
+			}
+			next, ok = obj[key]
+		case '[':
+			arr, has := l.arrays[prefix]
+			if !has {
+				if err := json.Unmarshal(cur, &arr); err != nil {
+					return Undefined[json.RawMessage](), err
+				}
+				l.arrays[prefix] = arr
+			}
+			idx, err := strconv.ParseUint(key, 10, 64)
+			if err == nil && (len(key) == 1 || key[0] != '0') && idx < uint64(len(arr)) {
+				next, ok = arr[idx], true
+			}
+		}
+		if !ok {
+			return Undefined[json.RawMessage](), nil
+		}
+		cur = next
+		prefix += "/" + token
+	}
+
+	if string(cur) == "null" {
+		return Null[json.RawMessage](), nil
+	}
+	return Defined(cur), nil
+}
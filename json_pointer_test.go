@@ -0,0 +1,48 @@
+package und_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestFromJSONPointers(t *testing.T) {
+	doc := []byte(`{"foo":{"bar":[1,null,{"baz":"qux"}],"a/b":true,"m~n":null},"nil":null, "num": 5 }`)
+
+	values, err := und.FromJSONPointers(
+		doc,
+		"", "/foo/bar/0", "/foo/bar/1", "/foo/bar/2/baz", "/foo/bar/3", "/foo/bar/01",
+		"/foo/a~1b", "/foo/m~0n", "/nil", "/nil/foo", "/num", "/num/0", "/missing",
+	)
+	assert.NilError(t, err)
+
+	for pointer, expected := range map[string]string{
+		"":               string(doc),
+		"/foo/bar/0":     `1`,
+		"/foo/bar/2/baz": `"qux"`,
+		"/foo/a~1b":      `true`,
+		"/num":           `5`,
+	} {
+		u := values[pointer]
+		assert.Assert(t, u.IsDefined(), "pointer = %q", pointer)
+		assert.Equal(t, string(u.Value()), expected, "pointer = %q", pointer)
+	}
+	for _, pointer := range []string{"/foo/bar/1", "/foo/m~0n", "/nil"} {
+		assert.Assert(t, values[pointer].IsNull(), "pointer = %q", pointer)
+	}
+	for _, pointer := range []string{"/foo/bar/3", "/foo/bar/01", "/nil/foo", "/num/0", "/missing"} {
+		assert.Assert(t, values[pointer].IsUndefined(), "pointer = %q", pointer)
+	}
+
+	_, err = und.FromJSONPointers(doc, "foo")
+	assert.ErrorContains(t, err, "malformed pointer")
+	_, err = und.FromJSONPointers([]byte(`{"foo":`), "/foo")
+	assert.ErrorContains(t, err, "invalid JSON")
+
+	var raw json.RawMessage = values["/foo/bar/2/baz"].Value()
+	var s string
+	assert.NilError(t, json.Unmarshal(raw, &s))
+	assert.Equal(t, s, "qux")
+}
@@ -0,0 +1,112 @@
+package und
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+var (
+	_ json.Marshaler   = NonNullable[any]{}
+	_ json.Unmarshaler = (*NonNullable[any])(nil)
+	_ xml.Unmarshaler  = (*NonNullable[any])(nil)
+)
+
+// NonNullable is Und[T] which rejects null on unmarshaling.
+//
+// It is for API contracts where a field may be absent or a value, but never null.
+// Unmarshaling null into NonNullable[T] fails with an error wrapping [ErrNull],
+// instead of storing a null Und[T].
+// The rule applies to every unmarshaler Und[T] implements, e.g. UnmarshalCBOR and UnmarshalGQL,
+// except for UnmarshalYAML which yaml decoders never call for null.
+// Other methods are promoted from the embedded Und[T];
+// undefined fields are still omitted by `json:",omitzero"`.
+type NonNullable[T any] struct {
+	Und[T]
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It returns an error wrapping [ErrNull] if data is null.
+func (n *NonNullable[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return fmt.Errorf("%w is not allowed for %T", ErrNull, *n)
+	}
+	return n.Und.UnmarshalJSON(data)
+}
+
+// UnmarshalXML implements xml.Unmarshaler in the same way as [Und.UnmarshalXML].
+// It returns an error wrapping [ErrNull] if the element has xsi:nil="true" attribute.
+func (n *NonNullable[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var u Und[T]
+	if err := u.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	return n.set(u)
+}
+
+// UnmarshalYAML implements the yaml unmarshaler interface in the same way as [Und.UnmarshalYAML].
+// gopkg.in/yaml.v2 and gopkg.in/yaml.v3 do not call it for null, so null is decoded as undefined rather than rejected.
+func (n *NonNullable[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var u Und[T]
+	if err := u.UnmarshalYAML(unmarshal); err != nil {
+		return err
+	}
+	return n.set(u)
+}
+
+// UnmarshalTOML implements the unmarshaler interface of github.com/BurntSushi/toml in the same way as [Und.UnmarshalTOML].
+func (n *NonNullable[T]) UnmarshalTOML(data any) error {
+	var u Und[T]
+	if err := u.UnmarshalTOML(data); err != nil {
+		return err
+	}
+	return n.set(u)
+}
+
+// UnmarshalCBOR implements the unmarshaler interface of github.com/fxamacker/cbor/v2 in the same way as [Und.UnmarshalCBOR].
+// It returns an error wrapping [ErrNull] if data is null.
+func (n *NonNullable[T]) UnmarshalCBOR(data []byte) error {
+	var u Und[T]
+	if err := u.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+	return n.set(u)
+}
+
+// UnmarshalMsgpack implements the unmarshaler interface of github.com/vmihailenco/msgpack/v5 in the same way as [Und.UnmarshalMsgpack].
+// It returns an error wrapping [ErrNull] if data is nil.
+func (n *NonNullable[T]) UnmarshalMsgpack(data []byte) error {
+	var u Und[T]
+	if err := u.UnmarshalMsgpack(data); err != nil {
+		return err
+	}
+	return n.set(u)
+}
+
+// UnmarshalGQL implements the unmarshaler interface of github.com/99designs/gqlgen in the same way as [Und.UnmarshalGQL].
+// It returns an error wrapping [ErrNull] if v is nil.
+func (n *NonNullable[T]) UnmarshalGQL(v any) error {
+	var u Und[T]
+	if err := u.UnmarshalGQL(v); err != nil {
+		return err
+	}
+	return n.set(u)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler in the same way as [Und.UnmarshalBinary].
+// It returns an error wrapping [ErrNull] if data encodes null.
+func (n *NonNullable[T]) UnmarshalBinary(data []byte) error {
+	var u Und[T]
+	if err := u.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	return n.set(u)
+}
+
+func (n *NonNullable[T]) set(u Und[T]) error {
+	if u.IsNull() {
+		return fmt.Errorf("%w is not allowed for %T", ErrNull, *n)
+	}
+	n.Und = u
+	return nil
+}
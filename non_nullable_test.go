@@ -0,0 +1,90 @@
+package und_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonNullable(t *testing.T) {
+	type sample struct {
+		V und.NonNullable[int] `json:"v,omitzero"`
+	}
+
+	var s sample
+	assert.NilError(t, json.Unmarshal([]byte(`{}`), &s))
+	assert.Assert(t, s.V.IsUndefined())
+
+	assert.NilError(t, json.Unmarshal([]byte(`{"v":5}`), &s))
+	assert.Assert(t, und.Equal(s.V.Und, und.Defined(5)))
+
+	err := json.Unmarshal([]byte(`{"v":null}`), &s)
+	assert.ErrorIs(t, err, und.ErrNull)
+	assert.ErrorContains(t, err, "null is not allowed for und.NonNullable[int]")
+	assert.Assert(t, und.Equal(s.V.Und, und.Defined(5)))
+
+	bin, err := json.Marshal(s)
+	assert.NilError(t, err)
+	assert.Equal(t, string(bin), `{"v":5}`)
+	bin, err = json.Marshal(sample{})
+	assert.NilError(t, err)
+	assert.Equal(t, string(bin), `{}`)
+}
+
+// nonNullableNullInputs calls each unmarshaler of NonNullable[int] with null.
+// Add an entry here when adding an unmarshaler to Und[T], along with the override on NonNullable[T].
+var nonNullableNullInputs = map[string]func(v any) error{
+	"UnmarshalJSON": func(v any) error {
+		return v.(json.Unmarshaler).UnmarshalJSON([]byte(`null`))
+	},
+	"UnmarshalYAML": nil, // yaml decoders do not call unmarshalers for null.
+	"UnmarshalXML": func(v any) error {
+		return xml.Unmarshal(
+			[]byte(`<v xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></v>`),
+			v,
+		)
+	},
+	"UnmarshalTOML": nil, // TOML has no null.
+	"UnmarshalCBOR": func(v any) error {
+		return v.(interface{ UnmarshalCBOR([]byte) error }).UnmarshalCBOR([]byte{0xf6})
+	},
+	"UnmarshalMsgpack": func(v any) error {
+		return v.(interface{ UnmarshalMsgpack([]byte) error }).UnmarshalMsgpack([]byte{0xc0})
+	},
+	"UnmarshalGQL": func(v any) error {
+		return v.(interface{ UnmarshalGQL(any) error }).UnmarshalGQL(nil)
+	},
+	"UnmarshalBinary": func(v any) error {
+		return v.(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte{byte(und.StateNull)})
+	},
+}
+
+func TestNonNullable_unmarshalers(t *testing.T) {
+	rt := reflect.TypeFor[*und.Und[int]]()
+	for i := range rt.NumMethod() {
+		name := rt.Method(i).Name
+		if !strings.HasPrefix(name, "Unmarshal") {
+			continue
+		}
+		unmarshal, ok := nonNullableNullInputs[name]
+		assert.Assert(t, ok, "NonNullable must override %s", name)
+		if unmarshal == nil {
+			continue
+		}
+
+		var u und.Und[int]
+		assert.NilError(t, unmarshal(&u), "Und.%s", name)
+		assert.Assert(t, u.IsNull(), "Und.%s", name)
+
+		n := und.NonNullable[int]{Und: und.Defined(5)}
+		err := unmarshal(&n)
+		assert.ErrorIs(t, err, und.ErrNull, "NonNullable.%s", name)
+		assert.Assert(t, und.Equal(n.Und, und.Defined(5)), "NonNullable.%s", name)
+	}
+}
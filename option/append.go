@@ -0,0 +1,102 @@
+package option
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// AppendJSON appends JSON encoding of o to dst and returns the extended buffer.
+// The appended bytes are same as what MarshalJSON returns.
+//
+// Values of predeclared boolean, numeric and string types are appended directly to dst
+// without intermediate allocations.
+// Other values, and values that need escaping or are not representable in JSON, e.g. NaN,
+// are encoded by json.Marshal then appended.
+// If an error occurs, dst is returned unchanged along with the error.
+func (o Option[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if o.IsNone() {
+		return append(dst, "null"...), nil
+	}
+	if b, ok := appendJSONFast(dst, o.v); ok {
+		return b, nil
+	}
+	bin, err := json.Marshal(o.v)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, bin...), nil
+}
+
+func appendJSONFast(dst []byte, v any) ([]byte, bool) {
+	switch x := v.(type) {
+	case bool:
+		return strconv.AppendBool(dst, x), true
+	case int:
+		return strconv.AppendInt(dst, int64(x), 10), true
+	case int8:
+		return strconv.AppendInt(dst, int64(x), 10), true
+	case int16:
+		return strconv.AppendInt(dst, int64(x), 10), true
+	case int32:
+		return strconv.AppendInt(dst, int64(x), 10), true
+	case int64:
+		return strconv.AppendInt(dst, x, 10), true
+	case uint:
+		return strconv.AppendUint(dst, uint64(x), 10), true
+	case uint8:
+		return strconv.AppendUint(dst, uint64(x), 10), true
+	case uint16:
+		return strconv.AppendUint(dst, uint64(x), 10), true
+	case uint32:
+		return strconv.AppendUint(dst, uint64(x), 10), true
+	case uint64:
+		return strconv.AppendUint(dst, x, 10), true
+	case uintptr:
+		return strconv.AppendUint(dst, uint64(x), 10), true
+	case float32:
+		return appendFloat(dst, float64(x), 32)
+	case float64:
+		return appendFloat(dst, x, 64)
+	case string:
+		return appendString(dst, x)
+	}
+	return dst, false
+}
+
+// appendFloat formats f as encoding/json does.
+func appendFloat(dst []byte, f float64, bits int) ([]byte, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return dst, false
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, true
+}
+
+// appendString appends s quoted if it needs no escaping.
+func appendString(dst []byte, s string) ([]byte, bool) {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c < 0x20, c >= 0x80, c == '"', c == '\\', c == '<', c == '>', c == '&':
+			return dst, false
+		}
+	}
+	dst = append(dst, '"')
+	dst = append(dst, s...)
+	return append(dst, '"'), true
+}
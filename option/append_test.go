@@ -0,0 +1,53 @@
+package option
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func testAppendJSON[T any](t *testing.T, o Option[T]) {
+	t.Helper()
+	expected, expectedErr := o.MarshalJSON()
+	prefix := []byte(`prefix`)
+	bin, err := o.AppendJSON(prefix)
+	if expectedErr != nil {
+		assert.Assert(t, err != nil)
+		assert.Equal(t, string(bin), "prefix")
+		return
+	}
+	assert.NilError(t, err)
+	assert.Equal(t, string(bin), "prefix"+string(expected))
+}
+
+func TestOption_AppendJSON(t *testing.T) {
+	testAppendJSON(t, None[int]())
+	testAppendJSON(t, Some(true))
+	testAppendJSON(t, Some(-12))
+	testAppendJSON(t, Some(int8(math.MinInt8)))
+	testAppendJSON(t, Some(int64(math.MaxInt64)))
+	testAppendJSON(t, Some(uint64(math.MaxUint64)))
+	testAppendJSON(t, Some(uintptr(5)))
+	for _, f := range []float64{0, 1.5, -1e-7, 1e21, 123456789.125, 1e-9, math.MaxFloat64, math.NaN(), math.Inf(1)} {
+		testAppendJSON(t, Some(f))
+		testAppendJSON(t, Some(float32(f)))
+	}
+	for _, s := range []string{"", "foo", "quo\"te", "back\\slash", "<html>&", "\n", "日本語", " ", "\xff"} {
+		testAppendJSON(t, Some(s))
+	}
+	testAppendJSON(t, Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	testAppendJSON(t, Some([]int{1, 2}))
+	testAppendJSON(t, Some(json.RawMessage(`{"foo":"bar"}`)))
+	testAppendJSON(t, Some(func() {}))
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = Some(12345).AppendJSON(buf[:0])
+		buf, _ = Some("foo").AppendJSON(buf)
+		buf, _ = Some(1.25).AppendJSON(buf)
+	})
+	assert.Equal(t, allocs, float64(0))
+}
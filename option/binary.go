@@ -0,0 +1,129 @@
+package option
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/ngicks/und/internal/transcode"
+	"github.com/ngicks/und/internal/undstate"
+)
+
+var (
+	// ErrBinaryFormat is returned from UnmarshalBinary methods when input is not in the expected format.
+	ErrBinaryFormat = errors.New("invalid binary format")
+)
+
+type binaryCodec struct {
+	marshal   func(v any) ([]byte, error)
+	unmarshal func(data []byte) (any, error)
+}
+
+var binaryCodecs sync.Map // reflect.Type -> binaryCodec
+
+// RegisterBinaryCodec registers marshal and unmarshal as the codec for T
+// used by [MarshalBinaryValue] and [UnmarshalBinaryValue],
+// and therefore by MarshalBinary and UnmarshalBinary methods of Option[T] and und types.
+// Registering a codec for T again replaces the previous one.
+//
+// RegisterBinaryCodec is safe for concurrent use,
+// but it is expected to be called in init functions.
+func RegisterBinaryCodec[T any](marshal func(t T) ([]byte, error), unmarshal func(data []byte) (T, error)) {
+	binaryCodecs.Store(reflect.TypeFor[T](), binaryCodec{
+		marshal: func(v any) ([]byte, error) {
+			return marshal(v.(T))
+		},
+		unmarshal: func(data []byte) (any, error) {
+			return unmarshal(data)
+		},
+	})
+}
+
+// MarshalBinaryValue encodes t into bytes.
+//
+// t is encoded by, in the order of precedence,
+// the codec registered by [RegisterBinaryCodec],
+// t's MarshalBinary method if T implements encoding.BinaryMarshaler,
+// or its JSON representation transcoded into CBOR as the fallback,
+// which is same as the CBOR payload codec of github.com/ngicks/und/undcodec.
+func MarshalBinaryValue[T any](t T) ([]byte, error) {
+	if c, ok := binaryCodecs.Load(reflect.TypeFor[T]()); ok {
+		return c.(binaryCodec).marshal(t)
+	}
+	if m, ok := any(t).(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
+	bin, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return transcode.JSONToCBOR(nil, bin)
+}
+
+// UnmarshalBinaryValue decodes data encoded by [MarshalBinaryValue] into T.
+func UnmarshalBinaryValue[T any](data []byte) (T, error) {
+	var t T
+	if c, ok := binaryCodecs.Load(reflect.TypeFor[T]()); ok {
+		v, err := c.(binaryCodec).unmarshal(data)
+		if err != nil {
+			return t, err
+		}
+		return v.(T), nil
+	}
+	if u, ok := any(&t).(encoding.BinaryUnmarshaler); ok {
+		err := u.UnmarshalBinary(data)
+		return t, err
+	}
+	bin, err := transcode.CBORToJSON(nil, data)
+	if err != nil {
+		return t, err
+	}
+	err = json.Unmarshal(bin, &t)
+	return t, err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// o is encoded as a state tag byte, the value of und.State, null (2) for None and defined (4) for Some,
+// followed by the value encoded by [MarshalBinaryValue] if o is some.
+// The format is shared with und.Und[T] and sliceund.Und[T].
+func (o Option[T]) MarshalBinary() ([]byte, error) {
+	if o.IsNone() {
+		return []byte{byte(undstate.StateNull)}, nil
+	}
+	bin, err := MarshalBinaryValue(o.v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(undstate.StateDefined)}, bin...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// data must be in the format [Option.MarshalBinary] produces,
+// otherwise UnmarshalBinary returns an error wrapping [ErrBinaryFormat].
+// Undefined, which und types write, is decoded as None.
+func (o *Option[T]) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty input", ErrBinaryFormat)
+	}
+	switch undstate.State(data[0]) {
+	case undstate.StateUndefined, undstate.StateNull:
+		if len(data) != 1 {
+			return fmt.Errorf("%w: trailing bytes after state tag", ErrBinaryFormat)
+		}
+		*o = None[T]()
+		return nil
+	case undstate.StateDefined:
+		t, err := UnmarshalBinaryValue[T](data[1:])
+		if err != nil {
+			return err
+		}
+		*o = Some(t)
+		return nil
+	}
+	return fmt.Errorf("%w: unknown tag %d", ErrBinaryFormat, data[0])
+}
@@ -0,0 +1,121 @@
+package option
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und/internal/transcode"
+	"gotest.tools/v3/assert"
+)
+
+type binaryCodecTestType int
+
+func init() {
+	RegisterBinaryCodec(
+		func(t binaryCodecTestType) ([]byte, error) {
+			return []byte(strconv.Itoa(int(t))), nil
+		},
+		func(data []byte) (binaryCodecTestType, error) {
+			i, err := strconv.Atoi(string(data))
+			return binaryCodecTestType(i), err
+		},
+	)
+}
+
+func TestOption_Binary(t *testing.T) {
+	t.Run("codec", func(t *testing.T) {
+		bin, err := Some(binaryCodecTestType(123)).MarshalBinary()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, []byte("\x04123"), bin)
+
+		var o Option[binaryCodecTestType]
+		assert.NilError(t, o.UnmarshalBinary(bin))
+		assert.Equal(t, o, Some(binaryCodecTestType(123)))
+	})
+
+	t.Run("BinaryMarshaler", func(t *testing.T) {
+		now := time.Now()
+		tBin, err := now.MarshalBinary()
+		assert.NilError(t, err)
+
+		bin, err := Some(now).MarshalBinary()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, append([]byte{4}, tBin...), bin)
+
+		var o Option[time.Time]
+		assert.NilError(t, o.UnmarshalBinary(bin))
+		assert.Assert(t, o.Value().Equal(now))
+	})
+
+	t.Run("cbor", func(t *testing.T) {
+		type sample struct {
+			Foo string
+			Bar []int
+		}
+		for _, opt := range []Option[sample]{
+			None[sample](),
+			Some(sample{}),
+			Some(sample{Foo: "foo", Bar: []int{1, 2}}),
+		} {
+			bin, err := opt.MarshalBinary()
+			assert.NilError(t, err)
+			if opt.IsSome() {
+				j, err := json.Marshal(opt.Value())
+				assert.NilError(t, err)
+				cbor, err := transcode.JSONToCBOR(nil, j)
+				assert.NilError(t, err)
+				assert.DeepEqual(t, append([]byte{4}, cbor...), bin)
+			}
+			var o Option[sample]
+			assert.NilError(t, o.UnmarshalBinary(bin))
+			assert.Assert(t, EqualDeep(opt, o))
+		}
+	})
+
+	t.Run("number", func(t *testing.T) {
+		i := new(big.Int).Lsh(big.NewInt(1), 70)
+		i.Add(i, big.NewInt(1))
+		for _, n := range []*big.Int{i, new(big.Int).Neg(i)} {
+			bin, err := Some(n).MarshalBinary()
+			assert.NilError(t, err)
+			var o Option[*big.Int]
+			assert.NilError(t, o.UnmarshalBinary(bin))
+			assert.Assert(t, o.Value().Cmp(n) == 0, "%s != %s", o.Value(), n)
+		}
+
+		for _, n := range []json.Number{
+			"123456789012345678901234567890",
+			"-0.1234567890123456789012345678",
+			"1e400",
+			"0.5",
+		} {
+			bin, err := Some(n).MarshalBinary()
+			assert.NilError(t, err)
+			var o Option[json.Number]
+			assert.NilError(t, o.UnmarshalBinary(bin))
+			assert.Equal(t, n, o.Value())
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		bin, err := None[int]().MarshalBinary()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, []byte{2}, bin)
+
+		o := Some(5)
+		assert.NilError(t, o.UnmarshalBinary(bin))
+		assert.Assert(t, o.IsNone())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		for _, data := range [][]byte{nil, {0}, {2, 1}, {3}} {
+			var o Option[int]
+			err := o.UnmarshalBinary(data)
+			assert.Assert(t, errors.Is(err, ErrBinaryFormat), "data = %v, err = %v", data, err)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package option
+
+// Cloner is implemented by types which can clone themselves,
+// e.g. Option[T], Options[T], und types and elastic types.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// cloneValue clones t by its Clone method if T implements Cloner[T],
+// otherwise returns t as is, which is copy by assignment.
+func cloneValue[T any](t T) T {
+	if c, ok := any(t).(Cloner[T]); ok {
+		return c.Clone()
+	}
+	return t
+}
+
+// Clone returns a copy of o.
+// If T implements Cloner[T], the value is cloned by its Clone method,
+// otherwise it is copied by assignment.
+func (o Option[T]) Clone() Option[T] {
+	return o.CloneFunc(cloneValue[T])
+}
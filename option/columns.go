@@ -0,0 +1,38 @@
+package option
+
+import "fmt"
+
+// ToColumn converts opts into a column vector, values along with an Apache Arrow-style validity bitmap
+// in least-significant bit numbering.
+//
+// values has the length of len(opts); elements for None are zero value of V.
+// Bits of valid are set for Some.
+// values and valid can be used as buffers of an Arrow array as they are if V is a fixed-width primitive type.
+func ToColumn[V any](opts []Option[V]) (values []V, valid []byte) {
+	values = make([]V, len(opts))
+	valid = make([]byte, (len(opts)+7)/8)
+	for i, o := range opts {
+		if o.IsSome() {
+			values[i] = o.v
+			valid[i/8] |= 1 << (i % 8)
+		}
+	}
+	return values, valid
+}
+
+// FromColumn converts a column vector, values along with an Arrow-style validity bitmap, back into []Option[V].
+// Rows whose bit is set in valid become Some, and other rows become None.
+//
+// FromColumn panics if valid is shorter than needed for len(values) rows.
+func FromColumn[V any](values []V, valid []byte) []Option[V] {
+	if len(valid) < (len(values)+7)/8 {
+		panic(fmt.Sprintf("option.FromColumn: bitmap too short: %d rows, len(valid) = %d", len(values), len(valid)))
+	}
+	opts := make([]Option[V], len(values))
+	for i, v := range values {
+		if valid[i/8]&(1<<(i%8)) != 0 {
+			opts[i] = Some(v)
+		}
+	}
+	return opts
+}
@@ -0,0 +1,21 @@
+package option
+
+import (
+	"slices"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestColumn(t *testing.T) {
+	opts := []Option[int]{Some(1), None[int](), None[int](), Some(4), Some(0), None[int](), None[int](), None[int](), Some(9)}
+	values, valid := ToColumn(opts)
+	assert.DeepEqual(t, []int{1, 0, 0, 4, 0, 0, 0, 0, 9}, values)
+	assert.DeepEqual(t, []byte{0b0001_1001, 0b1}, valid)
+	assert.Assert(t, slices.EqualFunc(opts, FromColumn(values, valid), Equal[int]))
+
+	defer func() {
+		assert.Assert(t, recover() != nil)
+	}()
+	FromColumn(values, valid[:1])
+}
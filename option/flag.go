@@ -0,0 +1,50 @@
+package option
+
+import (
+	"flag"
+	"fmt"
+)
+
+var _ flag.Getter = (*Flag[any])(nil)
+
+// Flag is a flag.Value which sets a parsed value to the bound Option[T].
+//
+// The bound Option stays None unless the flag is passed,
+// so that programs can tell whether users set the flag or not.
+type Flag[T any] struct {
+	o     *Option[T]
+	parse func(s string) (T, error)
+}
+
+// NewFlag returns a new Flag which sets values parsed by parse to o.
+// parse is usually strconv.Atoi or similar functions.
+//
+// To bind the flag, pass the returned value to flag.Var or (*flag.FlagSet).Var.
+func NewFlag[T any](o *Option[T], parse func(s string) (T, error)) *Flag[T] {
+	return &Flag[T]{o: o, parse: parse}
+}
+
+// String implements flag.Value.
+// It returns an empty string for None, otherwise the value formatted by fmt.Sprint.
+func (f *Flag[T]) String() string {
+	if f == nil || f.o == nil || f.o.IsNone() {
+		return ""
+	}
+	return fmt.Sprint(f.o.Value())
+}
+
+// Set implements flag.Value.
+// It parses s and sets the bound Option to some of the parsed value.
+func (f *Flag[T]) Set(s string) error {
+	t, err := f.parse(s)
+	if err != nil {
+		return err
+	}
+	*f.o = Some(t)
+	return nil
+}
+
+// Get implements flag.Getter. It returns the bound Option[T].
+func (f *Flag[T]) Get() any {
+	return *f.o
+}
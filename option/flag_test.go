@@ -0,0 +1,35 @@
+package option
+
+import (
+	"flag"
+	"io"
+	"strconv"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFlag(t *testing.T) {
+	parse := func(args ...string) (Option[int], error) {
+		var o Option[int]
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(NewFlag(&o, strconv.Atoi), "n", "")
+		err := fs.Parse(args)
+		return o, err
+	}
+
+	o, err := parse()
+	assert.NilError(t, err)
+	assert.Assert(t, o.IsNone())
+
+	o, err = parse("-n", "12")
+	assert.NilError(t, err)
+	assert.Equal(t, o, Some(12))
+
+	_, err = parse("-n", "foo")
+	assert.Assert(t, err != nil)
+
+	assert.Equal(t, NewFlag(&o, strconv.Atoi).String(), "12")
+	assert.Equal(t, NewFlag(new(Option[int]), strconv.Atoi).String(), "")
+}
@@ -0,0 +1,50 @@
+package option
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// MarshalGQL implements the marshaler interface of github.com/99designs/gqlgen.
+//
+// o is written as what MarshalJSON returns, since GraphQL responses are serialized as JSON.
+// If T can not be marshaled into JSON, null is written instead, as the interface has no way to report errors.
+func (o Option[T]) MarshalGQL(w io.Writer) {
+	bin, err := o.MarshalJSON()
+	if err != nil {
+		bin = []byte(`null`)
+	}
+	_, _ = w.Write(bin)
+}
+
+// UnmarshalGQL implements the unmarshaler interface of github.com/99designs/gqlgen.
+//
+// v is an input value decoded by gqlgen, e.g. string, json.Number or map[string]any.
+// nil becomes None. Other v is stored as it is if it is assignable to T, otherwise it is converted through JSON.
+func (o *Option[T]) UnmarshalGQL(v any) error {
+	if v == nil {
+		*o = None[T]()
+		return nil
+	}
+	t, err := fromAny[T](v)
+	if err != nil {
+		return err
+	}
+	*o = Some(t)
+	return nil
+}
+
+// fromAny converts v, a value decoded by a decoder of another format, into T.
+// v is returned as it is if it is T, otherwise it is converted through JSON.
+func fromAny[T any](v any) (T, error) {
+	if t, ok := v.(T); ok {
+		return t, nil
+	}
+	var t T
+	bin, err := json.Marshal(v)
+	if err != nil {
+		return t, err
+	}
+	err = json.Unmarshal(bin, &t)
+	return t, err
+}
@@ -0,0 +1,29 @@
+package option
+
+import (
+	"hash/maphash"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func hashString(h *maphash.Hash, s string) {
+	_, _ = h.WriteString(s)
+}
+
+func TestOption_HashFunc(t *testing.T) {
+	seed := maphash.MakeSeed()
+
+	none := None[string]().HashFunc(seed, hashString)
+	zero := Some("").HashFunc(seed, hashString)
+	foo := Some("foo").HashFunc(seed, hashString)
+
+	assert.Assert(t, none != zero)
+	assert.Assert(t, zero != foo)
+	assert.Equal(t, none, None[string]().HashFunc(seed, hashString))
+	assert.Equal(t, foo, Some("foo").HashFunc(seed, hashString))
+
+	called := false
+	_ = None[string]().HashFunc(seed, func(h *maphash.Hash, t string) { called = true })
+	assert.Assert(t, !called)
+}
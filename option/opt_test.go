@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"slices"
+	"strconv"
 	"testing"
 	"time"
 	_ "time/tzdata"
@@ -79,6 +80,13 @@ func TestOption_Equal(t *testing.T) {
 		assert.Assert(t, s3.EqualFunc(s4, func(i, j time.Time) bool { return i.Equal(j) }))
 	})
 
+	t.Run("EqualDeep", func(t *testing.T) {
+		assert.Assert(t, EqualDeep(None[[]int](), None[[]int]()))
+		assert.Assert(t, !EqualDeep(None[[]int](), Some([]int(nil))))
+		assert.Assert(t, EqualDeep(Some([]int{1, 2}), Some([]int{1, 2})))
+		assert.Assert(t, !EqualDeep(Some([]int{1, 2}), Some([]int{2, 1})))
+	})
+
 	t.Run("EqualFunc", func(t *testing.T) {
 		assert.Assert(
 			t,
@@ -206,6 +214,40 @@ func TestOption_methods(t *testing.T) {
 		assert.Equal(t, Map(n, func(o string) bool { return true }), None[bool]())
 	})
 
+	t.Run("MapErr", func(t *testing.T) {
+		atoi := func(s string) (int, error) { return strconv.Atoi(s) }
+		o, err := MapErr(Some("123"), atoi)
+		assert.NilError(t, err)
+		assert.Equal(t, o, Some(123))
+		o, err = MapErr(s, atoi)
+		assert.ErrorContains(t, err, "invalid syntax")
+		assert.Equal(t, o, None[int]())
+		o, err = MapErr(n, func(s string) (int, error) { panic("must not be called") })
+		assert.NilError(t, err)
+		assert.Equal(t, o, None[int]())
+	})
+
+	t.Run("AndThenErr", func(t *testing.T) {
+		parse := func(s string) (Option[int], error) {
+			if s == "" {
+				return None[int](), nil
+			}
+			i, err := strconv.Atoi(s)
+			return Some(i), err
+		}
+		o, err := AndThenErr(Some("5"), parse)
+		assert.NilError(t, err)
+		assert.Equal(t, o, Some(5))
+		o, err = AndThenErr(Some(""), parse)
+		assert.NilError(t, err)
+		assert.Equal(t, o, None[int]())
+		_, err = AndThenErr(s, parse)
+		assert.ErrorContains(t, err, "invalid syntax")
+		o, err = AndThenErr(n, parse)
+		assert.NilError(t, err)
+		assert.Equal(t, o, None[int]())
+	})
+
 	t.Run("Map", func(t *testing.T) {
 		assert.Equal(t, s.Map(func(v string) string { return v + v }), Some("aaaaaa"))
 		assert.Equal(t, n.Map(func(v string) string { return "ccc" }), None[string]())
@@ -257,4 +299,48 @@ func TestOption_methods(t *testing.T) {
 		assert.Equal(t, s.Xor(n), s)
 		assert.Equal(t, n.Xor(s), s)
 	})
+
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, Compare(n, n), 0)
+		assert.Equal(t, Compare(n, s), -1)
+		assert.Equal(t, Compare(s, n), +1)
+		assert.Equal(t, Compare(s, s), 0)
+		assert.Equal(t, Compare(s, s2), -1)
+		assert.Equal(t, Compare(s2, s), +1)
+	})
+
+	t.Run("FirstSome", func(t *testing.T) {
+		assert.Equal(t, FirstSome[string](), n)
+		assert.Equal(t, FirstSome(n, n), n)
+		assert.Equal(t, FirstSome(n, s, s2), s)
+		assert.Equal(t, FirstSome(s2, n, s), s2)
+	})
+
+	t.Run("FilterMap", func(t *testing.T) {
+		atoi := func(s string) (int, bool) {
+			i, err := strconv.Atoi(s)
+			return i, err == nil
+		}
+		assert.Equal(t, FilterMap(Some("12"), atoi), Some(12))
+		assert.Equal(t, FilterMap(Some("foo"), atoi), None[int]())
+		assert.Equal(t, FilterMap(None[string](), atoi), None[int]())
+	})
+
+	t.Run("Ref", func(t *testing.T) {
+		var none Option[string]
+		assert.Assert(t, none.Ref() == nil)
+
+		o := Some("aaa")
+		r := o.Ref()
+		assert.Equal(t, *r, "aaa")
+		*r = "bbb"
+		assert.Equal(t, o.Value(), "bbb")
+	})
+
+	t.Run("ValueOr", func(t *testing.T) {
+		assert.Equal(t, s.ValueOr("baz"), "aaa")
+		assert.Equal(t, n.ValueOr("baz"), "baz")
+		assert.Equal(t, s.ValueOrElse(func() string { panic("must not be called") }), "aaa")
+		assert.Equal(t, n.ValueOrElse(func() string { return "baz" }), "baz")
+	})
 }
@@ -2,8 +2,10 @@ package option
 
 import (
 	"errors"
-	"fmt"
+	"log/slog"
+	"reflect"
 	"slices"
+	"strconv"
 
 	"github.com/ngicks/und/validate"
 )
@@ -11,11 +13,23 @@ import (
 var (
 	_ validate.UndValidator = Options[any]{}
 	_ validate.UndChecker   = Options[any]{}
+	_ slog.LogValuer        = Options[any]{}
 )
 
+// Options is a slice of Option[T], which represents a JSON array of form [](null | T).
+//
+// Equal, EqualFunc, Clone and CloneFunc are position-aware:
+// None elements are holes at their positions and never skipped or compacted,
+// so [null,1] and [1,null] are different.
+// Other methods may move or drop None elements as documented on each of them;
+// e.g. SortFunc places None elements first, and Dedup keeps only the first None.
 type Options[T any] []Option[T]
 
-// EqualFunc tests equality of l and r using an equality function cmp.
+// EqualFunc tests equality of o and opts using an equality function cmp.
+// o and opts are equal if they have same length and elements at each position are equal,
+// where None only equals to None. cmp is only called for pairs of some elements.
+//
+// For comparable T, [EqualOptions] compares elements by ==.
 func (o Options[T]) EqualFunc(opts Options[T], cmp func(i, j T) bool) bool {
 	return slices.EqualFunc(
 		o, opts,
@@ -25,6 +39,22 @@ func (o Options[T]) EqualFunc(opts Options[T], cmp func(i, j T) bool) bool {
 	)
 }
 
+// Equal tests equality of o and opts in the same manner as [Options.EqualFunc].
+// Values are compared by == if they are comparable, or by [reflect.DeepEqual] otherwise,
+// so Equal never panics, even for uncomparable T or interface T holding uncomparable values.
+func (o Options[T]) Equal(opts Options[T]) bool {
+	return o.EqualFunc(opts, equalValue[T])
+}
+
+func equalValue[T any](i, j T) bool {
+	if reflect.TypeFor[T]().Comparable() {
+		if vi, vj := reflect.ValueOf(&i).Elem(), reflect.ValueOf(&j).Elem(); vi.Comparable() && vj.Comparable() {
+			return any(i) == any(j)
+		}
+	}
+	return reflect.DeepEqual(i, j)
+}
+
 // EqualOptions tests equality of l and r then returns true if they are equal, false otherwise
 func EqualOptions[T comparable, Opts ~[]Option[T]](l, r Opts) bool {
 	return Options[T](l).EqualFunc(Options[T](r), func(i, j T) bool { return i == j })
@@ -35,6 +65,8 @@ func EqualOptionsFunc[T any, Opts ~[]Option[T]](l, r Opts, cmp func(i, j T) bool
 	return Options[T](l).EqualFunc(Options[T](r), cmp)
 }
 
+// CloneFunc returns a copy of o, where some values are cloned by cloneT.
+// Nil o results in nil. None elements stay at same positions.
 func (o Options[T]) CloneFunc(cloneT func(T) T) Options[T] {
 	if o == nil { // in case it matters.
 		return nil
@@ -46,27 +78,95 @@ func (o Options[T]) CloneFunc(cloneT func(T) T) Options[T] {
 	return opts
 }
 
-func CloneOptions[T comparable, Opts ~[]Option[T]](o Opts) Opts {
+// Clone returns a copy of o.
+// Values are cloned by [Option.Clone]; values implementing [Cloner] are cloned by their Clone method,
+// others are copied by assignment.
+// Nil o results in nil. None elements stay at same positions.
+func (o Options[T]) Clone() Options[T] {
+	return o.CloneFunc(cloneValue[T])
+}
+
+// CloneOptions is like [Options.Clone] but accepts any slice types of Option[T].
+func CloneOptions[T any, Opts ~[]Option[T]](o Opts) Opts {
+	return Opts(Options[T](o).Clone())
+}
+
+// Dedup returns a new Options[T] where duplicated elements of o are removed.
+// Only the first occurrence of each element is retained, so relative order of elements is preserved.
+//
+// Elements are tested equality by eq, through [Option.EqualFunc]; all None elements are considered equal.
+// eq is never called with None.
+func (o Options[T]) Dedup(eq func(i, j T) bool) Options[T] {
 	if o == nil {
 		return nil
 	}
-	opts := make(Options[T], len(o), cap(o)) // exact cap copying, in case it matters.
-	copy(opts, o)
-	return Opts(opts)
+	deduped := make(Options[T], 0, len(o))
+	for _, opt := range o {
+		if !slices.ContainsFunc(deduped, func(d Option[T]) bool { return d.EqualFunc(opt, eq) }) {
+			deduped = append(deduped, opt)
+		}
+	}
+	return deduped
+}
+
+// SortFunc returns a new Options[T] whose elements are sorted in ascending order as determined by cmp.
+// None elements are placed before any some elements (see [Option.CompareFunc]).
+//
+// The sort is stable; o itself is not modified.
+func (o Options[T]) SortFunc(cmp func(i, j T) int) Options[T] {
+	if o == nil {
+		return nil
+	}
+	sorted := slices.Clone(o)
+	slices.SortStableFunc(sorted, func(i, j Option[T]) int { return i.CompareFunc(j, cmp) })
+	return sorted
+}
+
+// FilterMapOptions filters and maps elements of opts in one pass.
+// f is called for each some element; the element is replaced with some of the value f returned if f reports true,
+// otherwise it is removed.
+// None elements are kept at their relative positions without calling f.
+//
+// Nil opts results in nil.
+func FilterMapOptions[T, U any, Opts ~[]Option[T]](opts Opts, f func(T) (U, bool)) Options[U] {
+	if opts == nil {
+		return nil
+	}
+	mapped := make(Options[U], 0, len(opts))
+	for _, opt := range opts {
+		if opt.IsNone() {
+			mapped = append(mapped, None[U]())
+			continue
+		}
+		if u, ok := f(opt.Value()); ok {
+			mapped = append(mapped, Some(u))
+		}
+	}
+	return mapped
+}
+
+// LogValue implements slog.LogValuer.
+//
+// o is logged as a slice of values where None elements are nil,
+// in the same way [Option.LogValue] logs each element.
+func (o Options[T]) LogValue() slog.Value {
+	if o == nil {
+		return slog.AnyValue(nil)
+	}
+	values := make([]any, len(o))
+	for i, opt := range o {
+		values[i] = opt.LogValue().Any()
+	}
+	return slog.AnyValue(values)
 }
 
+// UndValidate validates each some element of o in the same way as [Option.UndValidate].
+// A returned error is annotated with the index of the failing element, e.g. "validation failed at [3].Foo: ...",
+// and its path is reported by [validate.ValidationError.Pointer].
 func (o Options[T]) UndValidate() error {
 	for i, oo := range o {
-		err := MapOr(oo, nil, func(t T) error {
-			return validate.UndValidate(t)
-		})
-		if err != nil {
-			if errors.Is(err, validate.ErrNotStruct) {
-				// no point further inspect.
-				// assumes T is not `any`.
-				return nil
-			}
-			return fmt.Errorf("[%d].%w", i, err)
+		if err := oo.UndValidate(); err != nil {
+			return validate.AppendValidationErrorIndex(err, strconv.Itoa(i))
 		}
 	}
 	return nil
@@ -0,0 +1,105 @@
+package option
+
+import (
+	"bytes"
+	"cmp"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestOptions_Dedup(t *testing.T) {
+	eq := func(i, j int) bool { return i == j }
+
+	assert.Assert(t, Options[int](nil).Dedup(eq) == nil)
+
+	input := Options[int]{Some(3), None[int](), Some(1), Some(3), None[int](), Some(2), Some(1)}
+	deduped := input.Dedup(eq)
+	assert.Assert(t, EqualOptions(Options[int]{Some(3), None[int](), Some(1), Some(2)}, deduped))
+	// input is not modified
+	assert.Equal(t, len(input), 7)
+}
+
+func TestOptions_SortFunc(t *testing.T) {
+	assert.Assert(t, Options[int](nil).SortFunc(cmp.Compare[int]) == nil)
+
+	input := Options[int]{Some(3), None[int](), Some(1), Some(2), None[int]()}
+	sorted := input.SortFunc(cmp.Compare[int])
+	assert.Assert(t, EqualOptions(Options[int]{None[int](), None[int](), Some(1), Some(2), Some(3)}, sorted))
+	// input is not modified
+	assert.Assert(t, EqualOptions(Options[int]{Some(3), None[int](), Some(1), Some(2), None[int]()}, input))
+}
+
+func TestOptions_Equal(t *testing.T) {
+	base := Options[int]{None[int](), Some(1), None[int](), Some(2)}
+
+	assert.Assert(t, base.Equal(Options[int]{None[int](), Some(1), None[int](), Some(2)}))
+	assert.Assert(t, Options[int](nil).Equal(Options[int]{}))
+	for _, other := range []Options[int]{
+		{Some(1), None[int](), None[int](), Some(2)},
+		{None[int](), Some(1), Some(0), Some(2)},
+		{None[int](), Some(1), None[int]()},
+		{None[int](), Some(1), None[int](), Some(2), None[int]()},
+	} {
+		assert.Assert(t, !base.Equal(other), "other = %#v", other)
+	}
+
+	// uncomparable T, and interface T holding uncomparable values, do not panic.
+	slices := Options[[]int]{Some([]int{1}), None[[]int]()}
+	assert.Assert(t, slices.Equal(Options[[]int]{Some([]int{1}), None[[]int]()}))
+	assert.Assert(t, !slices.Equal(Options[[]int]{Some([]int{2}), None[[]int]()}))
+	anys := Options[any]{Some[any]([]int{1}), Some[any](1)}
+	assert.Assert(t, anys.Equal(Options[any]{Some[any]([]int{1}), Some[any](1)}))
+	assert.Assert(t, !anys.Equal(Options[any]{Some[any]([]int{1}), Some[any]("1")}))
+
+	called := 0
+	eq := func(i, j int) bool { called++; return i == j }
+	assert.Assert(t, base.EqualFunc(base, eq))
+	// eq is not called for None.
+	assert.Equal(t, called, 2)
+}
+
+func TestOptions_Clone(t *testing.T) {
+	assert.Assert(t, Options[int](nil).Clone() == nil)
+
+	base := Options[int]{None[int](), Some(1), None[int](), Some(2)}
+	cloned := base.Clone()
+	assert.Assert(t, EqualOptions(base, cloned))
+	cloned[0] = Some(5)
+	assert.Assert(t, base[0].IsNone())
+
+	s := Options[[]int]{Some([]int{1}), None[[]int]()}
+	clonedS := s.CloneFunc(func(i []int) []int { return append([]int(nil), i...) })
+	clonedS[0].Value()[0] = 5
+	assert.Equal(t, s[0].Value()[0], 1)
+	assert.Assert(t, clonedS[1].IsNone())
+}
+
+func TestOptions_LogValue(t *testing.T) {
+	assert.Assert(t, Options[int](nil).LogValue().Any() == nil)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("opts", "v", Options[string]{None[string](), Some("foo")})
+	assert.Equal(t, buf.String(), "msg=opts v=\"[<nil> foo]\"\n")
+}
+
+func TestFilterMapOptions(t *testing.T) {
+	atoi := func(s string) (int, bool) {
+		i, err := strconv.Atoi(s)
+		return i, err == nil
+	}
+	assert.Assert(t, FilterMapOptions(Options[string](nil), atoi) == nil)
+
+	mapped := FilterMapOptions([]Option[string]{Some("1"), None[string](), Some("foo"), Some("2")}, atoi)
+	assert.Assert(t, EqualOptions(mapped, Options[int]{Some(1), None[int](), Some(2)}))
+}
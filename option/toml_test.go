@@ -0,0 +1,74 @@
+package option
+
+import (
+	"math"
+	"net/netip"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestOption_MarshalTOML(t *testing.T) {
+	for _, tc := range []struct {
+		v        interface{ MarshalTOML() ([]byte, error) }
+		expected string
+	}{
+		{Some(true), `true`},
+		{Some(-12), `-12`},
+		{Some(uint8(5)), `5`},
+		{Some(1.5), `1.5`},
+		{Some(float32(2)), `2.0`},
+		{Some(1e21), `1e+21`},
+		{Some(math.NaN()), `nan`},
+		{Some(math.Inf(-1)), `-inf`},
+		{Some("foo\n\"bar\""), `"foo\n\"bar\""`},
+		{Some(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)), `2024-01-02T03:04:05.000000006Z`},
+		{Some(netip.MustParseAddr("127.0.0.1")), `"127.0.0.1"`},
+		{Some([]int{1, 2}), `[1, 2]`},
+		{Some([0]int{}), `[]`},
+		{Some(map[string]any{"b": []string{"x"}, "a": 1}), `{ "a" = 1, "b" = ["x"] }`},
+		{Some(map[string]int{}), `{}`},
+		{Some(&[]int{3}), `[3]`},
+	} {
+		bin, err := tc.v.MarshalTOML()
+		assert.NilError(t, err)
+		assert.Equal(t, string(bin), tc.expected)
+	}
+
+	for _, v := range []interface{ MarshalTOML() ([]byte, error) }{
+		None[int](),
+		Some[*int](nil),
+		Some([]any{1, nil}),
+		Some(uint64(math.MaxUint64)),
+		Some(map[int]int{1: 1}),
+		Some(struct{ A int }{}),
+	} {
+		_, err := v.MarshalTOML()
+		assert.Assert(t, err != nil)
+	}
+}
+
+func TestOption_UnmarshalTOML(t *testing.T) {
+	var o Option[int64]
+	assert.NilError(t, o.UnmarshalTOML(int64(5)))
+	assert.Equal(t, o, Some[int64](5))
+
+	var i8 Option[int8]
+	assert.NilError(t, i8.UnmarshalTOML(int64(5)))
+	assert.Equal(t, i8, Some[int8](5))
+	assert.Assert(t, i8.UnmarshalTOML(int64(300)) != nil)
+
+	var m Option[map[string][]int]
+	assert.NilError(t, m.UnmarshalTOML(map[string]any{"foo": []any{int64(1), int64(2)}}))
+	assert.DeepEqual(t, m.Value(), map[string][]int{"foo": {1, 2}})
+
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var tm Option[time.Time]
+	assert.NilError(t, tm.UnmarshalTOML(date))
+	assert.Assert(t, tm.Value().Equal(date))
+
+	var addr Option[netip.Addr]
+	assert.NilError(t, addr.UnmarshalTOML("127.0.0.1"))
+	assert.Equal(t, addr.Value(), netip.MustParseAddr("127.0.0.1"))
+}
@@ -2,6 +2,7 @@ package option
 
 import (
 	"errors"
+	"reflect"
 
 	"github.com/ngicks/und/validate"
 )
@@ -11,8 +12,19 @@ var (
 	_ validate.UndChecker   = Option[any]{}
 )
 
+// UndValidate validates o's value if o is some.
+//
+// If T implements validate.UndValidator, T's UndValidate is called directly
+// so that nested types, e.g. generated ones, validate themselves recursively without reflection.
+// Otherwise the value is validated by validate.UndValidate.
 func (o Option[T]) UndValidate() error {
 	return MapOr(o, nil, func(t T) error {
+		if v, ok := any(t).(validate.UndValidator); ok {
+			if rv := reflect.ValueOf(t); rv.Kind() == reflect.Pointer && rv.IsNil() {
+				return nil
+			}
+			return v.UndValidate()
+		}
 		err := validate.UndValidate(t)
 		if errors.Is(err, validate.ErrNotStruct) {
 			return nil
@@ -0,0 +1,96 @@
+package patch
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// Coalesce receives patches from in, merges them by merge and sends consolidated patches to the returned channel
+// at most once per interval. Nothing is sent for intervals in which no patch is received.
+// If merge is nil, [Merge] is used.
+//
+// When in is closed, the pending patch, if any, is sent and the returned channel is closed.
+// When ctx is cancelled, the pending patch is discarded and the returned channel is closed.
+//
+// Coalesce is useful for sources which emit patches frequently for the same resource, e.g. autosave of UIs,
+// where sending each patch to backends is wasteful.
+func Coalesce[T any](ctx context.Context, in <-chan T, interval time.Duration, merge func(base, patch T) T) <-chan T {
+	if merge == nil {
+		merge = Merge[T]
+	}
+	out := make(chan T)
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var (
+			pending    T
+			hasPending bool
+		)
+		flush := func() bool {
+			if !hasPending {
+				return true
+			}
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- pending:
+			}
+			var zero T
+			pending, hasPending = zero, false
+			return true
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case p, ok := <-in:
+				if !ok {
+					_ = flush()
+					return
+				}
+				if hasPending {
+					pending = merge(pending, p)
+				} else {
+					pending, hasPending = p, true
+				}
+			case <-ticker.C:
+				if !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// CoalesceSeq is like [Coalesce] but for iter.Seq.
+// seq is consumed in a separate goroutine, which is stopped when the caller stops iterating over the returned iterator.
+func CoalesceSeq[T any](seq iter.Seq[T], interval time.Duration, merge func(base, patch T) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		in := make(chan T)
+		go func() {
+			defer close(in)
+			for p := range seq {
+				select {
+				case <-ctx.Done():
+					return
+				case in <- p:
+				}
+			}
+		}()
+
+		for p := range Coalesce(ctx, in, interval, merge) {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
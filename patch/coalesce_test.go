@@ -0,0 +1,80 @@
+package patch_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/patch"
+	"gotest.tools/v3/assert"
+)
+
+type coalesceSample struct {
+	Foo und.Und[string]
+	Bar und.Und[int]
+}
+
+func (s coalesceSample) Equal(o coalesceSample) bool {
+	return und.Equal(s.Foo, o.Foo) && und.Equal(s.Bar, o.Bar)
+}
+
+func TestCoalesce(t *testing.T) {
+	t.Run("closed", func(t *testing.T) {
+		in := make(chan coalesceSample, 3)
+		in <- coalesceSample{Foo: und.Defined("foo")}
+		in <- coalesceSample{Bar: und.Defined(1)}
+		in <- coalesceSample{Foo: und.Null[string]()}
+		close(in)
+
+		var out []coalesceSample
+		for p := range patch.Coalesce(context.Background(), in, time.Hour, nil) {
+			out = append(out, p)
+		}
+		assert.DeepEqual(t, []coalesceSample{{Foo: und.Null[string](), Bar: und.Defined(1)}}, out)
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		in := make(chan coalesceSample)
+		out := patch.Coalesce(context.Background(), in, time.Millisecond, nil)
+
+		in <- coalesceSample{Foo: und.Defined("foo")}
+		assert.DeepEqual(t, coalesceSample{Foo: und.Defined("foo")}, <-out)
+
+		in <- coalesceSample{Bar: und.Defined(1)}
+		close(in)
+		assert.DeepEqual(t, coalesceSample{Bar: und.Defined(1)}, <-out)
+
+		_, ok := <-out
+		assert.Assert(t, !ok)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan coalesceSample)
+		out := patch.Coalesce(ctx, in, time.Hour, nil)
+		in <- coalesceSample{Foo: und.Defined("foo")}
+		cancel()
+		_, ok := <-out
+		assert.Assert(t, !ok)
+	})
+
+	t.Run("seq", func(t *testing.T) {
+		seq := slices.Values([]coalesceSample{
+			{Foo: und.Defined("foo")},
+			{Foo: und.Defined("bar")},
+			{Bar: und.Null[int]()},
+		})
+		var out []coalesceSample
+		for p := range patch.CoalesceSeq(seq, time.Hour, nil) {
+			out = append(out, p)
+		}
+		assert.DeepEqual(t, []coalesceSample{{Foo: und.Defined("bar"), Bar: und.Null[int]()}}, out)
+
+		// stopping iteration does not leave goroutines blocked.
+		for range patch.CoalesceSeq(seq, time.Millisecond, nil) {
+			break
+		}
+	})
+}
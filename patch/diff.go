@@ -0,0 +1,52 @@
+package patch
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+// Diff returns a patch which, when applied onto base by [Merge], results in same values as target does.
+// Fields of target which would not change base are omitted from the returned patch,
+// i.e. left undefined for und types, None for option.Option[T] and zero for other types.
+// T must be a struct type, otherwise Diff panics.
+//
+// Fields are compared by reflect.DeepEqual, and classified in the same way as [Merge] does.
+// Thus Merge(base, Diff(base, target)) equals to Merge(base, target) for any base and target.
+func Diff[T any](base, target T) T {
+	rt := reflect.TypeFor[T]()
+	if rt.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("patch.Diff: T must be a struct type but is %s", rt))
+	}
+	diff := reflect.New(rt).Elem()
+	diffStruct(diff, reflect.ValueOf(base), reflect.ValueOf(target))
+	return diff.Interface().(T)
+}
+
+func diffStruct(dst, base, target reflect.Value) {
+	for i := range dst.NumField() {
+		f := dst.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		d, b, t := dst.Field(i), base.Field(i), target.Field(i)
+		var changes bool
+		switch {
+		case isUndType(f.Type):
+			changes = undStateOf(t) != und.StateUndefined
+		case f.Type.Implements(undreflect.OptionLikeTy):
+			changes = t.Interface().(validate.OptionLike).IsSome()
+		case f.Type.Kind() == reflect.Struct && !f.Type.Implements(isZeroerTy):
+			diffStruct(d, b, t)
+			continue
+		default:
+			changes = !isZero(t)
+		}
+		if changes && !reflect.DeepEqual(b.Interface(), t.Interface()) {
+			d.Set(t)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package patch_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/patch"
+	"github.com/ngicks/und/sliceund"
+	"gotest.tools/v3/assert"
+)
+
+func TestDiff(t *testing.T) {
+	now := time.Now()
+	base := sample{
+		Und:      und.Defined("foo"),
+		SliceUnd: sliceund.Defined("foo"),
+		Elastic:  elastic.FromValues(1, 2),
+		Opt:      option.Some(1),
+		Plain:    "foo",
+		Time:     now,
+		Nested:   nested{A: und.Defined(1), B: "foo"},
+	}
+
+	assert.Assert(t, patch.Diff(base, base).Equal(sample{}))
+
+	target := sample{
+		Und:      und.Defined("foo"),
+		SliceUnd: sliceund.Null[string](),
+		Elastic:  elastic.FromValues(1, 2, 3),
+		Opt:      option.Some(1),
+		Plain:    "bar",
+		Nested:   nested{A: und.Defined(2), B: "foo"},
+		private:  "bar",
+	}
+	diff := patch.Diff(base, target)
+	expected := sample{
+		SliceUnd: sliceund.Null[string](),
+		Elastic:  elastic.FromValues(1, 2, 3),
+		Plain:    "bar",
+		Nested:   nested{A: und.Defined(2)},
+	}
+	assert.Assert(t, diff.Equal(expected), "diff = %#v", diff)
+	assert.Assert(t, patch.Merge(base, diff).Equal(patch.Merge(base, target)))
+}
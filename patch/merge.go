@@ -0,0 +1,90 @@
+// Package patch implements utilities for patch structs,
+// structs whose fields are und types and which describe partial updates to resources,
+// as typically decoded from bodies of HTTP PATCH requests.
+package patch
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+var (
+	isZeroerTy = reflect.TypeFor[interface{ IsZero() bool }]()
+)
+
+// Merge returns a new T where patch is applied onto base.
+// T must be a struct type, otherwise Merge panics.
+//
+// Each exported field is merged as follows:
+//   - Fields of und types, e.g. und.Und[T], sliceund.Und[T], elastic types and other types implementing und.UndStater,
+//     are taken from patch unless patch's is undefined.
+//     Thus null in patch overwrites base's value.
+//   - Fields of option.Option[T] are taken from patch if patch's is some.
+//   - Fields of struct types that do not implement IsZero() bool are merged recursively.
+//   - Other fields are taken from patch if patch's is not zero, as reported by IsZero() bool method if implemented.
+//
+// Unexported fields are always taken from base.
+func Merge[T any](base, patch T) T {
+	rt := reflect.TypeFor[T]()
+	if rt.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("patch.Merge: T must be a struct type but is %s", rt))
+	}
+	merged := reflect.New(rt).Elem()
+	merged.Set(reflect.ValueOf(base))
+	mergeStruct(merged, reflect.ValueOf(patch), TombstoneOverwrite)
+	return merged.Interface().(T)
+}
+
+func mergeStruct(dst, patch reflect.Value, policy TombstonePolicy) {
+	for i := range dst.NumField() {
+		f := dst.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		d, p := dst.Field(i), patch.Field(i)
+		switch {
+		case isUndType(f.Type):
+			if undStateOf(p) == und.StateUndefined {
+				continue
+			}
+			if policy == TombstoneRetain && undStateOf(d) == und.StateNull {
+				continue
+			}
+			d.Set(p)
+		case f.Type.Implements(undreflect.OptionLikeTy):
+			if p.Interface().(validate.OptionLike).IsSome() {
+				d.Set(p)
+			}
+		case f.Type.Kind() == reflect.Struct && !f.Type.Implements(isZeroerTy):
+			mergeStruct(d, p, policy)
+		case !isZero(p):
+			d.Set(p)
+		}
+	}
+}
+
+// isUndType reports whether rt is of und types, i.e. implements und.UndStater or validate.UndLike.
+// Types implementing only validate.OptionLike are merged by their own rule.
+func isUndType(rt reflect.Type) bool {
+	return rt.Implements(undreflect.UndStaterTy) || rt.Implements(undreflect.UndLikeTy)
+}
+
+// undStateOf returns the state of rv, whose type must satisfy isUndType.
+func undStateOf(rv reflect.Value) und.State {
+	s, _ := undreflect.StateOf(rv.Interface())
+	return s
+}
+
+func isZero(rv reflect.Value) bool {
+	if rv.Type().Implements(isZeroerTy) {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return true
+		}
+		return rv.Interface().(interface{ IsZero() bool }).IsZero()
+	}
+	return rv.IsZero()
+}
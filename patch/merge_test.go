@@ -0,0 +1,92 @@
+package patch_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/patch"
+	"github.com/ngicks/und/sliceund"
+	"gotest.tools/v3/assert"
+)
+
+type nested struct {
+	A und.Und[int]
+	B string
+}
+
+type sample struct {
+	Und      und.Und[string]
+	SliceUnd sliceund.Und[string]
+	Elastic  elastic.Elastic[int]
+	Opt      option.Option[int]
+	Plain    string
+	Time     time.Time
+	Nested   nested
+	Ptr      *int
+	private  string
+}
+
+func (s sample) Equal(o sample) bool {
+	return und.Equal(s.Und, o.Und) &&
+		sliceund.Equal(s.SliceUnd, o.SliceUnd) &&
+		elastic.Equal(s.Elastic, o.Elastic) &&
+		option.Equal(s.Opt, o.Opt) &&
+		s.Plain == o.Plain &&
+		s.Time.Equal(o.Time) &&
+		und.Equal(s.Nested.A, o.Nested.A) && s.Nested.B == o.Nested.B &&
+		s.Ptr == o.Ptr &&
+		s.private == o.private
+}
+
+func TestMerge(t *testing.T) {
+	one := 1
+	now := time.Now()
+	base := sample{
+		Und:      und.Defined("foo"),
+		SliceUnd: sliceund.Defined("foo"),
+		Elastic:  elastic.FromValues(1, 2),
+		Opt:      option.Some(1),
+		Plain:    "foo",
+		Time:     now,
+		Nested:   nested{A: und.Defined(1), B: "foo"},
+		Ptr:      &one,
+		private:  "foo",
+	}
+
+	assert.Assert(t, patch.Merge(base, sample{}).Equal(base))
+
+	later := now.Add(time.Hour)
+	merged := patch.Merge(base, sample{
+		Und:      und.Null[string](),
+		SliceUnd: sliceund.Defined("bar"),
+		Elastic:  elastic.Null[int](),
+		Opt:      option.Some(2),
+		Plain:    "bar",
+		Time:     later,
+		Nested:   nested{B: "bar"},
+		private:  "bar",
+	})
+	expected := sample{
+		Und:      und.Null[string](),
+		SliceUnd: sliceund.Defined("bar"),
+		Elastic:  elastic.Null[int](),
+		Opt:      option.Some(2),
+		Plain:    "bar",
+		Time:     later,
+		Nested:   nested{A: und.Defined(1), B: "bar"},
+		Ptr:      &one,
+		private:  "foo",
+	}
+	assert.Assert(t, merged.Equal(expected), "merged = %#v", merged)
+	// base is not modified.
+	assert.Assert(t, base.Und.IsDefined())
+
+	assert.Assert(t, func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		patch.Merge(1, 2)
+		return
+	}())
+}
@@ -0,0 +1,167 @@
+package patch
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// SnapshotVersion is the version of the format written by [Save].
+const SnapshotVersion = 1
+
+var (
+	// ErrInvalidSnapshot is returned by [Load] if input is not a snapshot written by [Save].
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+)
+
+var snapshotMagic = [4]byte{'U', 'N', 'D', 'S'}
+
+var (
+	binaryMarshalerTy   = reflect.TypeFor[encoding.BinaryMarshaler]()
+	binaryUnmarshalerTy = reflect.TypeFor[encoding.BinaryUnmarshaler]()
+	jsonMarshalerTy     = reflect.TypeFor[json.Marshaler]()
+	jsonUnmarshalerTy   = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// Save writes v to w as a snapshot record.
+// T must be a struct type.
+//
+// A record consists of a 4 bytes magic, a version byte, a 4 bytes big endian length of the body and the body.
+// The body is a sequence of exported fields of v, each of which is the field name and the encoded value.
+// Zero fields, including undefined und types, are omitted.
+// Fields are encoded by encoding.BinaryMarshaler if implemented, which und.Und and sliceund.Und do,
+// or json.Marshaler if implemented, which elastic types do,
+// otherwise by encoding/gob.
+//
+// Records are self-delimiting, so multiple records can be written to a same file,
+// e.g. to make a durable queue of pending patches, and read back by calling [Load] repeatedly.
+func Save[T any](w io.Writer, v T) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("patch.Save: T must be a struct type but is %T", v)
+	}
+
+	var body []byte
+	for i := range rv.NumField() {
+		f := rv.Type().Field(i)
+		if !f.IsExported() || isZero(rv.Field(i)) {
+			continue
+		}
+		bin, err := encodeField(rv.Field(i))
+		if err != nil {
+			return fmt.Errorf("patch.Save: field %s: %w", f.Name, err)
+		}
+		body = binary.AppendUvarint(body, uint64(len(f.Name)))
+		body = append(body, f.Name...)
+		body = binary.AppendUvarint(body, uint64(len(bin)))
+		body = append(body, bin...)
+	}
+
+	if uint64(len(body)) > math.MaxUint32 {
+		return fmt.Errorf("patch.Save: body too large: %d bytes", len(body))
+	}
+
+	header := make([]byte, 0, 9)
+	header = append(header, snapshotMagic[:]...)
+	header = append(header, SnapshotVersion)
+	header = binary.BigEndian.AppendUint32(header, uint32(len(body)))
+	_, err := w.Write(append(header, body...))
+	return err
+}
+
+// Load reads a snapshot record written by [Save] from r.
+// It returns io.EOF if r has no more record.
+//
+// Load is forward-compatible with changes to T:
+// fields present in the record but not in T are ignored,
+// and fields of T not present in the record are left zero, which is undefined for und types.
+// Load returns an error wrapping [ErrInvalidSnapshot] if the record is malformed or of a newer version.
+func Load[T any](r io.Reader) (T, error) {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	if rv.Kind() != reflect.Struct {
+		return v, fmt.Errorf("patch.Load: T must be a struct type but is %T", v)
+	}
+
+	var header [9]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return v, fmt.Errorf("%w: truncated header", ErrInvalidSnapshot)
+		}
+		return v, err
+	}
+	if [4]byte(header[:4]) != snapshotMagic {
+		return v, fmt.Errorf("%w: wrong magic", ErrInvalidSnapshot)
+	}
+	if version := header[4]; version == 0 || version > SnapshotVersion {
+		return v, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, version)
+	}
+	// The length comes from input; reading through io.LimitReader rather than allocating it upfront
+	// keeps a corrupted or malicious header from causing a huge allocation.
+	size := binary.BigEndian.Uint32(header[5:])
+	body, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil {
+		return v, err
+	}
+	if len(body) < int(size) {
+		return v, fmt.Errorf("%w: truncated body: %w", ErrInvalidSnapshot, io.ErrUnexpectedEOF)
+	}
+
+	for len(body) > 0 {
+		name, rest, err := readChunk(body)
+		if err != nil {
+			return v, err
+		}
+		bin, rest, err := readChunk(rest)
+		if err != nil {
+			return v, err
+		}
+		body = rest
+
+		f, ok := rv.Type().FieldByName(string(name))
+		if !ok || !f.IsExported() || len(f.Index) != 1 {
+			continue
+		}
+		if err := decodeField(rv.Field(f.Index[0]), bin); err != nil {
+			return v, fmt.Errorf("patch.Load: field %s: %w", f.Name, err)
+		}
+	}
+	return v, nil
+}
+
+func readChunk(b []byte) (chunk, rest []byte, err error) {
+	l, n := binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) < l {
+		return nil, nil, fmt.Errorf("%w: malformed body", ErrInvalidSnapshot)
+	}
+	return b[n : n+int(l)], b[n+int(l):], nil
+}
+
+func encodeField(fv reflect.Value) ([]byte, error) {
+	switch {
+	case fv.Type().Implements(binaryMarshalerTy) && reflect.PointerTo(fv.Type()).Implements(binaryUnmarshalerTy):
+		return fv.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+	case fv.Type().Implements(jsonMarshalerTy) && reflect.PointerTo(fv.Type()).Implements(jsonUnmarshalerTy):
+		return fv.Interface().(json.Marshaler).MarshalJSON()
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).EncodeValue(fv)
+	return buf.Bytes(), err
+}
+
+func decodeField(fv reflect.Value, bin []byte) error {
+	switch {
+	case fv.Type().Implements(binaryMarshalerTy) && reflect.PointerTo(fv.Type()).Implements(binaryUnmarshalerTy):
+		return fv.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(bin)
+	case fv.Type().Implements(jsonMarshalerTy) && reflect.PointerTo(fv.Type()).Implements(jsonUnmarshalerTy):
+		return fv.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(bin)
+	}
+	return gob.NewDecoder(bytes.NewReader(bin)).DecodeValue(fv.Addr())
+}
@@ -0,0 +1,88 @@
+package patch_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/patch"
+	"github.com/ngicks/und/sliceund"
+	"gotest.tools/v3/assert"
+)
+
+type snapshotV1 struct {
+	Foo     und.Und[string]
+	Bar     sliceund.Und[int]
+	Baz     elastic.Elastic[string]
+	Qux     option.Option[time.Time]
+	Removed string
+}
+
+type snapshotV2 struct {
+	Foo   und.Und[string]
+	Bar   sliceund.Und[int]
+	Baz   elastic.Elastic[string]
+	Qux   option.Option[time.Time]
+	Added und.Und[int]
+}
+
+func TestSnapshot(t *testing.T) {
+	now := time.Now()
+	records := []snapshotV1{
+		{Foo: und.Defined("foo"), Bar: sliceund.Null[int](), Baz: elastic.FromOptions(option.None[string](), option.Some("baz")), Qux: option.Some(now), Removed: "removed"},
+		{Foo: und.Null[string](), Baz: elastic.Null[string]()},
+		{},
+	}
+
+	var buf bytes.Buffer
+	for _, r := range records {
+		assert.NilError(t, patch.Save(&buf, r))
+	}
+
+	var loaded []snapshotV2
+	for {
+		v, err := patch.Load[snapshotV2](&buf)
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		loaded = append(loaded, v)
+	}
+
+	assert.Equal(t, len(loaded), len(records))
+	for i, l := range loaded {
+		r := records[i]
+		assert.Assert(t, und.Equal(l.Foo, r.Foo), "%d", i)
+		assert.Assert(t, sliceund.Equal(l.Bar, r.Bar), "%d", i)
+		assert.Assert(t, elastic.Equal(l.Baz, r.Baz), "%d", i)
+		assert.Assert(t, l.Qux.EqualFunc(r.Qux, time.Time.Equal), "%d", i)
+		assert.Assert(t, l.Added.IsUndefined(), "%d", i)
+	}
+}
+
+func TestSnapshot_error(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, patch.Save(&buf, snapshotV1{Foo: und.Defined("foo")}))
+	valid := buf.Bytes()
+
+	for _, input := range [][]byte{
+		[]byte("UND"),
+		append([]byte("UNDX"), valid[4:]...),
+		append([]byte("UNDS\x02"), valid[5:]...),
+		valid[:len(valid)-1],
+		// a body length of 4 GiB - 1 must not be allocated before reading.
+		append([]byte("UNDS\x01\xff\xff\xff\xff"), valid[9:]...),
+	} {
+		_, err := patch.Load[snapshotV1](bytes.NewReader(input))
+		assert.Assert(t, errors.Is(err, patch.ErrInvalidSnapshot), "input = %q, err = %v", input, err)
+	}
+
+	assert.Assert(t, patch.Save(&buf, 1) != nil)
+	_, err := patch.Load[int](bytes.NewReader(valid))
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,92 @@
+package patch
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ngicks/und"
+)
+
+// TombstonePolicy controls how [MergeN] treats null values of und-typed fields,
+// which are deletion markers, a.k.a. tombstones, in patches.
+type TombstonePolicy int
+
+const (
+	// TombstoneOverwrite lets later defined values overwrite earlier null values, as [Merge] does.
+	TombstoneOverwrite TombstonePolicy = iota
+	// TombstoneRetain keeps null values once they are set; later defined values for the fields are ignored.
+	TombstoneRetain
+	// TombstoneDrop is like TombstoneOverwrite but removes null values remaining in the squashed patch,
+	// leaving those fields zero, i.e. undefined.
+	// This assumes the zero value of the field type is undefined, which holds for und types of this module.
+	// Fields of other und.UndStater or validate.UndLike types whose zero value is not undefined are left null.
+	TombstoneDrop
+)
+
+// MergeN squashes patches into a single patch by applying them in order, as folding them by [Merge],
+// except that null values are treated as specified by policy.
+// T must be a struct type, otherwise MergeN panics.
+//
+// MergeN returns zero T if no patch is given.
+func MergeN[T any](policy TombstonePolicy, patches ...T) T {
+	rt := reflect.TypeFor[T]()
+	if rt.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("patch.MergeN: T must be a struct type but is %s", rt))
+	}
+	merged := reflect.New(rt).Elem()
+	for _, p := range patches {
+		mergeStruct(merged, reflect.ValueOf(p), policy)
+	}
+	if policy == TombstoneDrop {
+		dropTombstones(merged)
+	}
+	return merged.Interface().(T)
+}
+
+func dropTombstones(rv reflect.Value) {
+	for i := range rv.NumField() {
+		f := rv.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		switch {
+		case isUndType(f.Type):
+			if undStateOf(fv) == und.StateNull && undStateOf(reflect.Zero(f.Type)) == und.StateUndefined {
+				fv.SetZero()
+			}
+		case f.Type.Kind() == reflect.Struct && !f.Type.Implements(isZeroerTy):
+			dropTombstones(fv)
+		}
+	}
+}
+
+// Tombstones lists paths to und-typed fields of p which are null.
+// Paths are names of struct fields, joined by "." for fields of nested structs, e.g. "Foo.Bar".
+// T must be a struct type, otherwise Tombstones panics.
+func Tombstones[T any](p T) []string {
+	rt := reflect.TypeFor[T]()
+	if rt.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("patch.Tombstones: T must be a struct type but is %s", rt))
+	}
+	return appendTombstones(nil, "", reflect.ValueOf(p))
+}
+
+func appendTombstones(paths []string, prefix string, rv reflect.Value) []string {
+	for i := range rv.NumField() {
+		f := rv.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		switch {
+		case isUndType(f.Type):
+			if undStateOf(fv) == und.StateNull {
+				paths = append(paths, prefix+f.Name)
+			}
+		case f.Type.Kind() == reflect.Struct && !f.Type.Implements(isZeroerTy):
+			paths = appendTombstones(paths, prefix+f.Name+".", fv)
+		}
+	}
+	return paths
+}
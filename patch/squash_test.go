@@ -0,0 +1,96 @@
+package patch_test
+
+import (
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/patch"
+	"gotest.tools/v3/assert"
+)
+
+type squashSample struct {
+	Foo    und.Und[string]
+	Bar    und.Und[int]
+	Nested nested
+}
+
+func (s squashSample) Equal(o squashSample) bool {
+	return und.Equal(s.Foo, o.Foo) && und.Equal(s.Bar, o.Bar) &&
+		und.Equal(s.Nested.A, o.Nested.A) && s.Nested.B == o.Nested.B
+}
+
+func TestMergeN(t *testing.T) {
+	patches := []squashSample{
+		{Foo: und.Defined("foo"), Bar: und.Defined(1)},
+		{Foo: und.Null[string](), Nested: nested{A: und.Null[int]()}},
+		{Foo: und.Defined("bar")},
+		{Bar: und.Null[int]()},
+	}
+
+	assert.Assert(t, patch.MergeN[squashSample](patch.TombstoneOverwrite).Equal(squashSample{}))
+
+	for _, tc := range []struct {
+		policy   patch.TombstonePolicy
+		expected squashSample
+	}{
+		{
+			patch.TombstoneOverwrite,
+			squashSample{Foo: und.Defined("bar"), Bar: und.Null[int](), Nested: nested{A: und.Null[int]()}},
+		},
+		{
+			patch.TombstoneRetain,
+			squashSample{Foo: und.Null[string](), Bar: und.Null[int](), Nested: nested{A: und.Null[int]()}},
+		},
+		{
+			patch.TombstoneDrop,
+			squashSample{Foo: und.Defined("bar")},
+		},
+	} {
+		merged := patch.MergeN(tc.policy, patches...)
+		assert.Assert(t, merged.Equal(tc.expected), "policy = %d, merged = %#v", tc.policy, merged)
+	}
+
+	folded := squashSample{}
+	for _, p := range patches {
+		folded = patch.Merge(folded, p)
+	}
+	assert.Assert(t, patch.MergeN(patch.TombstoneOverwrite, patches...).Equal(folded))
+}
+
+// definedByDefault is a third party und.UndStater whose zero value is defined rather than undefined.
+type definedByDefault struct {
+	null, undefined bool
+}
+
+func (d definedByDefault) State() und.State {
+	switch {
+	case d.undefined:
+		return und.StateUndefined
+	case d.null:
+		return und.StateNull
+	default:
+		return und.StateDefined
+	}
+}
+
+func (d definedByDefault) AnyValue() any { return nil }
+
+func TestMergeN_zero_value_not_undefined(t *testing.T) {
+	type sample struct {
+		D   definedByDefault
+		Und und.Und[int]
+	}
+	merged := patch.MergeN(patch.TombstoneDrop, sample{D: definedByDefault{null: true}, Und: und.Null[int]()})
+	// zero definedByDefault is not undefined, so the tombstone can not be dropped.
+	assert.Equal(t, und.StateNull, merged.D.State())
+	assert.Assert(t, merged.Und.IsUndefined())
+}
+
+func TestTombstones(t *testing.T) {
+	assert.Assert(t, patch.Tombstones(squashSample{Foo: und.Defined("foo")}) == nil)
+	assert.DeepEqual(
+		t,
+		[]string{"Foo", "Nested.A"},
+		patch.Tombstones(squashSample{Foo: und.Null[string](), Bar: und.Defined(1), Nested: nested{A: und.Null[int]()}}),
+	)
+}
@@ -0,0 +1,101 @@
+package patch
+
+import (
+	"iter"
+	"maps"
+	"sync"
+)
+
+// Store keeps pending patches per key, e.g. IDs of resources.
+// Patches put for a same key are merged into a single pending patch.
+//
+// Store is safe for concurrent use.
+// The zero Store is ready to use and merges patches by [Merge].
+type Store[K comparable, T any] struct {
+	mu      sync.RWMutex
+	merge   func(base, patch T) T
+	patches map[K]T
+}
+
+// NewStore returns a new Store which merges patches by merge.
+// If merge is nil, [Merge] is used.
+func NewStore[K comparable, T any](merge func(base, patch T) T) *Store[K, T] {
+	return &Store[K, T]{merge: merge}
+}
+
+func (s *Store[K, T]) mergeFn() func(base, patch T) T {
+	if s.merge == nil {
+		return Merge[T]
+	}
+	return s.merge
+}
+
+// Put merges p onto the pending patch for key, or stores p as is if there is none.
+func (s *Store[K, T]) Put(key K, p T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.patches == nil {
+		s.patches = make(map[K]T)
+	}
+	if pending, ok := s.patches[key]; ok {
+		p = s.mergeFn()(pending, p)
+	}
+	s.patches[key] = p
+}
+
+// Get returns the pending patch for key.
+// ok is false if there is no pending patch for key.
+func (s *Store[K, T]) Get(key K) (p T, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok = s.patches[key]
+	return p, ok
+}
+
+// Diff returns the pending patch for key reduced against current, the current state of the resource,
+// so that fields which would not change current are omitted (see [Diff]).
+// ok is false if there is no pending patch for key.
+func (s *Store[K, T]) Diff(key K, current T) (p T, ok bool) {
+	p, ok = s.Get(key)
+	if !ok {
+		return p, false
+	}
+	return Diff(current, s.mergeFn()(current, p)), true
+}
+
+// Take removes the pending patch for key and returns it.
+// ok is false if there is no pending patch for key.
+func (s *Store[K, T]) Take(key K) (p T, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok = s.patches[key]
+	delete(s.patches, key)
+	return p, ok
+}
+
+// Delete removes the pending patch for key.
+func (s *Store[K, T]) Delete(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.patches, key)
+}
+
+// Len returns the number of keys which have pending patches.
+func (s *Store[K, T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.patches)
+}
+
+// Snapshot returns a copy of all pending patches.
+// Changes made to s after Snapshot returns are not reflected to the returned map, and vice versa.
+func (s *Store[K, T]) Snapshot() map[K]T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.patches)
+}
+
+// All returns an iterator over pending patches taken by [Store.Snapshot].
+func (s *Store[K, T]) All() iter.Seq2[K, T] {
+	return maps.All(s.Snapshot())
+}
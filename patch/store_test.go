@@ -0,0 +1,61 @@
+package patch_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/patch"
+	"gotest.tools/v3/assert"
+)
+
+func TestStore(t *testing.T) {
+	var s patch.Store[string, coalesceSample]
+
+	_, ok := s.Get("a")
+	assert.Assert(t, !ok)
+	assert.Equal(t, s.Len(), 0)
+
+	s.Put("a", coalesceSample{Foo: und.Defined("foo")})
+	s.Put("a", coalesceSample{Bar: und.Defined(1)})
+	s.Put("b", coalesceSample{Foo: und.Null[string]()})
+
+	p, ok := s.Get("a")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, coalesceSample{Foo: und.Defined("foo"), Bar: und.Defined(1)}, p)
+	assert.Equal(t, s.Len(), 2)
+
+	p, ok = s.Diff("a", coalesceSample{Foo: und.Defined("foo"), Bar: und.Defined(2)})
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, coalesceSample{Bar: und.Defined(1)}, p)
+
+	snapshot := s.Snapshot()
+	assert.DeepEqual(
+		t,
+		map[string]coalesceSample{
+			"a": {Foo: und.Defined("foo"), Bar: und.Defined(1)},
+			"b": {Foo: und.Null[string]()},
+		},
+		snapshot,
+	)
+	assert.DeepEqual(t, snapshot, maps.Collect(s.All()))
+
+	p, ok = s.Take("b")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, coalesceSample{Foo: und.Null[string]()}, p)
+	_, ok = s.Get("b")
+	assert.Assert(t, !ok)
+	// snapshot is not affected.
+	assert.Equal(t, len(snapshot), 2)
+
+	s.Delete("a")
+	assert.Equal(t, s.Len(), 0)
+}
+
+func TestStore_merge(t *testing.T) {
+	s := patch.NewStore[int](func(base, p coalesceSample) coalesceSample { return base })
+	s.Put(1, coalesceSample{Foo: und.Defined("foo")})
+	s.Put(1, coalesceSample{Foo: und.Defined("bar")})
+	p, _ := s.Get(1)
+	assert.DeepEqual(t, coalesceSample{Foo: und.Defined("foo")}, p)
+}
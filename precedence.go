@@ -0,0 +1,73 @@
+package und
+
+// Precedence decides which Und value wins when combining multiple values by [Or].
+type Precedence int
+
+const (
+	// PreferPresent makes the first value that is not undefined win.
+	// A null value is considered present; it shadows any following defined values.
+	// This is the precedence of overlaying patches, where null means deletion.
+	PreferPresent Precedence = iota
+	// PreferDefined makes the first defined value win.
+	// If no value is defined, null wins over undefined.
+	PreferDefined
+	// PreferNull makes null win over anything.
+	// If no value is null, the first defined value wins.
+	PreferNull
+)
+
+// Pick returns the index of the value that wins among n values according to p.
+// state must report the state of i-th value.
+//
+// Pick returns -1 if no value wins, i.e. n is 0 or all values are undefined.
+func (p Precedence) Pick(n int, state func(i int) State) int {
+	picked := -1
+	for i := 0; i < n; i++ {
+		switch s := state(i); p {
+		case PreferDefined:
+			if s == StateDefined {
+				return i
+			}
+			if s == StateNull && picked < 0 {
+				picked = i
+			}
+		case PreferNull:
+			if s == StateNull {
+				return i
+			}
+			if s == StateDefined && picked < 0 {
+				picked = i
+			}
+		default:
+			if s != StateUndefined {
+				return i
+			}
+		}
+	}
+	return picked
+}
+
+// Or returns a value chosen from us according to p.
+// If us is empty or all values in us are undefined, it returns an undefined Und[T].
+//
+// For example, given us = [undefined, null, Defined(1)],
+// Or returns null for PreferPresent and PreferNull, Defined(1) for PreferDefined.
+// Given us = [undefined, Defined(1), null], it returns Defined(1) for PreferPresent and PreferDefined, null for PreferNull.
+func Or[T any](p Precedence, us ...Und[T]) Und[T] {
+	i := p.Pick(len(us), func(i int) State { return us[i].State() })
+	if i < 0 {
+		return Undefined[T]()
+	}
+	return us[i]
+}
+
+// OrDefined is an alias for Or(PreferDefined, us...).
+// Unlike [FirstDefined], which returns undefined, it returns null if us has null values but no defined value.
+func OrDefined[T any](us ...Und[T]) Und[T] {
+	return Or(PreferDefined, us...)
+}
+
+// OrNull is an alias for Or(PreferNull, us...).
+func OrNull[T any](us ...Und[T]) Und[T] {
+	return Or(PreferNull, us...)
+}
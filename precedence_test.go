@@ -0,0 +1,38 @@
+package und_test
+
+import (
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestOr(t *testing.T) {
+	undefined := und.Undefined[int]()
+	null := und.Null[int]()
+	one := und.Defined(1)
+	two := und.Defined(2)
+
+	type testCase struct {
+		input                   []und.Und[int]
+		present, defined, nulls und.Und[int]
+	}
+	for _, tc := range []testCase{
+		{nil, undefined, undefined, undefined},
+		{[]und.Und[int]{undefined, undefined}, undefined, undefined, undefined},
+		{[]und.Und[int]{undefined, null, one}, null, one, null},
+		{[]und.Und[int]{undefined, one, null}, one, one, null},
+		{[]und.Und[int]{two, undefined, one}, two, two, two},
+		{[]und.Und[int]{null, undefined}, null, null, null},
+	} {
+		assert.Equal(t, und.Or(und.PreferPresent, tc.input...), tc.present, "input = %v", tc.input)
+		assert.Equal(t, und.Or(und.PreferDefined, tc.input...), tc.defined, "input = %v", tc.input)
+		assert.Equal(t, und.OrDefined(tc.input...), tc.defined, "input = %v", tc.input)
+		assert.Equal(t, und.Or(und.PreferNull, tc.input...), tc.nulls, "input = %v", tc.input)
+		assert.Equal(t, und.OrNull(tc.input...), tc.nulls, "input = %v", tc.input)
+	}
+
+	// OrDefined and FirstDefined differ only when nothing is defined but null is.
+	assert.Equal(t, und.OrDefined(undefined, null), null)
+	assert.Equal(t, und.FirstDefined(undefined, null), undefined)
+}
@@ -0,0 +1,37 @@
+package und_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestRawUnd(t *testing.T) {
+	type sample struct {
+		A und.RawUnd `json:"a"`
+		B und.RawUnd `json:"b"`
+		C und.RawUnd `json:"c"`
+	}
+	var s sample
+	assert.NilError(t, json.Unmarshal([]byte(`{"a":{"foo":[1,2]},"b":null}`), &s))
+	assert.Equal(t, string(s.A.Value()), `{"foo":[1,2]}`)
+
+	a, err := und.DecodeAs[map[string][]int](s.A)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string][]int{"foo": {1, 2}}, a.Value())
+	b, err := und.DecodeAs[int](s.B)
+	assert.NilError(t, err)
+	assert.Assert(t, b.IsNull())
+	c, err := und.DecodeAs[int](s.C)
+	assert.NilError(t, err)
+	assert.Assert(t, c.IsUndefined())
+
+	_, err = und.DecodeAs[int](s.A)
+	assert.Assert(t, err != nil)
+
+	bin, err := json.Marshal(s)
+	assert.NilError(t, err)
+	assert.Equal(t, string(bin), `{"a":{"foo":[1,2]},"b":null,"c":null}`)
+}
@@ -0,0 +1,39 @@
+package sliceund
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und/option"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnd_Binary(t *testing.T) {
+	now := time.Now()
+	tBin, err := now.MarshalBinary()
+	assert.NilError(t, err)
+
+	for _, tc := range []struct {
+		u        Und[time.Time]
+		expected []byte
+	}{
+		{Undefined[time.Time](), []byte{1}},
+		{Null[time.Time](), []byte{2}},
+		{Defined(now), append([]byte{4}, tBin...)},
+	} {
+		bin, err := tc.u.MarshalBinary()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, tc.expected, bin)
+
+		var u Und[time.Time]
+		assert.NilError(t, u.UnmarshalBinary(bin))
+		assert.Assert(t, u.EqualFunc(tc.u, time.Time.Equal))
+	}
+
+	for _, data := range [][]byte{nil, {0}, {1, 0}, {2, 0}, {3}} {
+		var u Und[int]
+		err := u.UnmarshalBinary(data)
+		assert.Assert(t, errors.Is(err, option.ErrBinaryFormat), "data = %v, err = %v", data, err)
+	}
+}
@@ -0,0 +1,47 @@
+package sliceund
+
+import "github.com/ngicks/und"
+
+// FromColumn converts a column vector, values along with Arrow-style validity bitmaps, back into []Und[V].
+//
+// FromColumn is the sliceund counterpart of [und.FromColumn]; see it for details.
+func FromColumn[V any](values []V, valid, null []byte) []Und[V] {
+	return fromUnds(und.FromColumn(values, valid, null))
+}
+
+// ToColumn converts us into a column vector, values along with Arrow-style validity bitmaps.
+//
+// ToColumn is the sliceund counterpart of [und.ToColumn]; see it for details.
+func ToColumn[V any](us []Und[V]) (values []V, valid, null []byte) {
+	values = make([]V, len(us))
+	valid = make([]byte, (len(us)+7)/8)
+	null = make([]byte, (len(us)+7)/8)
+	for i, u := range us {
+		switch {
+		case u.IsDefined():
+			values[i] = u.Value()
+			valid[i/8] |= 1 << (i % 8)
+		case u.IsNull():
+			null[i/8] |= 1 << (i % 8)
+		}
+	}
+	return values, valid, null
+}
+
+// Column converts c into []Und[V].
+// It returns an error wrapping [und.ErrNotColumn] if c's values are not []V.
+func Column[V any](c und.ColumnData) ([]Und[V], error) {
+	us, err := und.Column[V](c)
+	if err != nil {
+		return nil, err
+	}
+	return fromUnds(us), nil
+}
+
+func fromUnds[V any](us []und.Und[V]) []Und[V] {
+	out := make([]Und[V], len(us))
+	for i, u := range us {
+		out[i] = FromUnd(u)
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package sliceund
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestFromColumn(t *testing.T) {
+	values := []int{1, 0, 0, 4}
+	valid := []byte{0b1001}
+
+	assert.Assert(t, slices.EqualFunc(
+		FromColumn(values, valid, []byte{0b0010}),
+		[]Und[int]{Defined(1), Null[int](), Undefined[int](), Defined(4)},
+		Equal[int],
+	))
+
+	rows := []struct{ Foo Und[string] }{
+		{Foo: Defined("foo")},
+		{Foo: Null[string]()},
+		{},
+	}
+	columns, err := und.Columns(rows, "Foo")
+	assert.NilError(t, err)
+	foo, err := Column[string](columns["Foo"])
+	assert.NilError(t, err)
+	assert.Assert(t, slices.EqualFunc(foo, []Und[string]{rows[0].Foo, rows[1].Foo, rows[2].Foo}, Equal[string]))
+
+	_, err = Column[int](columns["Foo"])
+	assert.ErrorIs(t, err, und.ErrNotColumn)
+}
+
+func TestToColumn(t *testing.T) {
+	us := []Und[int]{Defined(1), Null[int](), Undefined[int](), Defined(4)}
+	values, valid, null := ToColumn(us)
+	assert.DeepEqual(t, []int{1, 0, 0, 4}, values)
+	assert.DeepEqual(t, []byte{0b1001}, valid)
+	assert.DeepEqual(t, []byte{0b0010}, null)
+	assert.Assert(t, slices.EqualFunc(us, FromColumn(values, valid, null), Equal[int]))
+}
@@ -0,0 +1,41 @@
+package elastic
+
+import (
+	"encoding/json"
+
+	"github.com/ngicks/und/internal/transcode"
+)
+
+// portable methods that can be copied from github.com/ngicks/und/elastic into github.com/ngicks/und/sliceund/elastic
+
+// MarshalCBOR implements the marshaler interface of github.com/fxamacker/cbor/v2.
+//
+// Undefined is encoded as the CBOR simple value undefined (0xf7), null as null,
+// and a defined Elastic[T] as an array of its values, the CBOR counterpart of what MarshalJSON returns.
+func (e Elastic[T]) MarshalCBOR() ([]byte, error) {
+	if e.IsUndefined() {
+		return []byte{transcode.CBORUndefined}, nil
+	}
+	bin, err := e.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return transcode.JSONToCBOR(nil, bin)
+}
+
+// UnmarshalCBOR implements the unmarshaler interface of github.com/fxamacker/cbor/v2.
+//
+// The CBOR simple value undefined becomes undefined, and null becomes null.
+// Other data is transcoded into JSON, then decoded as UnmarshalJSON does;
+// like UnmarshalJSON, it accepts either an array of (null | T) or a single T.
+func (e *Elastic[T]) UnmarshalCBOR(data []byte) error {
+	if transcode.IsCBORUndefined(data) {
+		*e = Undefined[T]()
+		return nil
+	}
+	bin, err := transcode.CBORToJSON(nil, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bin, e)
+}
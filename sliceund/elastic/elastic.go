@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"log/slog"
 
+	"github.com/ngicks/und"
 	_ "github.com/ngicks/und/elastic"
 	"github.com/ngicks/und/option"
 	"github.com/ngicks/und/sliceund"
@@ -23,6 +24,7 @@ var (
 	_ validate.UndValidator = Elastic[any]{}
 	_ validate.UndChecker   = Elastic[any]{}
 	_ validate.ElasticLike  = Elastic[any]{}
+	_ und.UndStater         = Elastic[any]{}
 )
 
 // Elastic[T] is a slice-based variant of [elastic.Elastic].
@@ -69,6 +71,37 @@ func FromUnd[T any, Opts ~[]option.Option[T]](u sliceund.Und[Opts]) Elastic[T] {
 	}
 }
 
+// FromUndSlice converts sliceund.Und[[]T] into Elastic[T].
+// Undefined and null are kept as they are, and elements of a defined slice become some values.
+//
+// To convert sliceund.Und[option.Options[T]] losslessly, use [FromUnd] instead.
+func FromUndSlice[T any, S ~[]T](u sliceund.Und[S]) Elastic[T] {
+	switch {
+	case u.IsUndefined():
+		return Undefined[T]()
+	case u.IsNull():
+		return Null[T]()
+	default:
+		return FromValues(u.Value()...)
+	}
+}
+
+// ToUndSlice converts e into sliceund.Und[[]T].
+// Undefined and null are kept as they are, and values of a defined e are converted as [Elastic.Values] does:
+// None elements become zero value of T.
+//
+// To convert e losslessly into sliceund.Und[option.Options[T]], use [Elastic.Unwrap] instead.
+func ToUndSlice[T any](e Elastic[T]) sliceund.Und[[]T] {
+	switch {
+	case e.IsUndefined():
+		return sliceund.Undefined[[]T]()
+	case e.IsNull():
+		return sliceund.Null[[]T]()
+	default:
+		return sliceund.Defined(e.Values())
+	}
+}
+
 func (e Elastic[T]) inner() sliceund.Und[option.Options[T]] {
 	return sliceund.Und[option.Options[T]](e)
 }
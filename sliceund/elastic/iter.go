@@ -14,6 +14,20 @@ func (e Elastic[T]) Iter() iter.Seq[option.Option[option.Options[T]]] {
 	return e.Unwrap().Iter()
 }
 
+// FromSeq returns a defined Elastic[T] whose values are those yielded by seq, as some values.
+// The returned value is defined even if seq yields nothing.
+func FromSeq[T any](seq iter.Seq[T]) Elastic[T] {
+	return FromOptionSeq(func(yield func(option.Option[T]) bool) {
+		for t := range seq {
+			if !yield(option.Some(t)) {
+				return
+			}
+		}
+	})
+}
+
+// FromOptionSeq returns a defined Elastic[T] whose values are options yielded by seq.
+// The returned value is defined even if seq yields nothing.
 func FromOptionSeq[T any](seq iter.Seq[option.Option[T]]) Elastic[T] {
 	options := option.Options[T](slices.Collect(seq))
 	if options == nil {
@@ -22,4 +36,44 @@ func FromOptionSeq[T any](seq iter.Seq[option.Option[T]]) Elastic[T] {
 	return Elastic[T](sliceund.Defined(options))
 }
 
-// TODO: add more useful into-iterator kind methods here?
+// All returns an iterator over index-option pairs of e's values, in order.
+// If e is not defined, the iterator yields nothing.
+//
+// Unlike [Elastic.Unwrap], the internal slice is not exposed to the caller.
+func (e Elastic[T]) All() iter.Seq2[int, option.Option[T]] {
+	return func(yield func(int, option.Option[T]) bool) {
+		for i, opt := range e.inner().Value() {
+			if !yield(i, opt) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over e's values as plain T, in order.
+// It yields the same values as [Elastic.Values] without allocating a slice:
+// None values are yielded as zero value of T.
+// If e is not defined, the iterator yields nothing.
+func (e Elastic[T]) ValuesSeq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, opt := range e.inner().Value() {
+			if !yield(opt.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// PointersSeq returns an iterator over e's values as *T, in order.
+// It yields the same values as [Elastic.Pointers] without allocating a slice:
+// None values are yielded as nil.
+// If e is not defined, the iterator yields nothing.
+func (e Elastic[T]) PointersSeq() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		for _, opt := range e.inner().Value() {
+			if !yield(opt.Pointer()) {
+				return
+			}
+		}
+	}
+}
@@ -20,3 +20,56 @@ func TestIter(t *testing.T) {
 	assert.Assert(t, option.EqualOptionsFunc([]option.Option[option.Options[int]]{option.None[option.Options[int]]()}, slices.Collect(n.Iter()), cmp))
 	assert.Assert(t, option.EqualOptionsFunc([]option.Option[option.Options[int]](nil), slices.Collect(u.Iter()), cmp))
 }
+
+func TestElastic_seq(t *testing.T) {
+	e := FromOptions(option.Some(1), option.None[int](), option.Some(3))
+
+	var idx []int
+	var opts []option.Option[int]
+	for i, opt := range e.All() {
+		idx = append(idx, i)
+		opts = append(opts, opt)
+	}
+	assert.DeepEqual(t, []int{0, 1, 2}, idx)
+	assert.Assert(t, option.EqualOptions(opts, e.Unwrap().Value()))
+
+	assert.DeepEqual(t, e.Values(), slices.Collect(e.ValuesSeq()))
+	ptrs := slices.Collect(e.PointersSeq())
+	assert.Equal(t, 3, len(ptrs))
+	assert.Equal(t, 1, *ptrs[0])
+	assert.Assert(t, ptrs[1] == nil)
+	assert.Equal(t, 3, *ptrs[2])
+
+	// breaking early
+	for i := range e.All() {
+		if i == 1 {
+			break
+		}
+	}
+	for range e.ValuesSeq() {
+		break
+	}
+	for range e.PointersSeq() {
+		break
+	}
+
+	for _, e := range []Elastic[int]{Undefined[int](), Null[int]()} {
+		for range e.All() {
+			t.Fatal("must not yield")
+		}
+		assert.Equal(t, 0, len(slices.Collect(e.ValuesSeq())))
+		assert.Equal(t, 0, len(slices.Collect(e.PointersSeq())))
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	assert.Assert(t, Equal(FromSeq(slices.Values([]int{1, 2, 3})), FromValues(1, 2, 3)))
+	empty := FromSeq(slices.Values([]int(nil)))
+	assert.Assert(t, empty.IsDefined())
+	assert.Equal(t, 0, empty.Len())
+
+	assert.Assert(t, Equal(
+		FromOptionSeq(slices.Values([]option.Option[int]{option.Some(1), option.None[int]()})),
+		FromOptions(option.Some(1), option.None[int]()),
+	))
+}
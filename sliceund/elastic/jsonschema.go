@@ -0,0 +1,16 @@
+package elastic
+
+// portable methods that can be copied from github.com/ngicks/und/elastic into github.com/ngicks/und/sliceund/elastic
+
+// JSONSchemaAlias implements the alias interface of github.com/invopop/jsonschema.
+//
+// Reflectors use the type of the returned value, a *[]T, in place of Elastic[T],
+// so generated schemas describe an array of T, the form Elastic[T] is marshaled into.
+// Elastic[T] also accepts a single T and null elements on unmarshaling, which the schema does not describe.
+// Only the type is replaced; the reflector decides nullability and requiredness per field from struct tags,
+// which a type can not override without returning a *jsonschema.Schema, and this module does not depend on that package.
+// Mark fields with `jsonschema:"nullable"` to allow null,
+// and with `json:",omitempty"` so that they are not required; the reflector ignores omitzero.
+func (e Elastic[T]) JSONSchemaAlias() any {
+	return new([]T)
+}
@@ -0,0 +1,159 @@
+package elastic
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ngicks/und"
+)
+
+var (
+	// ErrMultipleValues is returned when Single[T] is unmarshaled from more than one value.
+	ErrMultipleValues = errors.New("multiple values")
+)
+
+var (
+	_ json.Marshaler   = Single[any]{}
+	_ json.Unmarshaler = (*Single[any])(nil)
+	_ xml.Marshaler    = Single[any]{}
+	_ xml.Unmarshaler  = (*Single[any])(nil)
+)
+
+// Single is Elastic[T] which rejects more than one value on unmarshaling.
+//
+// It is for fields mapped as single-valued, where a JSON array with multiple elements
+// indicates a broken document rather than something to be silently truncated to its first element.
+// Unmarshaling such an array fails with an error wrapping [ErrMultipleValues].
+// A single value, an array with at most one element, null and absence are accepted as Elastic[T] accepts them.
+// The rule applies to every unmarshaler Elastic[T] implements, e.g. UnmarshalXML, UnmarshalCBOR and UnmarshalGQL.
+//
+// Unlike elastic.Single[T], which embeds elastic.Elastic[T], Single[T] shares the underlying slice type of Elastic[T],
+// so that undefined fields are omitted by `json:",omitempty"` as Elastic[T] fields are.
+// Convert values with Single[T](e) and [Single.Elastic]; methods other than below are available through the latter.
+type Single[T any] Elastic[T]
+
+// Elastic returns s as Elastic[T].
+func (s Single[T]) Elastic() Elastic[T] {
+	return Elastic[T](s)
+}
+
+// IsDefined returns true if s is defined.
+func (s Single[T]) IsDefined() bool { return s.Elastic().IsDefined() }
+
+// IsNull returns true if s is null.
+func (s Single[T]) IsNull() bool { return s.Elastic().IsNull() }
+
+// IsUndefined returns true if s is undefined.
+func (s Single[T]) IsUndefined() bool { return s.Elastic().IsUndefined() }
+
+// IsZero is an alias for IsUndefined.
+func (s Single[T]) IsZero() bool { return s.Elastic().IsZero() }
+
+// Len returns the number of values s holds.
+func (s Single[T]) Len() int { return s.Elastic().Len() }
+
+// HasNull reports whether s is defined and has a null value.
+func (s Single[T]) HasNull() bool { return s.Elastic().HasNull() }
+
+// Value returns the first value of s, or the zero value of T if s has no value or the first value is null.
+func (s Single[T]) Value() T { return s.Elastic().Value() }
+
+// Pointer returns a pointer to the first value of s, or nil if s has no value or the first value is null.
+func (s Single[T]) Pointer() *T { return s.Elastic().Pointer() }
+
+// State returns the state of s.
+func (s Single[T]) State() und.State { return s.Elastic().State() }
+
+// AnyValue implements und.UndStater in the same way as [Elastic.AnyValue].
+func (s Single[T]) AnyValue() any { return s.Elastic().AnyValue() }
+
+// MarshalJSON implements json.Marshaler in the same way as [Elastic.MarshalJSON].
+func (s Single[T]) MarshalJSON() ([]byte, error) { return s.Elastic().MarshalJSON() }
+
+// MarshalYAML implements the yaml marshaler interface in the same way as [Elastic.MarshalYAML].
+func (s Single[T]) MarshalYAML() (any, error) { return s.Elastic().MarshalYAML() }
+
+// MarshalXML implements xml.Marshaler in the same way as [Elastic.MarshalXML].
+func (s Single[T]) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return s.Elastic().MarshalXML(enc, start)
+}
+
+// MarshalCBOR implements the marshaler interface of github.com/fxamacker/cbor/v2 in the same way as [Elastic.MarshalCBOR].
+func (s Single[T]) MarshalCBOR() ([]byte, error) { return s.Elastic().MarshalCBOR() }
+
+// MarshalMsgpack implements the marshaler interface of github.com/vmihailenco/msgpack/v5 in the same way as [Elastic.MarshalMsgpack].
+func (s Single[T]) MarshalMsgpack() ([]byte, error) { return s.Elastic().MarshalMsgpack() }
+
+// MarshalGQL implements the marshaler interface of github.com/99designs/gqlgen in the same way as [Elastic.MarshalGQL].
+func (s Single[T]) MarshalGQL(w io.Writer) { s.Elastic().MarshalGQL(w) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It returns an error wrapping [ErrMultipleValues] if data is an array with more than one element.
+func (s *Single[T]) UnmarshalJSON(data []byte) error {
+	var e Elastic[T]
+	if err := e.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalYAML implements the yaml unmarshaler interface in the same way as [Elastic.UnmarshalYAML].
+// It returns an error wrapping [ErrMultipleValues] if the node is a sequence with more than one element.
+func (s *Single[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var e Elastic[T]
+	if err := e.UnmarshalYAML(unmarshal); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalXML implements xml.Unmarshaler in the same way as [Elastic.UnmarshalXML].
+// It returns an error wrapping [ErrMultipleValues] if the element repeats.
+func (s *Single[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	e := s.Elastic()
+	if err := e.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalCBOR implements the unmarshaler interface of github.com/fxamacker/cbor/v2 in the same way as [Elastic.UnmarshalCBOR].
+// It returns an error wrapping [ErrMultipleValues] if data is an array with more than one element.
+func (s *Single[T]) UnmarshalCBOR(data []byte) error {
+	var e Elastic[T]
+	if err := e.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalMsgpack implements the unmarshaler interface of github.com/vmihailenco/msgpack/v5 in the same way as [Elastic.UnmarshalMsgpack].
+// It returns an error wrapping [ErrMultipleValues] if data is an array with more than one element.
+func (s *Single[T]) UnmarshalMsgpack(data []byte) error {
+	var e Elastic[T]
+	if err := e.UnmarshalMsgpack(data); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+// UnmarshalGQL implements the unmarshaler interface of github.com/99designs/gqlgen in the same way as [Elastic.UnmarshalGQL].
+// It returns an error wrapping [ErrMultipleValues] if v is a slice with more than one element.
+func (s *Single[T]) UnmarshalGQL(v any) error {
+	var e Elastic[T]
+	if err := e.UnmarshalGQL(v); err != nil {
+		return err
+	}
+	return s.set(e)
+}
+
+func (s *Single[T]) set(e Elastic[T]) error {
+	if e.Len() > 1 {
+		return fmt.Errorf("%w: %T has %d values", ErrMultipleValues, *s, e.Len())
+	}
+	*s = Single[T](e)
+	return nil
+}
@@ -0,0 +1,52 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und/option"
+	"gotest.tools/v3/assert"
+)
+
+func TestSingle(t *testing.T) {
+	// omitempty, not omitzero, so that the test covers Go 1.23 as well.
+	type sample struct {
+		V Single[int] `json:"v,omitempty"`
+	}
+
+	for _, tc := range []struct {
+		input    string
+		expected Elastic[int]
+	}{
+		{`{}`, Undefined[int]()},
+		{`{"v":null}`, Null[int]()},
+		{`{"v":5}`, FromValue(5)},
+		{`{"v":[5]}`, FromValue(5)},
+		{`{"v":[null]}`, FromOptions(option.None[int]())},
+		{`{"v":[]}`, FromOptions[int]()},
+	} {
+		var s sample
+		assert.NilError(t, json.Unmarshal([]byte(tc.input), &s), "input = %s", tc.input)
+		assert.Assert(t, Equal(s.V.Elastic(), tc.expected), "input = %s", tc.input)
+	}
+
+	s := sample{V: Single[int](FromValue(1))}
+	err := json.Unmarshal([]byte(`{"v":[5,null]}`), &s)
+	assert.ErrorIs(t, err, ErrMultipleValues)
+	assert.ErrorContains(t, err, "elastic.Single[int] has 2 values")
+	assert.Assert(t, Equal(s.V.Elastic(), FromValue(1)))
+
+	for _, tc := range []struct {
+		v        sample
+		expected string
+	}{
+		{s, `{"v":[1]}`},
+		{sample{}, `{}`},
+		{sample{V: Single[int](Undefined[int]())}, `{}`},
+		{sample{V: Single[int](Null[int]())}, `{"v":null}`},
+	} {
+		bin, err := json.Marshal(tc.v)
+		assert.NilError(t, err)
+		assert.Equal(t, tc.expected, string(bin))
+	}
+}
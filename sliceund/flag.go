@@ -0,0 +1,64 @@
+package sliceund
+
+import (
+	"flag"
+	"fmt"
+)
+
+var _ flag.Getter = (*Flag[any])(nil)
+
+// Flag is a flag.Value which sets a parsed value or null to the bound Und[T].
+//
+// The bound Und stays undefined unless the flag is passed.
+// If the flag is passed with the value equal to Null, empty string by default, the Und becomes null.
+// Otherwise it becomes defined with the parsed value.
+// This makes it possible to build PATCH-style CLIs which only send what users set.
+type Flag[T any] struct {
+	u     *Und[T]
+	parse func(s string) (T, error)
+	// Null is the flag value which sets null to the bound Und.
+	Null string
+}
+
+// NewFlag returns a new Flag which sets values parsed by parse to u.
+// parse is usually strconv.Atoi or similar functions.
+//
+// To bind the flag, pass the returned value to flag.Var or (*flag.FlagSet).Var.
+func NewFlag[T any](u *Und[T], parse func(s string) (T, error)) *Flag[T] {
+	return &Flag[T]{u: u, parse: parse}
+}
+
+// String implements flag.Value.
+// It returns an empty string for undefined, [Flag.Null] for null,
+// otherwise the value formatted by fmt.Sprint.
+func (f *Flag[T]) String() string {
+	switch {
+	case f == nil || f.u == nil || f.u.IsUndefined():
+		return ""
+	case f.u.IsNull():
+		return f.Null
+	default:
+		return fmt.Sprint(f.u.Value())
+	}
+}
+
+// Set implements flag.Value.
+// It sets the bound Und to null if s equals to [Flag.Null],
+// otherwise parses s and sets the bound Und to defined.
+func (f *Flag[T]) Set(s string) error {
+	if s == f.Null {
+		*f.u = Null[T]()
+		return nil
+	}
+	t, err := f.parse(s)
+	if err != nil {
+		return err
+	}
+	*f.u = Defined(t)
+	return nil
+}
+
+// Get implements flag.Getter. It returns the bound Und[T].
+func (f *Flag[T]) Get() any {
+	return *f.u
+}
@@ -0,0 +1,50 @@
+package sliceund
+
+import (
+	"flag"
+	"io"
+	"strconv"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFlag(t *testing.T) {
+	parse := func(null string, args ...string) (Und[int], error) {
+		var u Und[int]
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		f := NewFlag(&u, strconv.Atoi)
+		f.Null = null
+		fs.Var(f, "n", "")
+		err := fs.Parse(args)
+		return u, err
+	}
+
+	for _, tc := range []struct {
+		null     string
+		args     []string
+		expected Und[int]
+	}{
+		{"", nil, Undefined[int]()},
+		{"", []string{"-n", ""}, Null[int]()},
+		{"", []string{"-n=12"}, Defined(12)},
+		{"null", []string{"-n", "null"}, Null[int]()},
+		{"null", []string{"-n", "-1"}, Defined(-1)},
+	} {
+		u, err := parse(tc.null, tc.args...)
+		assert.NilError(t, err)
+		assert.Assert(t, Equal(u, tc.expected), "args = %#v", tc.args)
+	}
+
+	_, err := parse("null", "-n", "")
+	assert.Assert(t, err != nil)
+
+	u := Null[int]()
+	f := NewFlag(&u, strconv.Atoi)
+	f.Null = "null"
+	assert.Equal(t, f.String(), "null")
+	u = Defined(5)
+	assert.Equal(t, f.String(), "5")
+	assert.Assert(t, Equal(f.Get().(Und[int]), u))
+}
@@ -0,0 +1,20 @@
+package sliceund
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGetMap(t *testing.T) {
+	m := map[string]int{"foo": 0, "bar": 2}
+	assert.Assert(t, Equal(GetMap(m, "foo"), Defined(0)))
+	assert.Assert(t, Equal(GetMap(m, "bar"), Defined(2)))
+	assert.Assert(t, GetMap(m, "baz").IsUndefined())
+
+	two := 2
+	mp := map[string]*int{"foo": nil, "bar": &two}
+	assert.Assert(t, GetMapPointer(mp, "foo").IsNull())
+	assert.Assert(t, Equal(GetMapPointer(mp, "bar"), Defined(2)))
+	assert.Assert(t, GetMapPointer(mp, "baz").IsUndefined())
+}
@@ -0,0 +1,31 @@
+package sliceund
+
+import (
+	"io"
+
+	"github.com/ngicks/und/option"
+)
+
+// MarshalGQL implements the marshaler interface of github.com/99designs/gqlgen.
+//
+// Both null and undefined are written as null.
+// See [option.Option.MarshalGQL] for details.
+func (u Und[T]) MarshalGQL(w io.Writer) {
+	u.Unwrap().Value().MarshalGQL(w)
+}
+
+// UnmarshalGQL implements the unmarshaler interface of github.com/99designs/gqlgen.
+//
+// nil makes u null, and other v makes u defined as [option.Option.UnmarshalGQL] decodes it.
+// UnmarshalGQL never makes u undefined; u stays undefined only if UnmarshalGQL is not called.
+// Whether gqlgen skips the call for a field missing from an input object depends on the code it generates,
+// which this module does not test.
+func (u *Und[T]) UnmarshalGQL(v any) error {
+	var opt option.Option[T]
+	err := opt.UnmarshalGQL(v)
+	if err != nil {
+		return err
+	}
+	*u = FromOption(option.Some(opt))
+	return nil
+}
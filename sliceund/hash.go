@@ -0,0 +1,24 @@
+package sliceund
+
+import "hash/maphash"
+
+// HashFunc returns a hash value of u, seeded by seed.
+// The hash value takes u's state (defined, null or undefined) into account.
+//
+// hashT must write t into h. It is only called if u is defined.
+func (u Und[T]) HashFunc(seed maphash.Seed, hashT func(h *maphash.Hash, t T)) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	u.WriteHash(&h, hashT)
+	return h.Sum64()
+}
+
+// WriteHash writes u's state and, if u is defined, its value by calling hashT, into h.
+//
+// WriteHash is useful for composing a hash value of a type that contains Und[T].
+func (u Und[T]) WriteHash(h *maphash.Hash, hashT func(h *maphash.Hash, t T)) {
+	_ = h.WriteByte(byte(u.State()))
+	if u.IsDefined() {
+		hashT(h, u.Value())
+	}
+}
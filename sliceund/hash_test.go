@@ -0,0 +1,27 @@
+package sliceund
+
+import (
+	"hash/maphash"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func hashString(h *maphash.Hash, s string) {
+	_, _ = h.WriteString(s)
+}
+
+func TestUnd_HashFunc(t *testing.T) {
+	seed := maphash.MakeSeed()
+
+	undefined := Undefined[string]().HashFunc(seed, hashString)
+	null := Null[string]().HashFunc(seed, hashString)
+	zero := Defined("").HashFunc(seed, hashString)
+	foo := Defined("foo").HashFunc(seed, hashString)
+
+	assert.Assert(t, undefined != null)
+	assert.Assert(t, null != zero)
+	assert.Assert(t, undefined != zero)
+	assert.Assert(t, zero != foo)
+	assert.Equal(t, foo, Defined("foo").HashFunc(seed, hashString))
+}
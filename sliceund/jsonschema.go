@@ -0,0 +1,13 @@
+package sliceund
+
+// JSONSchemaAlias implements the alias interface of github.com/invopop/jsonschema.
+//
+// Reflectors use the type of the returned value, a *T, in place of Und[T],
+// so generated schemas describe T rather than an opaque object.
+// Only the type is replaced; the reflector decides nullability and requiredness per field from struct tags,
+// which a type can not override without returning a *jsonschema.Schema, and this module does not depend on that package.
+// Mark fields with `jsonschema:"nullable"` to allow null,
+// and with `json:",omitempty"` so that they are not required; the reflector ignores omitzero.
+func (u Und[T]) JSONSchemaAlias() any {
+	return new(T)
+}
@@ -0,0 +1,34 @@
+package sliceund
+
+import (
+	"encoding/json"
+
+	"github.com/ngicks/und/internal/transcode"
+)
+
+// MarshalMsgpack implements the marshaler interface of github.com/vmihailenco/msgpack/v5.
+//
+// MessagePack has no undefined; both null and undefined are encoded as nil.
+// Undefined fields are omitted if `msgpack:",omitempty"` option is attached to those fields,
+// since Und[T] implements IsZero.
+// A defined value is encoded as its JSON representation transcoded into MessagePack.
+// See [option.Option.MarshalMsgpack] for details.
+func (u Und[T]) MarshalMsgpack() ([]byte, error) {
+	if !u.IsDefined() {
+		return []byte{transcode.MsgpackNil}, nil
+	}
+	return u[0].MarshalMsgpack()
+}
+
+// UnmarshalMsgpack implements the unmarshaler interface of github.com/vmihailenco/msgpack/v5.
+//
+// github.com/vmihailenco/msgpack/v5 does not call UnmarshalMsgpack for nil but zeroes u instead,
+// so nil is decoded as undefined just like a missing key; null does not survive a round trip.
+// Other data is transcoded into JSON, then decoded as UnmarshalJSON does.
+func (u *Und[T]) UnmarshalMsgpack(data []byte) error {
+	bin, err := transcode.MsgpackToJSON(nil, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bin, u)
+}
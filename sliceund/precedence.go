@@ -0,0 +1,25 @@
+package sliceund
+
+import "github.com/ngicks/und"
+
+// Or returns a value chosen from us according to p.
+// If us is empty or all values in us are undefined, it returns an undefined Und[T].
+//
+// See [und.Or] for detailed behavior.
+func Or[T any](p und.Precedence, us ...Und[T]) Und[T] {
+	i := p.Pick(len(us), func(i int) und.State { return us[i].State() })
+	if i < 0 {
+		return Undefined[T]()
+	}
+	return us[i]
+}
+
+// OrDefined is an alias for Or(und.PreferDefined, us...).
+func OrDefined[T any](us ...Und[T]) Und[T] {
+	return Or(und.PreferDefined, us...)
+}
+
+// OrNull is an alias for Or(und.PreferNull, us...).
+func OrNull[T any](us ...Und[T]) Und[T] {
+	return Or(und.PreferNull, us...)
+}
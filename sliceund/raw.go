@@ -0,0 +1,26 @@
+package sliceund
+
+import "encoding/json"
+
+// RawUnd is an Und which holds JSON value as it is.
+//
+// Unmarshaling into RawUnd only validates and copies input,
+// deferring actual decoding until [DecodeAs] is called.
+// It is useful for large documents where only a few fields are ever inspected.
+type RawUnd = Und[json.RawMessage]
+
+// DecodeAs decodes u's raw JSON value into T.
+// Undefined and null states are kept as they are.
+func DecodeAs[T any](u RawUnd) (Und[T], error) {
+	switch {
+	case u.IsUndefined():
+		return Undefined[T](), nil
+	case u.IsNull():
+		return Null[T](), nil
+	}
+	var t T
+	if err := json.Unmarshal(u.Value(), &t); err != nil {
+		return Undefined[T](), err
+	}
+	return Defined(t), nil
+}
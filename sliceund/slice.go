@@ -1,10 +1,13 @@
 package sliceund
 
 import (
+	"cmp"
 	"database/sql"
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"log/slog"
+	"reflect"
 
 	"github.com/ngicks/und"
 	"github.com/ngicks/und/option"
@@ -19,6 +22,7 @@ var (
 	_ xml.Marshaler         = Und[any]{}
 	_ xml.Unmarshaler       = (*Und[any])(nil)
 	_ slog.LogValuer        = Und[any]{}
+	_ und.UndStater         = Und[any]{}
 )
 
 // Und[T] is a slice-based variant of [und.Und].
@@ -58,6 +62,21 @@ func FromPointer[T any](v *T) Und[T] {
 	return Defined(*v)
 }
 
+// FromDoublePointer converts **T into Und[T].
+// It is the inverse of [Und.DoublePointer]:
+// the und value is undefined if v is nil, null if *v is nil, defined otherwise.
+// The pointed value is copied by assignment.
+func FromDoublePointer[T any](v **T) Und[T] {
+	switch {
+	case v == nil:
+		return Undefined[T]()
+	case *v == nil:
+		return Null[T]()
+	default:
+		return Defined(**v)
+	}
+}
+
 // WrapPointer converts *T into Und[*T].
 // The und value is defined if t is non nil, undefined otherwise.
 //
@@ -83,6 +102,24 @@ func FromUnd[T any](u und.Und[T]) Und[T] {
 	return FromOption(u.Unwrap())
 }
 
+// FromOptionValue converts opt into an Und[T].
+// A some opt becomes a defined Und[T]. A none opt becomes null if noneAs is [und.StateNull],
+// undefined if noneAs is [und.StateUndefined].
+//
+// FromOptionValue panics if noneAs is neither of those.
+func FromOptionValue[T any](opt option.Option[T], noneAs und.State) Und[T] {
+	if opt.IsSome() {
+		return Defined(opt.Value())
+	}
+	switch noneAs {
+	case und.StateNull:
+		return Null[T]()
+	case und.StateUndefined:
+		return Undefined[T]()
+	}
+	panic(fmt.Sprintf("sliceund.FromOptionValue: noneAs must be either of StateNull or StateUndefined but is %d", noneAs))
+}
+
 // FromSqlNull converts a valid sql.Null[T] to a defined Und[T]
 // and invalid one into a null Und[].
 func FromSqlNull[T any](v sql.Null[T]) Und[T] {
@@ -93,7 +130,7 @@ func FromSqlNull[T any](v sql.Null[T]) Und[T] {
 }
 
 // IsZero is an alias for IsUndefined.
-// Using `json:",omitzero"` option with "github.com/go-json-experiment/json"
+// Using `json:",omitzero"` option with encoding/json of Go 1.24 or later version, or "github.com/go-json-experiment/json",
 // omits this field while encoding if IsZero returns true.
 func (u Und[T]) IsZero() bool {
 	return u.IsUndefined()
@@ -124,6 +161,59 @@ func (u Und[T]) Value() T {
 	return zero
 }
 
+// ToOption converts u into option.Option[T].
+// A defined u becomes some and an undefined u becomes none.
+// A null u becomes some of zero value of T if nullAsZero is true, none otherwise.
+func (u Und[T]) ToOption(nullAsZero bool) option.Option[T] {
+	switch {
+	case u.IsDefined():
+		return option.Some(u.Value())
+	case u.IsNull() && nullAsZero:
+		var zero T
+		return option.Some(zero)
+	}
+	return option.None[T]()
+}
+
+// CheckedGet returns u's value if u is defined.
+// Otherwise it returns zero value of T and [und.ErrNull] or [und.ErrUndefined] corresponding to u's state.
+func (u Und[T]) CheckedGet() (T, error) {
+	switch {
+	case u.IsDefined():
+		return u.Value(), nil
+	case u.IsNull():
+		var zero T
+		return zero, und.ErrNull
+	default:
+		var zero T
+		return zero, und.ErrUndefined
+	}
+}
+
+// OrDefault returns u if u is defined, otherwise a defined Und[T] wrapping def.
+func (u Und[T]) OrDefault(def T) Und[T] {
+	if u.IsDefined() {
+		return u
+	}
+	return Defined(def)
+}
+
+// ValueOr returns u's value if u is defined, otherwise def.
+func (u Und[T]) ValueOr(def T) T {
+	if u.IsDefined() {
+		return u[0].Value()
+	}
+	return def
+}
+
+// ValueOrElse returns u's value if u is defined, otherwise calls f and returns the result.
+func (u Und[T]) ValueOrElse(f func() T) T {
+	if u.IsDefined() {
+		return u[0].Value()
+	}
+	return f()
+}
+
 // MarshalJSON implements json.Marshaler.
 func (u Und[T]) MarshalJSON() ([]byte, error) {
 	if !u.IsDefined() {
@@ -132,6 +222,16 @@ func (u Und[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(u[0].Value())
 }
 
+// AppendJSON appends JSON encoding of u to dst and returns the extended buffer.
+// The appended bytes are same as what MarshalJSON returns.
+// See [option.Option.AppendJSON] for details.
+func (u Und[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if !u.IsDefined() {
+		return append(dst, "null"...), nil
+	}
+	return u[0].AppendJSON(dst)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (u *Und[T]) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
@@ -174,6 +274,37 @@ func Equal[T comparable](l, r Und[T]) bool {
 	return l.EqualFunc(r, func(i, j T) bool { return i == j })
 }
 
+// EqualDeep is like [Equal] but tests equality of values by [reflect.DeepEqual].
+// Unlike [Equal], EqualDeep accepts any T, including uncomparable ones, and never panics.
+func EqualDeep[T any](l, r Und[T]) bool {
+	return l.EqualFunc(r, func(i, j T) bool { return reflect.DeepEqual(i, j) })
+}
+
+// CompareFunc compares u and other.
+// States are ordered as undefined < null < defined, and Und values of the same non-defined state are equal.
+// If both are defined, it returns the result of cmp called with their values.
+//
+// The result is -1, 0 or +1 in the same meaning as [cmp.Compare] if cmp does so.
+func (u Und[T]) CompareFunc(other Und[T], cmp func(i, j T) int) int {
+	us, os := u.State(), other.State()
+	switch {
+	case us < os:
+		return -1
+	case us > os:
+		return +1
+	case us != und.StateDefined:
+		return 0
+	}
+	return cmp(u.Value(), other.Value())
+}
+
+// Compare compares l and r.
+// Undefined sorts before null, and null sorts before any defined value.
+// Defined values are compared by [cmp.Compare].
+func Compare[T cmp.Ordered](l, r Und[T]) int {
+	return l.CompareFunc(r, cmp.Compare[T])
+}
+
 // CloneFunc clones u using the cloneT functions.
 func (u Und[T]) CloneFunc(cloneT func(T) T) Und[T] {
 	return u.Map(func(o option.Option[option.Option[T]]) option.Option[option.Option[T]] {
@@ -181,6 +312,13 @@ func (u Und[T]) CloneFunc(cloneT func(T) T) Und[T] {
 	})
 }
 
+// Clone returns a copy of u.
+// If T implements option.Cloner[T], the value is cloned by its Clone method,
+// otherwise it is copied by assignment.
+func (u Und[T]) Clone() Und[T] {
+	return FromOption(u.Unwrap().Clone())
+}
+
 // Clone clones u.
 func Clone[T comparable](u Und[T]) Und[T] {
 	return u.CloneFunc(func(t T) T { return t })
@@ -203,6 +341,20 @@ func (u Und[T]) Pointer() *T {
 	return &v
 }
 
+// Ref returns a pointer to u's internal value, or nil if u is not defined.
+// Unlike [Und.Pointer], the value is not copied.
+//
+// The returned pointer aliases the underlying array of u, which is shared among copies of u:
+// writes through it modify u and its copies.
+// It no longer points to u's value after u is reassigned.
+// Ref is meant for large T where copying made by Pointer is expensive.
+func (u Und[T]) Ref() *T {
+	if !u.IsDefined() {
+		return nil
+	}
+	return u[0].Ref()
+}
+
 // DoublePointer returns nil if u is undefined, &(*T)(nil) if null, the internal value if defined.
 func (u Und[T]) DoublePointer() **T {
 	switch {
@@ -228,10 +380,25 @@ func (u Und[T]) Unwrap() option.Option[option.Option[T]] {
 }
 
 // Und converts u into non-slice version Und[T].
+//
+// Unlike Und[T], the returned value is comparable if T is comparable;
+// it can be compared by == or used as map keys.
 func (u Und[T]) Und() und.Und[T] {
 	return und.FromOption(u.Unwrap())
 }
 
+// Normalize returns u in the canonical form:
+// nil for undefined, a new slice of exactly one element for null and defined.
+//
+// The returned value does not share its underlying array with u,
+// and elements beyond the first one, if any, are dropped.
+func (u Und[T]) Normalize() Und[T] {
+	if u.IsUndefined() {
+		return nil
+	}
+	return Und[T]{u[0]}
+}
+
 // Map returns a new Und[T] whose internal value is u's mapped by f.
 func (u Und[T]) Map(f func(option.Option[option.Option[T]]) option.Option[option.Option[T]]) Und[T] {
 	return FromOption(f(u.Unwrap()))
@@ -243,14 +410,15 @@ func (o Und[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 }
 
 // UnmarshalXML implements xml.Unmarshaler.
+// An element with xsi:nil="true" attribute is unmarshaled as null.
 func (o *Und[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	var t T
-	err := d.DecodeElement(&t, &start)
+	var opt option.Option[T]
+	err := opt.UnmarshalXML(d, start)
 	if err != nil {
 		return err
 	}
 
-	*o = Defined(t)
+	*o = FromOptionValue(opt, und.StateNull)
 
 	return nil
 }
@@ -277,7 +445,16 @@ func (u Und[T]) State() und.State {
 	}
 }
 
+// AnyValue implements und.UndStater. It returns u's value as any.
+func (u Und[T]) AnyValue() any {
+	return u.Value()
+}
+
 // Map returns a new Und value whose internal value is mapped by f.
+// Map can change the type of the value; null stays null and undefined stays undefined, and f is only called if u is defined.
+//
+// Unlike [Und.Map], which operates on the doubly-nested Option and can not change T,
+// Map is suited for converting values, e.g. DTO patches into domain patches.
 func Map[T, U any](u Und[T], f func(t T) U) Und[U] {
 	switch {
 	case u.IsUndefined():
@@ -288,3 +465,205 @@ func Map[T, U any](u Und[T], f func(t T) U) Und[U] {
 		return Defined(f(u.Value()))
 	}
 }
+
+// MapOr returns u's value applied by f if u is defined.
+// Otherwise it returns defaultValue.
+func MapOr[T, U any](u Und[T], defaultValue U, f func(t T) U) U {
+	if !u.IsDefined() {
+		return defaultValue
+	}
+	return f(u.Value())
+}
+
+// MapOrElse returns u's value applied by f if u is defined.
+// Otherwise it returns the result of defaultFn.
+func MapOrElse[T, U any](u Und[T], defaultFn func() U, f func(t T) U) U {
+	if !u.IsDefined() {
+		return defaultFn()
+	}
+	return f(u.Value())
+}
+
+// FirstDefined returns the first defined Und in us.
+// If us has no defined value, it returns an undefined Und[T], even if us has null values.
+// This is where it differs from [und.PreferDefined], which picks null in that case.
+//
+// FirstDefined is like [cmp.Or] but for Und[T].
+func FirstDefined[T any](us ...Und[T]) Und[T] {
+	i := und.PreferDefined.Pick(len(us), func(i int) und.State { return us[i].State() })
+	if i < 0 || !us[i].IsDefined() {
+		return Undefined[T]()
+	}
+	return us[i]
+}
+
+// MapErr is like [Map] but f may fail.
+// If u is defined, it calls f with u's value and returns a defined Und[U] wrapping the result, or the error f returned.
+// Otherwise it returns Und[U] of the same state as u without calling f.
+func MapErr[T, U any](u Und[T], f func(t T) (U, error)) (Und[U], error) {
+	switch {
+	case u.IsUndefined():
+		return Undefined[U](), nil
+	case u.IsNull():
+		return Null[U](), nil
+	}
+	v, err := f(u.Value())
+	if err != nil {
+		return Undefined[U](), err
+	}
+	return Defined(v), nil
+}
+
+// AndThenErr calls f with u's value and returns the result if u is defined.
+// Otherwise it returns Und[U] of the same state as u without calling f.
+func AndThenErr[T, U any](u Und[T], f func(t T) (Und[U], error)) (Und[U], error) {
+	switch {
+	case u.IsUndefined():
+		return Undefined[U](), nil
+	case u.IsNull():
+		return Null[U](), nil
+	}
+	return f(u.Value())
+}
+
+// And returns v if u is defined, otherwise u as it is.
+//
+// Combinators of Und, i.e. And, AndThen, Or, OrElse and Xor, mirror those of option.Option.
+// Only defined values have values to be chained, thus And and AndThen proceed only on defined Und.
+// On the other hand, null counts as "present but empty" for Or, OrElse and Xor,
+// in the same way as [und.PreferPresent] does; only undefined is considered absent.
+func (u Und[T]) And(v Und[T]) Und[T] {
+	if u.IsDefined() {
+		return v
+	}
+	return u
+}
+
+// AndThen calls f with u's value and returns the result if u is defined, otherwise returns u as it is.
+func (u Und[T]) AndThen(f func(t T) Und[T]) Und[T] {
+	if u.IsDefined() {
+		return f(u.Value())
+	}
+	return u
+}
+
+// Or returns u if u is defined or null, otherwise v.
+func (u Und[T]) Or(v Und[T]) Und[T] {
+	if !u.IsUndefined() {
+		return u
+	}
+	return v
+}
+
+// OrElse returns u if u is defined or null, otherwise calls f and returns the result.
+func (u Und[T]) OrElse(f func() Und[T]) Und[T] {
+	if !u.IsUndefined() {
+		return u
+	}
+	return f()
+}
+
+// Xor returns u or v if exactly one of them is defined or null.
+// Otherwise it returns undefined Und[T].
+func (u Und[T]) Xor(v Und[T]) Und[T] {
+	switch {
+	case !u.IsUndefined() && v.IsUndefined():
+		return u
+	case u.IsUndefined() && !v.IsUndefined():
+		return v
+	default:
+		return Undefined[T]()
+	}
+}
+
+// Filter returns u if u is not defined or u's value satisfies pred.
+// Otherwise, i.e. u is defined but pred reports false, it returns an undefined Und[T].
+//
+// Filter is useful for dropping fields of patch payloads that fail checks before applying them.
+func (u Und[T]) Filter(pred func(t T) bool) Und[T] {
+	if u.IsDefined() && !pred(u.Value()) {
+		return Undefined[T]()
+	}
+	return u
+}
+
+// FilterNull is like [Und.Filter] but demotes u to null instead of undefined.
+func (u Und[T]) FilterNull(pred func(t T) bool) Und[T] {
+	if u.IsDefined() && !pred(u.Value()) {
+		return Null[T]()
+	}
+	return u
+}
+
+// Match calls one of functions corresponding to u's state and returns its result:
+// onDefined with u's value if u is defined, onNull if u is null, onUndefined otherwise.
+//
+// Match forces callers to handle all three states, unlike switch statements on u.State().
+func Match[T, U any](u Und[T], onDefined func(t T) U, onNull func() U, onUndefined func() U) U {
+	switch {
+	case u.IsDefined():
+		return onDefined(u.Value())
+	case u.IsNull():
+		return onNull()
+	default:
+		return onUndefined()
+	}
+}
+
+// Match is like the package-level [Match] but calls functions that return nothing.
+func (u Und[T]) Match(onDefined func(t T), onNull func(), onUndefined func()) {
+	switch {
+	case u.IsDefined():
+		onDefined(u.Value())
+	case u.IsNull():
+		onNull()
+	default:
+		onUndefined()
+	}
+}
+
+// Zip combines t and u into an Und of [und.Pair].
+// The returned value is defined only if both are defined.
+// Otherwise it is undefined if either of them is undefined, null if not.
+func Zip[T, U any](t Und[T], u Und[U]) Und[und.Pair[T, U]] {
+	return ZipWith(t, u, func(t T, u U) und.Pair[T, U] { return und.Pair[T, U]{First: t, Second: u} })
+}
+
+// ZipWith is like [Zip] but combines values with f.
+// f is called only if both t and u are defined.
+func ZipWith[T, U, V any](t Und[T], u Und[U], f func(t T, u U) V) Und[V] {
+	switch {
+	case t.IsUndefined() || u.IsUndefined():
+		return Undefined[V]()
+	case t.IsNull() || u.IsNull():
+		return Null[V]()
+	default:
+		return Defined(f(t.Value(), u.Value()))
+	}
+}
+
+// Flatten converts Und[Und[T]] into Und[T].
+//
+// Undefined and null of the outer Und are kept as they are.
+// If the outer Und is defined, the inner Und is returned.
+func Flatten[T any](u Und[Und[T]]) Und[T] {
+	switch {
+	case u.IsUndefined():
+		return Undefined[T]()
+	case u.IsNull():
+		return Null[T]()
+	default:
+		return u.Value()
+	}
+}
+
+// FlattenOption converts option.Option[Und[T]] into Und[T].
+//
+// None is converted into undefined, treating the absence of the Option as the absence of the value.
+// If o is some, its inner Und is returned.
+func FlattenOption[T any](o option.Option[Und[T]]) Und[T] {
+	if o.IsNone() {
+		return Undefined[T]()
+	}
+	return o.Value()
+}
@@ -2,8 +2,11 @@ package sliceund
 
 import (
 	"database/sql"
+	"slices"
+	"strconv"
 	"testing"
 
+	"github.com/ngicks/und"
 	"github.com/ngicks/und/internal/testcase"
 	"github.com/ngicks/und/option"
 	"gotest.tools/v3/assert"
@@ -221,3 +224,312 @@ func Test_Clone(t *testing.T) {
 	cloned = Clone(undefined)
 	assert.Assert(t, cloned.IsUndefined())
 }
+
+func TestUnd_FirstDefined(t *testing.T) {
+	foo := Defined("foo")
+	bar := Defined("bar")
+	null := Null[string]()
+	undefined := Undefined[string]()
+
+	assert.Assert(t, FirstDefined[string]().IsUndefined())
+	assert.Assert(t, FirstDefined(null, undefined).IsUndefined())
+	assert.Assert(t, Equal(FirstDefined(null, undefined, foo, bar), foo))
+	assert.Assert(t, Equal(FirstDefined(bar, null, foo), bar))
+}
+
+func TestUnd_ValueOr(t *testing.T) {
+	for _, u := range []Und[string]{Undefined[string](), Null[string]()} {
+		assert.Equal(t, u.ValueOr("bar"), "bar")
+		assert.Equal(t, u.ValueOrElse(func() string { return "bar" }), "bar")
+	}
+	u := Defined("foo")
+	assert.Equal(t, u.ValueOr("bar"), "foo")
+	assert.Equal(t, u.ValueOrElse(func() string { panic("must not be called") }), "foo")
+}
+
+func TestUnd_Ref(t *testing.T) {
+	for _, u := range []Und[string]{Undefined[string](), Null[string]()} {
+		assert.Assert(t, u.Ref() == nil)
+	}
+	u := Defined("foo")
+	r := u.Ref()
+	assert.Equal(t, *r, "foo")
+	*r = "bar"
+	assert.Equal(t, u.Value(), "bar")
+}
+
+func TestUnd_Compare(t *testing.T) {
+	us := []Und[int]{
+		Defined(2),
+		Null[int](),
+		Defined(1),
+		Undefined[int](),
+		Null[int](),
+	}
+	slices.SortStableFunc(us, Compare[int])
+	expected := []Und[int]{
+		Undefined[int](),
+		Null[int](),
+		Null[int](),
+		Defined(1),
+		Defined(2),
+	}
+	assert.Assert(t, slices.EqualFunc(us, expected, Equal[int]), "%#v", us)
+
+	assert.Equal(t, Compare(Null[int](), Null[int]()), 0)
+	assert.Equal(t, Compare(Defined(1), Defined(1)), 0)
+	assert.Equal(t, Compare(Defined(0), Null[int]()), +1)
+	assert.Equal(t, Compare(Undefined[int](), Null[int]()), -1)
+}
+
+func TestUnd_EqualDeep(t *testing.T) {
+	assert.Assert(t, EqualDeep(Undefined[[]int](), Undefined[[]int]()))
+	assert.Assert(t, EqualDeep(Null[[]int](), Null[[]int]()))
+	assert.Assert(t, !EqualDeep(Null[[]int](), Undefined[[]int]()))
+	assert.Assert(t, !EqualDeep(Null[[]int](), Defined([]int(nil))))
+	assert.Assert(t, EqualDeep(Defined([]int{1, 2}), Defined([]int{1, 2})))
+	assert.Assert(t, !EqualDeep(Defined([]int{1, 2}), Defined([]int{2, 1})))
+}
+
+func TestOr(t *testing.T) {
+	undefined := Undefined[int]()
+	null := Null[int]()
+	one := Defined(1)
+
+	assert.Assert(t, Or[int](und.PreferPresent).IsUndefined())
+	assert.Assert(t, Or(und.PreferPresent, undefined, null, one).IsNull())
+	assert.Assert(t, Equal(Or(und.PreferDefined, undefined, null, one), one))
+	assert.Assert(t, Equal(OrDefined(undefined, null, one), one))
+	assert.Assert(t, OrDefined(undefined, null).IsNull())
+	assert.Assert(t, Or(und.PreferNull, undefined, one, null).IsNull())
+	assert.Assert(t, Equal(OrNull(undefined, one), one))
+}
+
+func TestUnd_MapErr(t *testing.T) {
+	atoi := func(s string) (int, error) { return strconv.Atoi(s) }
+
+	u, err := MapErr(Defined("12"), atoi)
+	assert.NilError(t, err)
+	assert.Equal(t, u.Value(), 12)
+
+	_, err = MapErr(Defined("foo"), atoi)
+	assert.ErrorContains(t, err, "invalid syntax")
+
+	u, err = MapErr(Null[string](), atoi)
+	assert.NilError(t, err)
+	assert.Assert(t, u.IsNull())
+
+	parse := func(s string) (Und[int], error) {
+		if s == "" {
+			return Null[int](), nil
+		}
+		i, err := strconv.Atoi(s)
+		return Defined(i), err
+	}
+
+	u, err = AndThenErr(Defined(""), parse)
+	assert.NilError(t, err)
+	assert.Assert(t, u.IsNull())
+
+	u, err = AndThenErr(Undefined[string](), parse)
+	assert.NilError(t, err)
+	assert.Assert(t, u.IsUndefined())
+}
+
+func TestFlatten(t *testing.T) {
+	assert.Assert(t, Flatten(Undefined[Und[int]]()).IsUndefined())
+	assert.Assert(t, Flatten(Null[Und[int]]()).IsNull())
+	assert.Assert(t, Flatten(Defined(Undefined[int]())).IsUndefined())
+	assert.Assert(t, Flatten(Defined(Null[int]())).IsNull())
+	assert.Assert(t, Equal(Flatten(Defined(Defined(5))), Defined(5)))
+
+	assert.Assert(t, FlattenOption(option.None[Und[int]]()).IsUndefined())
+	assert.Assert(t, FlattenOption(option.Some(Null[int]())).IsNull())
+	assert.Assert(t, Equal(FlattenOption(option.Some(Defined(5))), Defined(5)))
+}
+
+func TestUnd_Filter(t *testing.T) {
+	positive := func(i int) bool { return i > 0 }
+	for _, u := range []Und[int]{Undefined[int](), Null[int](), Defined(1)} {
+		assert.Assert(t, Equal(u.Filter(positive), u))
+		assert.Assert(t, Equal(u.FilterNull(positive), u))
+	}
+	assert.Assert(t, Defined(-1).Filter(positive).IsUndefined())
+	assert.Assert(t, Defined(-1).FilterNull(positive).IsNull())
+}
+
+func TestMap(t *testing.T) {
+	called := false
+	itoa := func(i int) string { called = true; return strconv.Itoa(i) }
+	assert.Assert(t, Map(Undefined[int](), itoa).IsUndefined())
+	assert.Assert(t, Map(Null[int](), itoa).IsNull())
+	assert.Assert(t, !called)
+	assert.Assert(t, Equal(Map(Defined(12), itoa), Defined("12")))
+}
+
+func TestUnd_combinators(t *testing.T) {
+	var (
+		undefined = Undefined[int]()
+		null      = Null[int]()
+		one       = Defined(1)
+		two       = Defined(2)
+	)
+	double := func(i int) Und[int] { return Defined(i * 2) }
+	seven := func() Und[int] { return Defined(7) }
+
+	for _, tc := range []struct {
+		name     string
+		result   Und[int]
+		expected Und[int]
+	}{
+		{"undefined.And", undefined.And(two), undefined},
+		{"null.And", null.And(two), null},
+		{"defined.And", one.And(two), two},
+		{"undefined.AndThen", undefined.AndThen(double), undefined},
+		{"null.AndThen", null.AndThen(double), null},
+		{"defined.AndThen", one.AndThen(double), two},
+		{"undefined.Or", undefined.Or(two), two},
+		{"null.Or", null.Or(two), null},
+		{"defined.Or", one.Or(two), one},
+		{"undefined.OrElse", undefined.OrElse(seven), Defined(7)},
+		{"null.OrElse", null.OrElse(seven), null},
+		{"defined.OrElse", one.OrElse(seven), one},
+		{"undefined.Xor(undefined)", undefined.Xor(undefined), undefined},
+		{"undefined.Xor(null)", undefined.Xor(null), null},
+		{"null.Xor(undefined)", null.Xor(undefined), null},
+		{"null.Xor(defined)", null.Xor(one), undefined},
+		{"defined.Xor(undefined)", one.Xor(undefined), one},
+		{"defined.Xor(defined)", one.Xor(two), undefined},
+	} {
+		assert.Assert(t, Equal(tc.result, tc.expected), tc.name)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	onDefined := func(i int) string { return strconv.Itoa(i) }
+	onNull := func() string { return "null" }
+	onUndefined := func() string { return "undefined" }
+	assert.Equal(t, Match(Defined(5), onDefined, onNull, onUndefined), "5")
+	assert.Equal(t, Match(Null[int](), onDefined, onNull, onUndefined), "null")
+	assert.Equal(t, Match(Undefined[int](), onDefined, onNull, onUndefined), "undefined")
+
+	var called []string
+	for _, u := range []Und[int]{Defined(5), Null[int](), Undefined[int]()} {
+		u.Match(
+			func(i int) { called = append(called, onDefined(i)) },
+			func() { called = append(called, onNull()) },
+			func() { called = append(called, onUndefined()) },
+		)
+	}
+	assert.DeepEqual(t, []string{"5", "null", "undefined"}, called)
+}
+
+func TestUnd_OptionValue(t *testing.T) {
+	assert.Assert(t, Equal(FromOptionValue(option.Some(1), und.StateNull), Defined(1)))
+	assert.Assert(t, FromOptionValue(option.None[int](), und.StateNull).IsNull())
+	assert.Assert(t, FromOptionValue(option.None[int](), und.StateUndefined).IsUndefined())
+	func() {
+		defer func() { assert.Assert(t, recover() != nil) }()
+		FromOptionValue(option.None[int](), und.StateDefined)
+	}()
+
+	for _, nullAsZero := range []bool{false, true} {
+		assert.Equal(t, Defined(1).ToOption(nullAsZero), option.Some(1))
+		assert.Equal(t, Undefined[int]().ToOption(nullAsZero), option.None[int]())
+	}
+	assert.Equal(t, Null[int]().ToOption(false), option.None[int]())
+	assert.Equal(t, Null[int]().ToOption(true), option.Some(0))
+}
+
+func TestFromDoublePointer(t *testing.T) {
+	for _, u := range []Und[int]{Undefined[int](), Null[int](), Defined(5)} {
+		assert.Assert(t, Equal(u, FromDoublePointer(u.DoublePointer())))
+	}
+	var nilPtr *int
+	assert.Assert(t, FromDoublePointer[int](nil).IsUndefined())
+	assert.Assert(t, FromDoublePointer(&nilPtr).IsNull())
+	five := 5
+	p := &five
+	u := FromDoublePointer(&p)
+	five = 6
+	assert.Equal(t, u.Value(), 5)
+}
+
+func TestUnd_CheckedGet(t *testing.T) {
+	v, err := Defined(5).CheckedGet()
+	assert.NilError(t, err)
+	assert.Equal(t, v, 5)
+	v, err = Null[int]().CheckedGet()
+	assert.ErrorIs(t, err, und.ErrNull)
+	assert.Equal(t, v, 0)
+	v, err = Undefined[int]().CheckedGet()
+	assert.ErrorIs(t, err, und.ErrUndefined)
+	assert.Equal(t, v, 0)
+}
+
+func TestZip(t *testing.T) {
+	var (
+		undefined = Undefined[int]()
+		null      = Null[int]()
+		defined   = Defined(1)
+		undefStr  = Undefined[string]()
+		nullStr   = Null[string]()
+		defStr    = Defined("foo")
+	)
+	for _, tc := range []struct {
+		l        Und[int]
+		r        Und[string]
+		expected und.State
+	}{
+		{undefined, undefStr, und.StateUndefined},
+		{undefined, nullStr, und.StateUndefined},
+		{null, undefStr, und.StateUndefined},
+		{undefined, defStr, und.StateUndefined},
+		{defined, undefStr, und.StateUndefined},
+		{null, nullStr, und.StateNull},
+		{null, defStr, und.StateNull},
+		{defined, nullStr, und.StateNull},
+		{defined, defStr, und.StateDefined},
+	} {
+		assert.Equal(t, Zip(tc.l, tc.r).State(), tc.expected)
+	}
+	assert.Equal(t, Zip(defined, defStr).Value(), und.Pair[int, string]{First: 1, Second: "foo"})
+	assert.Equal(t, ZipWith(defined, defStr, func(i int, s string) string { return strconv.Itoa(i) + s }).Value(), "1foo")
+	ZipWith(null, defStr, func(int, string) string { panic("must not be called") })
+}
+
+func TestUnd_Normalize(t *testing.T) {
+	assert.Assert(t, Und[int]{}.Normalize() == nil)
+	assert.Assert(t, Undefined[int]().Normalize() == nil)
+
+	u := Und[int]{option.Some(1), option.Some(2)}
+	n := u.Normalize()
+	assert.Equal(t, len(n), 1)
+	assert.Equal(t, cap(n), 1)
+	assert.Assert(t, Equal(n, Defined(1)))
+	u[0] = option.Some(3)
+	assert.Equal(t, n.Value(), 1)
+
+	assert.Assert(t, Null[int]().Normalize().IsNull())
+
+	m := map[und.Und[int]]string{
+		Defined(1).Und():       "defined",
+		Null[int]().Und():      "null",
+		Undefined[int]().Und(): "undefined",
+	}
+	assert.Equal(t, m[Defined(1).Und()], "defined")
+	assert.Equal(t, m[Null[int]().Und()], "null")
+	assert.Equal(t, m[Und[int]{}.Und()], "undefined")
+}
+
+func TestMapOr(t *testing.T) {
+	itoa := func(i int) string { return strconv.Itoa(i) }
+	assert.Equal(t, MapOr(Defined(5), "default", itoa), "5")
+	assert.Equal(t, MapOr(Null[int](), "default", itoa), "default")
+	assert.Equal(t, MapOr(Undefined[int](), "default", itoa), "default")
+
+	def := func() string { return "default" }
+	assert.Equal(t, MapOrElse(Defined(5), def, itoa), "5")
+	assert.Equal(t, MapOrElse(Null[int](), def, itoa), "default")
+	assert.Equal(t, MapOrElse(Undefined[int](), def, itoa), "default")
+}
@@ -0,0 +1,15 @@
+package sliceund
+
+import (
+	"encoding/xml"
+
+	"github.com/ngicks/und"
+)
+
+// MarshalXMLNull is like MarshalXML but marshals null according to style.
+// Undefined u is always omitted.
+//
+// See [und.Und.MarshalXMLNull] for details.
+func (u Und[T]) MarshalXMLNull(e *xml.Encoder, start xml.StartElement, style und.XMLNullStyle) error {
+	return und.FromOption(u.Unwrap()).MarshalXMLNull(e, start, style)
+}
@@ -1,10 +1,40 @@
 package und
 
-// State is
-type State int
+import (
+	"errors"
+
+	"github.com/ngicks/und/internal/undstate"
+)
+
+var (
+	// ErrNull is returned by CheckedGet methods of und types if the value is null.
+	ErrNull = errors.New("null")
+	// ErrUndefined is returned by CheckedGet methods of und types if the value is undefined.
+	ErrUndefined = errors.New("undefined")
+)
+
+// State is the state of und types: undefined, null or defined.
+//
+// States are bit flags so that multiple states can be combined into a mask, e.g. StateUndefined | StateNull.
+//
+// State implements fmt.Stringer, encoding.TextMarshaler and encoding.TextUnmarshaler
+// with "undefined", "null" and "defined".
+// IsValid reports whether it is exactly one of the states, and Is reports whether it is any of the given masks.
+type State = undstate.State
 
 const (
-	StateUndefined = State(1 << iota)
-	StateNull
-	StateDefined
+	StateUndefined = undstate.StateUndefined
+	StateNull      = undstate.StateNull
+	StateDefined   = undstate.StateDefined
 )
+
+// UndStater is implemented by types which report their state as [State],
+// e.g. Und[T], sliceund.Und[T] and elastic types.
+//
+// Third party optional types, e.g. presence wrappers of generated code, can implement UndStater
+// so that validate.UndValidate, validate.UndCheck and functions of the patch package
+// treat them like Und[T] without conversion.
+//
+// It has the methods State() State and AnyValue() any,
+// which returns the underlying value as any and is only meaningful if State returns StateDefined.
+type UndStater = undstate.UndStater
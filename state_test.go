@@ -0,0 +1,49 @@
+package und_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und"
+	"gotest.tools/v3/assert"
+)
+
+func TestState(t *testing.T) {
+	for _, tc := range []struct {
+		s    und.State
+		text string
+	}{
+		{und.StateUndefined, "undefined"},
+		{und.StateNull, "null"},
+		{und.StateDefined, "defined"},
+	} {
+		assert.Assert(t, tc.s.IsValid())
+		assert.Equal(t, tc.s.String(), tc.text)
+
+		bin, err := tc.s.MarshalText()
+		assert.NilError(t, err)
+		assert.Equal(t, string(bin), tc.text)
+
+		var s und.State
+		assert.NilError(t, s.UnmarshalText([]byte(tc.text)))
+		assert.Equal(t, s, tc.s)
+	}
+
+	invalid := und.StateNull | und.StateDefined
+	assert.Assert(t, !invalid.IsValid())
+	assert.Equal(t, invalid.String(), "State(6)")
+	_, err := invalid.MarshalText()
+	assert.Assert(t, err != nil)
+	var s und.State
+	assert.Assert(t, s.UnmarshalText([]byte("Defined")) != nil)
+
+	bin, err := json.Marshal(map[und.State]und.State{und.StateNull: und.StateDefined})
+	assert.NilError(t, err)
+	assert.Equal(t, string(bin), `{"null":"defined"}`)
+
+	assert.Assert(t, und.StateNull.Is(und.StateNull))
+	assert.Assert(t, und.StateNull.Is(und.StateDefined, und.StateNull))
+	assert.Assert(t, und.StateNull.Is(und.StateUndefined|und.StateNull))
+	assert.Assert(t, !und.StateDefined.Is(und.StateUndefined|und.StateNull))
+	assert.Assert(t, !und.StateDefined.Is())
+}
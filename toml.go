@@ -0,0 +1,26 @@
+package und
+
+import "github.com/ngicks/und/option"
+
+// MarshalTOML implements the marshaler interface of github.com/BurntSushi/toml.
+//
+// TOML has no null; MarshalTOML returns an error if u is not defined.
+// Undefined fields are skipped if `toml:",omitempty"` option is attached to those fields.
+// See [option.Option.MarshalTOML] for supported types.
+func (u Und[T]) MarshalTOML() ([]byte, error) {
+	return u.opt.Value().MarshalTOML()
+}
+
+// UnmarshalTOML implements the unmarshaler interface of github.com/BurntSushi/toml.
+//
+// A missing key, e.g. a commented-out one, does not call UnmarshalTOML and leaves u undefined.
+// See [option.Option.UnmarshalTOML] for details.
+func (u *Und[T]) UnmarshalTOML(data any) error {
+	var opt option.Option[T]
+	err := opt.UnmarshalTOML(data)
+	if err != nil {
+		return err
+	}
+	*u = FromOption(option.Some(opt))
+	return nil
+}
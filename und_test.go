@@ -2,6 +2,8 @@ package und_test
 
 import (
 	"database/sql"
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/ngicks/und"
@@ -214,3 +216,285 @@ func Test_Clone(t *testing.T) {
 	cloned = und.Clone(undefined)
 	assert.Assert(t, cloned.IsUndefined())
 }
+
+func TestUnd_FirstDefined(t *testing.T) {
+	foo := und.Defined("foo")
+	bar := und.Defined("bar")
+	null := und.Null[string]()
+	undefined := und.Undefined[string]()
+
+	assert.Equal(t, und.FirstDefined[string](), undefined)
+	assert.Equal(t, und.FirstDefined(null, undefined), undefined)
+	assert.Equal(t, und.FirstDefined(null, undefined, foo, bar), foo)
+	assert.Equal(t, und.FirstDefined(bar, null, foo), bar)
+}
+
+func TestUnd_ValueOr(t *testing.T) {
+	for _, u := range []und.Und[string]{und.Undefined[string](), und.Null[string]()} {
+		assert.Equal(t, u.ValueOr("bar"), "bar")
+		assert.Equal(t, u.ValueOrElse(func() string { return "bar" }), "bar")
+	}
+	u := und.Defined("foo")
+	assert.Equal(t, u.ValueOr("bar"), "foo")
+	assert.Equal(t, u.ValueOrElse(func() string { panic("must not be called") }), "foo")
+}
+
+func TestUnd_Ref(t *testing.T) {
+	for _, u := range []und.Und[string]{und.Undefined[string](), und.Null[string]()} {
+		assert.Assert(t, u.Ref() == nil)
+	}
+	u := und.Defined("foo")
+	r := u.Ref()
+	assert.Equal(t, *r, "foo")
+	*r = "bar"
+	assert.Equal(t, u.Value(), "bar")
+}
+
+func TestUnd_Compare(t *testing.T) {
+	us := []und.Und[int]{
+		und.Defined(2),
+		und.Null[int](),
+		und.Defined(1),
+		und.Undefined[int](),
+		und.Null[int](),
+	}
+	slices.SortStableFunc(us, und.Compare[int])
+	expected := []und.Und[int]{
+		und.Undefined[int](),
+		und.Null[int](),
+		und.Null[int](),
+		und.Defined(1),
+		und.Defined(2),
+	}
+	assert.Assert(t, slices.EqualFunc(us, expected, und.Equal[int]), "%#v", us)
+
+	assert.Equal(t, und.Compare(und.Null[int](), und.Null[int]()), 0)
+	assert.Equal(t, und.Compare(und.Defined(1), und.Defined(1)), 0)
+	assert.Equal(t, und.Compare(und.Defined(0), und.Null[int]()), +1)
+	assert.Equal(t, und.Compare(und.Undefined[int](), und.Null[int]()), -1)
+}
+
+func TestUnd_EqualDeep(t *testing.T) {
+	assert.Assert(t, und.EqualDeep(und.Undefined[[]int](), und.Undefined[[]int]()))
+	assert.Assert(t, und.EqualDeep(und.Null[[]int](), und.Null[[]int]()))
+	assert.Assert(t, !und.EqualDeep(und.Null[[]int](), und.Undefined[[]int]()))
+	assert.Assert(t, !und.EqualDeep(und.Null[[]int](), und.Defined([]int(nil))))
+	assert.Assert(t, und.EqualDeep(und.Defined([]int{1, 2}), und.Defined([]int{1, 2})))
+	assert.Assert(t, !und.EqualDeep(und.Defined([]int{1, 2}), und.Defined([]int{2, 1})))
+}
+
+func TestUnd_MapErr(t *testing.T) {
+	atoi := func(s string) (int, error) { return strconv.Atoi(s) }
+
+	u, err := und.MapErr(und.Defined("12"), atoi)
+	assert.NilError(t, err)
+	assert.Equal(t, u, und.Defined(12))
+
+	_, err = und.MapErr(und.Defined("foo"), atoi)
+	assert.ErrorContains(t, err, "invalid syntax")
+
+	u, err = und.MapErr(und.Null[string](), atoi)
+	assert.NilError(t, err)
+	assert.Assert(t, u.IsNull())
+
+	u, err = und.MapErr(und.Undefined[string](), atoi)
+	assert.NilError(t, err)
+	assert.Assert(t, u.IsUndefined())
+
+	parse := func(s string) (und.Und[int], error) {
+		if s == "" {
+			return und.Null[int](), nil
+		}
+		i, err := strconv.Atoi(s)
+		return und.Defined(i), err
+	}
+
+	u, err = und.AndThenErr(und.Defined(""), parse)
+	assert.NilError(t, err)
+	assert.Assert(t, u.IsNull())
+
+	u, err = und.AndThenErr(und.Defined("7"), parse)
+	assert.NilError(t, err)
+	assert.Equal(t, u, und.Defined(7))
+
+	_, err = und.AndThenErr(und.Defined("foo"), parse)
+	assert.ErrorContains(t, err, "invalid syntax")
+
+	u, err = und.AndThenErr(und.Undefined[string](), parse)
+	assert.NilError(t, err)
+	assert.Assert(t, u.IsUndefined())
+}
+
+func TestFlatten(t *testing.T) {
+	assert.Assert(t, und.Flatten(und.Undefined[und.Und[int]]()).IsUndefined())
+	assert.Assert(t, und.Flatten(und.Null[und.Und[int]]()).IsNull())
+	assert.Assert(t, und.Flatten(und.Defined(und.Undefined[int]())).IsUndefined())
+	assert.Assert(t, und.Flatten(und.Defined(und.Null[int]())).IsNull())
+	assert.Assert(t, und.Equal(und.Flatten(und.Defined(und.Defined(5))), und.Defined(5)))
+
+	assert.Assert(t, und.FlattenOption(option.None[und.Und[int]]()).IsUndefined())
+	assert.Assert(t, und.FlattenOption(option.Some(und.Null[int]())).IsNull())
+	assert.Assert(t, und.Equal(und.FlattenOption(option.Some(und.Defined(5))), und.Defined(5)))
+}
+
+func TestUnd_Filter(t *testing.T) {
+	positive := func(i int) bool { return i > 0 }
+	for _, u := range []und.Und[int]{und.Undefined[int](), und.Null[int](), und.Defined(1)} {
+		assert.Assert(t, und.Equal(u.Filter(positive), u))
+		assert.Assert(t, und.Equal(u.FilterNull(positive), u))
+	}
+	assert.Assert(t, und.Defined(-1).Filter(positive).IsUndefined())
+	assert.Assert(t, und.Defined(-1).FilterNull(positive).IsNull())
+}
+
+func TestMap(t *testing.T) {
+	called := false
+	itoa := func(i int) string { called = true; return strconv.Itoa(i) }
+	assert.Assert(t, und.Map(und.Undefined[int](), itoa).IsUndefined())
+	assert.Assert(t, und.Map(und.Null[int](), itoa).IsNull())
+	assert.Assert(t, !called)
+	assert.Assert(t, und.Equal(und.Map(und.Defined(12), itoa), und.Defined("12")))
+}
+
+func TestUnd_combinators(t *testing.T) {
+	var (
+		undefined = und.Undefined[int]()
+		null      = und.Null[int]()
+		one       = und.Defined(1)
+		two       = und.Defined(2)
+	)
+	double := func(i int) und.Und[int] { return und.Defined(i * 2) }
+	seven := func() und.Und[int] { return und.Defined(7) }
+
+	for _, tc := range []struct {
+		name     string
+		result   und.Und[int]
+		expected und.Und[int]
+	}{
+		{"undefined.And", undefined.And(two), undefined},
+		{"null.And", null.And(two), null},
+		{"defined.And", one.And(two), two},
+		{"undefined.AndThen", undefined.AndThen(double), undefined},
+		{"null.AndThen", null.AndThen(double), null},
+		{"defined.AndThen", one.AndThen(double), two},
+		{"undefined.Or", undefined.Or(two), two},
+		{"null.Or", null.Or(two), null},
+		{"defined.Or", one.Or(two), one},
+		{"undefined.OrElse", undefined.OrElse(seven), und.Defined(7)},
+		{"null.OrElse", null.OrElse(seven), null},
+		{"defined.OrElse", one.OrElse(seven), one},
+		{"undefined.Xor(undefined)", undefined.Xor(undefined), undefined},
+		{"undefined.Xor(null)", undefined.Xor(null), null},
+		{"null.Xor(undefined)", null.Xor(undefined), null},
+		{"null.Xor(defined)", null.Xor(one), undefined},
+		{"defined.Xor(undefined)", one.Xor(undefined), one},
+		{"defined.Xor(defined)", one.Xor(two), undefined},
+	} {
+		assert.Assert(t, und.Equal(tc.result, tc.expected), tc.name)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	onDefined := func(i int) string { return strconv.Itoa(i) }
+	onNull := func() string { return "null" }
+	onUndefined := func() string { return "undefined" }
+	assert.Equal(t, und.Match(und.Defined(5), onDefined, onNull, onUndefined), "5")
+	assert.Equal(t, und.Match(und.Null[int](), onDefined, onNull, onUndefined), "null")
+	assert.Equal(t, und.Match(und.Undefined[int](), onDefined, onNull, onUndefined), "undefined")
+
+	var called []string
+	for _, u := range []und.Und[int]{und.Defined(5), und.Null[int](), und.Undefined[int]()} {
+		u.Match(
+			func(i int) { called = append(called, onDefined(i)) },
+			func() { called = append(called, onNull()) },
+			func() { called = append(called, onUndefined()) },
+		)
+	}
+	assert.DeepEqual(t, []string{"5", "null", "undefined"}, called)
+}
+
+func TestUnd_OptionValue(t *testing.T) {
+	assert.Assert(t, und.Equal(und.FromOptionValue(option.Some(1), und.StateNull), und.Defined(1)))
+	assert.Assert(t, und.FromOptionValue(option.None[int](), und.StateNull).IsNull())
+	assert.Assert(t, und.FromOptionValue(option.None[int](), und.StateUndefined).IsUndefined())
+	func() {
+		defer func() { assert.Assert(t, recover() != nil) }()
+		und.FromOptionValue(option.None[int](), und.StateDefined)
+	}()
+
+	for _, nullAsZero := range []bool{false, true} {
+		assert.Equal(t, und.Defined(1).ToOption(nullAsZero), option.Some(1))
+		assert.Equal(t, und.Undefined[int]().ToOption(nullAsZero), option.None[int]())
+	}
+	assert.Equal(t, und.Null[int]().ToOption(false), option.None[int]())
+	assert.Equal(t, und.Null[int]().ToOption(true), option.Some(0))
+}
+
+func TestFromDoublePointer(t *testing.T) {
+	for _, u := range []und.Und[int]{und.Undefined[int](), und.Null[int](), und.Defined(5)} {
+		assert.Assert(t, und.Equal(u, und.FromDoublePointer(u.DoublePointer())))
+	}
+	var nilPtr *int
+	assert.Assert(t, und.FromDoublePointer[int](nil).IsUndefined())
+	assert.Assert(t, und.FromDoublePointer(&nilPtr).IsNull())
+	five := 5
+	p := &five
+	u := und.FromDoublePointer(&p)
+	five = 6
+	assert.Equal(t, u.Value(), 5)
+}
+
+func TestUnd_CheckedGet(t *testing.T) {
+	v, err := und.Defined(5).CheckedGet()
+	assert.NilError(t, err)
+	assert.Equal(t, v, 5)
+	v, err = und.Null[int]().CheckedGet()
+	assert.ErrorIs(t, err, und.ErrNull)
+	assert.Equal(t, v, 0)
+	v, err = und.Undefined[int]().CheckedGet()
+	assert.ErrorIs(t, err, und.ErrUndefined)
+	assert.Equal(t, v, 0)
+}
+
+func TestZip(t *testing.T) {
+	var (
+		undefined = und.Undefined[int]()
+		null      = und.Null[int]()
+		defined   = und.Defined(1)
+		undefStr  = und.Undefined[string]()
+		nullStr   = und.Null[string]()
+		defStr    = und.Defined("foo")
+	)
+	for _, tc := range []struct {
+		l        und.Und[int]
+		r        und.Und[string]
+		expected und.State
+	}{
+		{undefined, undefStr, und.StateUndefined},
+		{undefined, nullStr, und.StateUndefined},
+		{null, undefStr, und.StateUndefined},
+		{undefined, defStr, und.StateUndefined},
+		{defined, undefStr, und.StateUndefined},
+		{null, nullStr, und.StateNull},
+		{null, defStr, und.StateNull},
+		{defined, nullStr, und.StateNull},
+		{defined, defStr, und.StateDefined},
+	} {
+		assert.Equal(t, und.Zip(tc.l, tc.r).State(), tc.expected)
+	}
+	assert.Equal(t, und.Zip(defined, defStr).Value(), und.Pair[int, string]{First: 1, Second: "foo"})
+	assert.Equal(t, und.ZipWith(defined, defStr, func(i int, s string) string { return strconv.Itoa(i) + s }).Value(), "1foo")
+	und.ZipWith(null, defStr, func(int, string) string { panic("must not be called") })
+}
+
+func TestMapOr(t *testing.T) {
+	itoa := func(i int) string { return strconv.Itoa(i) }
+	assert.Equal(t, und.MapOr(und.Defined(5), "default", itoa), "5")
+	assert.Equal(t, und.MapOr(und.Null[int](), "default", itoa), "default")
+	assert.Equal(t, und.MapOr(und.Undefined[int](), "default", itoa), "default")
+
+	def := func() string { return "default" }
+	assert.Equal(t, und.MapOrElse(und.Defined(5), def, itoa), "5")
+	assert.Equal(t, und.MapOrElse(und.Null[int](), def, itoa), "default")
+	assert.Equal(t, und.MapOrElse(und.Undefined[int](), def, itoa), "default")
+}
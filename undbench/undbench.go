@@ -0,0 +1,188 @@
+// Package undbench provides a benchmark harness comparing JSON encoding performance of und variants,
+// und.Und, sliceund.Und, elastic.Elastic and sliceund/elastic.Elastic,
+// over structs of configurable shapes.
+//
+// [Run] measures all [Variants] from ordinary programs, e.g. a main function,
+// and [Benchmark] can be called from Benchmark functions of users' test files
+// so that results are reported by go test -bench and compared across releases.
+package undbench
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+)
+
+// Shape describes the shape of structs under benchmark.
+type Shape struct {
+	// Fields is the number of und-typed fields of the struct.
+	Fields int
+	// Defined is the ratio of defined fields, in range of [0, 1].
+	Defined float64
+	// Null is the ratio of null fields, in range of [0, 1].
+	// The rest of fields, 1 - Defined - Null, are undefined.
+	Null float64
+	// Value is marshaled into defined fields.
+	// If Value is empty, "value" is used.
+	Value string
+}
+
+func (s Shape) String() string {
+	return fmt.Sprintf("fields=%d,defined=%.2f,null=%.2f", s.Fields, s.Defined, s.Null)
+}
+
+// Variant is an und type under benchmark.
+type Variant struct {
+	// Name is the name of the variant, e.g. "und".
+	Name string
+	// Type is the field type of the variant, e.g. und.Und[string].
+	Type reflect.Type
+
+	undefined, null any
+	defined         func(v string) any
+}
+
+func variant[U any](name string, undefined, null U, defined func(v string) U) Variant {
+	return Variant{
+		Name:      name,
+		Type:      reflect.TypeFor[U](),
+		undefined: undefined,
+		null:      null,
+		defined:   func(v string) any { return defined(v) },
+	}
+}
+
+// Variants are all variants defined in this module.
+var Variants = []Variant{
+	variant("und", und.Undefined[string](), und.Null[string](), und.Defined[string]),
+	variant("sliceund", sliceund.Undefined[string](), sliceund.Null[string](), sliceund.Defined[string]),
+	variant("elastic", elastic.Undefined[string](), elastic.Null[string](), elastic.FromValue[string]),
+	variant("sliceund/elastic", sliceelastic.Undefined[string](), sliceelastic.Null[string](), sliceelastic.FromValue[string]),
+}
+
+// Sample returns a pointer to a struct of shape s whose fields are of the variant v.
+//
+// Fields are tagged with `json:"f<index>,omitempty,omitzero"`, so undefined fields are omitted
+// (for und.Und and elastic.Elastic, only if built with Go 1.24 or later).
+// Defined fields come first, followed by null fields, then undefined fields.
+func Sample(v Variant, s Shape) any {
+	fields := make([]reflect.StructField, s.Fields)
+	for i := range fields {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("F%d", i),
+			Type: v.Type,
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:"f%d,omitempty,omitzero"`, i)),
+		}
+	}
+	rv := reflect.New(reflect.StructOf(fields))
+
+	value := s.Value
+	if value == "" {
+		value = "value"
+	}
+	defined := int(float64(s.Fields)*s.Defined + 0.5)
+	null := int(float64(s.Fields)*s.Null + 0.5)
+	for i := range s.Fields {
+		var fv any
+		switch {
+		case i < defined:
+			fv = v.defined(value)
+		case i < defined+null:
+			fv = v.null
+		default:
+			fv = v.undefined
+		}
+		rv.Elem().Field(i).Set(reflect.ValueOf(fv))
+	}
+	return rv.Interface()
+}
+
+// Result is a benchmark result of a variant.
+type Result struct {
+	Variant string
+	Shape   Shape
+	// Size is the length of the marshaled JSON in bytes.
+	Size      int
+	Marshal   testing.BenchmarkResult
+	Unmarshal testing.BenchmarkResult
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf(
+		"%s\t%s\tsize=%d\tmarshal: %s %s\tunmarshal: %s %s",
+		r.Variant, r.Shape, r.Size,
+		r.Marshal.String(), r.Marshal.MemString(),
+		r.Unmarshal.String(), r.Unmarshal.MemString(),
+	)
+}
+
+// Run benchmarks json.Marshal and json.Unmarshal of all [Variants] for shape s.
+// Each benchmark is run by testing.Benchmark, thus takes about 1 second.
+func Run(s Shape) ([]Result, error) {
+	results := make([]Result, 0, len(Variants))
+	for _, v := range Variants {
+		sample := Sample(v, s)
+		bin, err := json.Marshal(sample)
+		if err != nil {
+			return nil, fmt.Errorf("undbench: marshaling %s: %w", v.Name, err)
+		}
+		results = append(results, Result{
+			Variant:   v.Name,
+			Shape:     s,
+			Size:      len(bin),
+			Marshal:   testing.Benchmark(func(b *testing.B) { BenchmarkMarshal(b, v, s) }),
+			Unmarshal: testing.Benchmark(func(b *testing.B) { BenchmarkUnmarshal(b, v, s) }),
+		})
+	}
+	return results, nil
+}
+
+// Benchmark runs [BenchmarkMarshal] and [BenchmarkUnmarshal] of all [Variants] for shape s as sub-benchmarks.
+func Benchmark(b *testing.B, s Shape) {
+	for _, v := range Variants {
+		b.Run(v.Name+"/marshal", func(b *testing.B) { BenchmarkMarshal(b, v, s) })
+		b.Run(v.Name+"/unmarshal", func(b *testing.B) { BenchmarkUnmarshal(b, v, s) })
+	}
+}
+
+// BenchmarkMarshal benchmarks json.Marshal of [Sample] of v and s.
+// It reports the size of marshaled JSON as the "bytes" metric.
+func BenchmarkMarshal(b *testing.B, v Variant, s Shape) {
+	sample := Sample(v, s)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var size int
+	for range b.N {
+		bin, err := json.Marshal(sample)
+		if err != nil {
+			b.Fatal(err)
+		}
+		size = len(bin)
+	}
+	b.ReportMetric(float64(size), "bytes")
+}
+
+// BenchmarkUnmarshal benchmarks json.Unmarshal of JSON marshaled from [Sample] of v and s.
+// It reports the size of the JSON as the "bytes" metric.
+func BenchmarkUnmarshal(b *testing.B, v Variant, s Shape) {
+	sample := Sample(v, s)
+	bin, err := json.Marshal(sample)
+	if err != nil {
+		b.Fatal(err)
+	}
+	rt := reflect.TypeOf(sample).Elem()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if err := json.Unmarshal(bin, reflect.New(rt).Interface()); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(len(bin)), "bytes")
+}
@@ -0,0 +1,33 @@
+//go:build go1.24
+
+package undbench_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und/undbench"
+	"gotest.tools/v3/assert"
+)
+
+func TestSample(t *testing.T) {
+	s := undbench.Shape{Fields: 4, Defined: 0.5, Null: 0.25}
+	for _, v := range undbench.Variants {
+		bin, err := json.Marshal(undbench.Sample(v, s))
+		assert.NilError(t, err)
+		switch v.Name {
+		case "und", "sliceund":
+			assert.Equal(t, string(bin), `{"f0":"value","f1":"value","f2":null}`)
+		default:
+			assert.Equal(t, string(bin), `{"f0":["value"],"f1":["value"],"f2":null}`)
+		}
+	}
+}
+
+func BenchmarkSparse(b *testing.B) {
+	undbench.Benchmark(b, undbench.Shape{Fields: 32, Defined: 0.1, Null: 0.1})
+}
+
+func BenchmarkDense(b *testing.B) {
+	undbench.Benchmark(b, undbench.Shape{Fields: 32, Defined: 0.9, Null: 0.05})
+}
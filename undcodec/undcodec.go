@@ -0,0 +1,359 @@
+// Package undcodec implements a compact, versioned binary format for und types,
+// suitable for caches like Redis or Memcached.
+//
+// Every encoded value starts with a 3 bytes header:
+// the format version ([Version]), the ID of the [PayloadCodec] encoding values,
+// and the state tag, the value of und.State.
+// Undefined and null values consist only of the header.
+// For defined und.Und[T], sliceund.Und[T] and some option.Option[T] (which is tagged as defined),
+// the header is followed by the value encoded by the payload codec.
+// For defined elastic types, the header is followed by the number of elements as an unsigned varint,
+// then each element as a byte 0 for None, or a byte 1, the length of the encoded value as an unsigned varint and the encoded value for Some.
+//
+// Unlike JSON, the format keeps the undefined state of top level values,
+// and the state of elements of elastic types.
+//
+// Following the header, the format is the one MarshalBinary methods of option.Option[T], und.Und[T] and sliceund.Und[T] write,
+// for values encoded by [CBOR] if T neither has a codec registered by option.RegisterBinaryCodec nor implements encoding.BinaryMarshaler,
+// or by [Raw] if T implements encoding.BinaryMarshaler.
+// Thus data written by those methods can be decoded by Unmarshal functions after prepending the version and the payload codec ID.
+package undcodec
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/internal/transcode"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+)
+
+// Version is the version of the format written by Marshal functions.
+const Version = 1
+
+const headerLen = 3
+
+var (
+	// ErrFormat is returned by Unmarshal functions when input is not in the expected format,
+	// including a version or a payload codec ID other than expected.
+	ErrFormat = errors.New("invalid format")
+	// ErrUnsupportedType is returned by [Raw] when a value is neither of []byte, string
+	// nor a type implementing encoding.BinaryMarshaler (encoding.BinaryUnmarshaler for decoding).
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// PayloadCodec encodes and decodes values of und types.
+//
+// ID is written into the header so that values encoded by other codecs are detected when decoding.
+// IDs from 0 to 127 are reserved for codecs of this package;
+// other implementations must use IDs from 128 to 255.
+type PayloadCodec interface {
+	ID() byte
+	Marshal(v any) ([]byte, error)
+	// Unmarshal decodes data into v, a non-nil pointer.
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	// JSON encodes values with encoding/json.
+	JSON PayloadCodec = jsonCodec{}
+	// CBOR encodes values as CBOR (RFC 8949) data items transcoded from their JSON representation.
+	CBOR PayloadCodec = cborCodec{}
+	// Raw stores []byte and string as they are, and uses encoding.BinaryMarshaler and encoding.BinaryUnmarshaler for other types.
+	Raw PayloadCodec = rawCodec{}
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) ID() byte                           { return 1 }
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+type cborCodec struct{}
+
+func (cborCodec) ID() byte { return 2 }
+
+func (cborCodec) Marshal(v any) ([]byte, error) {
+	bin, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return transcode.JSONToCBOR(nil, bin)
+}
+
+func (cborCodec) Unmarshal(data []byte, v any) error {
+	bin, err := transcode.CBORToJSON(nil, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bin, v)
+}
+
+type rawCodec struct{}
+
+func (rawCodec) ID() byte { return 3 }
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, nil
+	case string:
+		return []byte(x), nil
+	case encoding.BinaryMarshaler:
+		return x.MarshalBinary()
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	switch x := v.(type) {
+	case *[]byte:
+		*x = append([]byte(nil), data...)
+		return nil
+	case *string:
+		*x = string(data)
+		return nil
+	case encoding.BinaryUnmarshaler:
+		return x.UnmarshalBinary(data)
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+}
+
+// MarshalOption encodes o with c. None is encoded as null.
+func MarshalOption[T any](c PayloadCodec, o option.Option[T]) ([]byte, error) {
+	if o.IsNone() {
+		return header(c, und.StateNull), nil
+	}
+	return appendValue(header(c, und.StateDefined), c, o.Value())
+}
+
+// UnmarshalOption decodes data encoded by [MarshalOption] with c.
+// Undefined is decoded as None.
+func UnmarshalOption[T any](c PayloadCodec, data []byte) (option.Option[T], error) {
+	s, payload, err := readHeader(c, data)
+	if err != nil || s != und.StateDefined {
+		return option.None[T](), err
+	}
+	t, err := unmarshalValue[T](c, payload)
+	if err != nil {
+		return option.None[T](), err
+	}
+	return option.Some(t), nil
+}
+
+// MarshalUnd encodes u with c.
+func MarshalUnd[T any](c PayloadCodec, u und.Und[T]) ([]byte, error) {
+	if !u.IsDefined() {
+		return header(c, u.State()), nil
+	}
+	return appendValue(header(c, und.StateDefined), c, u.Value())
+}
+
+// UnmarshalUnd decodes data encoded by [MarshalUnd] with c.
+func UnmarshalUnd[T any](c PayloadCodec, data []byte) (und.Und[T], error) {
+	s, payload, err := readHeader(c, data)
+	if err != nil {
+		return und.Undefined[T](), err
+	}
+	switch s {
+	case und.StateUndefined:
+		return und.Undefined[T](), nil
+	case und.StateNull:
+		return und.Null[T](), nil
+	}
+	t, err := unmarshalValue[T](c, payload)
+	if err != nil {
+		return und.Undefined[T](), err
+	}
+	return und.Defined(t), nil
+}
+
+// MarshalSliceUnd encodes u with c.
+func MarshalSliceUnd[T any](c PayloadCodec, u sliceund.Und[T]) ([]byte, error) {
+	if !u.IsDefined() {
+		return header(c, u.State()), nil
+	}
+	return appendValue(header(c, und.StateDefined), c, u.Value())
+}
+
+// UnmarshalSliceUnd decodes data encoded by [MarshalSliceUnd] with c.
+func UnmarshalSliceUnd[T any](c PayloadCodec, data []byte) (sliceund.Und[T], error) {
+	u, err := UnmarshalUnd[T](c, data)
+	if err != nil {
+		return sliceund.Undefined[T](), err
+	}
+	switch {
+	case u.IsNull():
+		return sliceund.Null[T](), nil
+	case u.IsDefined():
+		return sliceund.Defined(u.Value()), nil
+	}
+	return sliceund.Undefined[T](), nil
+}
+
+// MarshalElastic encodes e with c.
+func MarshalElastic[T any](c PayloadCodec, e elastic.Elastic[T]) ([]byte, error) {
+	if !e.IsDefined() {
+		return header(c, stateOf(e)), nil
+	}
+	return appendOptions(header(c, und.StateDefined), c, e.Unwrap().Value())
+}
+
+// UnmarshalElastic decodes data encoded by [MarshalElastic] with c.
+func UnmarshalElastic[T any](c PayloadCodec, data []byte) (elastic.Elastic[T], error) {
+	s, payload, err := readHeader(c, data)
+	if err != nil {
+		return elastic.Undefined[T](), err
+	}
+	switch s {
+	case und.StateUndefined:
+		return elastic.Undefined[T](), nil
+	case und.StateNull:
+		return elastic.Null[T](), nil
+	}
+	opts, err := readOptions[T](c, payload)
+	if err != nil {
+		return elastic.Undefined[T](), err
+	}
+	return elastic.FromOptions(opts...), nil
+}
+
+// MarshalSliceElastic encodes e with c.
+func MarshalSliceElastic[T any](c PayloadCodec, e sliceelastic.Elastic[T]) ([]byte, error) {
+	if !e.IsDefined() {
+		return header(c, stateOf(e)), nil
+	}
+	return appendOptions(header(c, und.StateDefined), c, e.Unwrap().Value())
+}
+
+// UnmarshalSliceElastic decodes data encoded by [MarshalSliceElastic] with c.
+func UnmarshalSliceElastic[T any](c PayloadCodec, data []byte) (sliceelastic.Elastic[T], error) {
+	s, payload, err := readHeader(c, data)
+	if err != nil {
+		return sliceelastic.Undefined[T](), err
+	}
+	switch s {
+	case und.StateUndefined:
+		return sliceelastic.Undefined[T](), nil
+	case und.StateNull:
+		return sliceelastic.Null[T](), nil
+	}
+	opts, err := readOptions[T](c, payload)
+	if err != nil {
+		return sliceelastic.Undefined[T](), err
+	}
+	return sliceelastic.FromOptions(opts...), nil
+}
+
+func stateOf(e interface{ IsNull() bool }) und.State {
+	if e.IsNull() {
+		return und.StateNull
+	}
+	return und.StateUndefined
+}
+
+func header(c PayloadCodec, s und.State) []byte {
+	return []byte{Version, c.ID(), byte(s)}
+}
+
+func readHeader(c PayloadCodec, data []byte) (und.State, []byte, error) {
+	if len(data) < headerLen {
+		return 0, nil, fmt.Errorf("%w: input too short", ErrFormat)
+	}
+	if data[0] != Version {
+		return 0, nil, fmt.Errorf("%w: unknown version %d", ErrFormat, data[0])
+	}
+	if data[1] != c.ID() {
+		return 0, nil, fmt.Errorf("%w: payload codec ID %d, expected %d", ErrFormat, data[1], c.ID())
+	}
+	switch s := und.State(data[2]); s {
+	case und.StateUndefined, und.StateNull:
+		if len(data) != headerLen {
+			return 0, nil, fmt.Errorf("%w: trailing bytes after state tag", ErrFormat)
+		}
+		return s, nil, nil
+	case und.StateDefined:
+		return s, data[headerLen:], nil
+	}
+	return 0, nil, fmt.Errorf("%w: unknown state tag %d", ErrFormat, data[2])
+}
+
+func appendValue(dst []byte, c PayloadCodec, v any) ([]byte, error) {
+	bin, err := c.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, bin...), nil
+}
+
+func unmarshalValue[T any](c PayloadCodec, data []byte) (T, error) {
+	var t T
+	err := c.Unmarshal(data, &t)
+	return t, err
+}
+
+func appendOptions[T any](dst []byte, c PayloadCodec, opts option.Options[T]) ([]byte, error) {
+	dst = binary.AppendUvarint(dst, uint64(len(opts)))
+	for _, o := range opts {
+		if o.IsNone() {
+			dst = append(dst, 0)
+			continue
+		}
+		bin, err := c.Marshal(o.Value())
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, 1)
+		dst = binary.AppendUvarint(dst, uint64(len(bin)))
+		dst = append(dst, bin...)
+	}
+	return dst, nil
+}
+
+func readOptions[T any](c PayloadCodec, data []byte) ([]option.Option[T], error) {
+	n, read := binary.Uvarint(data)
+	if read <= 0 {
+		return nil, fmt.Errorf("%w: malformed length", ErrFormat)
+	}
+	data = data[read:]
+	if n > uint64(len(data)) {
+		// each element takes at least 1 byte.
+		return nil, fmt.Errorf("%w: length %d exceeds input", ErrFormat, n)
+	}
+	opts := make([]option.Option[T], n)
+	for i := range opts {
+		if len(data) == 0 {
+			return nil, fmt.Errorf("%w: unexpected end of input", ErrFormat)
+		}
+		tag := data[0]
+		data = data[1:]
+		switch tag {
+		case 0:
+			continue
+		case 1:
+		default:
+			return nil, fmt.Errorf("%w: unknown element tag %d", ErrFormat, tag)
+		}
+		size, read := binary.Uvarint(data)
+		if read <= 0 || size > uint64(len(data)-read) {
+			return nil, fmt.Errorf("%w: malformed element length", ErrFormat)
+		}
+		data = data[read:]
+		t, err := unmarshalValue[T](c, data[:size])
+		if err != nil {
+			return nil, err
+		}
+		opts[i] = option.Some(t)
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("%w: trailing bytes after elements", ErrFormat)
+	}
+	return opts, nil
+}
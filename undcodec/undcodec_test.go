@@ -0,0 +1,167 @@
+package undcodec_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/netip"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"github.com/ngicks/und/undcodec"
+	"gotest.tools/v3/assert"
+)
+
+type value struct {
+	A und.Und[int] `json:"a,omitzero"`
+	B string       `json:"b"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, c := range []undcodec.PayloadCodec{undcodec.JSON, undcodec.CBOR} {
+		for _, u := range []und.Und[value]{
+			und.Undefined[value](),
+			und.Null[value](),
+			und.Defined(value{B: "foo"}),
+			und.Defined(value{A: und.Null[int](), B: "bar"}),
+		} {
+			bin, err := undcodec.MarshalUnd(c, u)
+			assert.NilError(t, err)
+			decoded, err := undcodec.UnmarshalUnd[value](c, bin)
+			assert.NilError(t, err)
+			assert.Equal(t, u.State(), decoded.State())
+			assert.Assert(t, und.Equal(u.Value().A, decoded.Value().A))
+			assert.Equal(t, u.Value().B, decoded.Value().B)
+
+			su := sliceund.FromUnd(u)
+			bin, err = undcodec.MarshalSliceUnd(c, su)
+			assert.NilError(t, err)
+			decodedSu, err := undcodec.UnmarshalSliceUnd[value](c, bin)
+			assert.NilError(t, err)
+			assert.Equal(t, su.State(), decodedSu.State())
+		}
+
+		for _, o := range []option.Option[string]{option.None[string](), option.Some(""), option.Some("foo")} {
+			bin, err := undcodec.MarshalOption(c, o)
+			assert.NilError(t, err)
+			decoded, err := undcodec.UnmarshalOption[string](c, bin)
+			assert.NilError(t, err)
+			assert.Assert(t, option.Equal(o, decoded))
+		}
+
+		for _, e := range []elastic.Elastic[int]{
+			elastic.Undefined[int](),
+			elastic.Null[int](),
+			elastic.FromOptions[int](),
+			elastic.FromOptions(option.Some(1), option.None[int](), option.Some(300)),
+		} {
+			bin, err := undcodec.MarshalElastic(c, e)
+			assert.NilError(t, err)
+			decoded, err := undcodec.UnmarshalElastic[int](c, bin)
+			assert.NilError(t, err)
+			assert.Assert(t, elastic.Equal(e, decoded))
+
+			se := sliceelastic.FromUnd(sliceund.FromUnd(e.Unwrap()))
+			bin, err = undcodec.MarshalSliceElastic(c, se)
+			assert.NilError(t, err)
+			decodedSe, err := undcodec.UnmarshalSliceElastic[int](c, bin)
+			assert.NilError(t, err)
+			assert.Assert(t, sliceelastic.Equal(se, decodedSe))
+		}
+	}
+}
+
+func TestRoundTrip_number(t *testing.T) {
+	i := new(big.Int).Lsh(big.NewInt(1), 70)
+	i.Add(i, big.NewInt(1))
+	for _, c := range []undcodec.PayloadCodec{undcodec.JSON, undcodec.CBOR} {
+		bin, err := undcodec.MarshalOption(c, option.Some(i))
+		assert.NilError(t, err)
+		decoded, err := undcodec.UnmarshalOption[*big.Int](c, bin)
+		assert.NilError(t, err)
+		assert.Assert(t, decoded.Value().Cmp(i) == 0, "%s != %s", decoded.Value(), i)
+
+		for _, n := range []json.Number{"-123456789012345678901234567890", "3.14159265358979323846264338327950288"} {
+			bin, err := undcodec.MarshalUnd(c, und.Defined(n))
+			assert.NilError(t, err)
+			decoded, err := undcodec.UnmarshalUnd[json.Number](c, bin)
+			assert.NilError(t, err)
+			assert.Equal(t, n, decoded.Value())
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	bin, err := undcodec.MarshalUnd(undcodec.JSON, und.Undefined[int]())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{undcodec.Version, 1, byte(und.StateUndefined)}, bin)
+
+	bin, err = undcodec.MarshalUnd(undcodec.Raw, und.Defined("foo"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{undcodec.Version, 3, byte(und.StateDefined), 'f', 'o', 'o'}, bin)
+
+	bin, err = undcodec.MarshalElastic(undcodec.Raw, elastic.FromOptions(option.Some([]byte("a")), option.None[[]byte]()))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{undcodec.Version, 3, byte(und.StateDefined), 2, 1, 1, 'a', 0}, bin)
+
+	addr := netip.MustParseAddr("127.0.0.1")
+	bin, err = undcodec.MarshalOption(undcodec.Raw, option.Some(addr))
+	assert.NilError(t, err)
+	decoded, err := undcodec.UnmarshalOption[netip.Addr](undcodec.Raw, bin)
+	assert.NilError(t, err)
+	assert.Equal(t, addr, decoded.Value())
+
+	_, err = undcodec.MarshalUnd(undcodec.Raw, und.Defined(1))
+	assert.ErrorIs(t, err, undcodec.ErrUnsupportedType)
+
+	for _, data := range [][]byte{
+		nil,
+		{2, 1, byte(und.StateNull)},
+		{undcodec.Version, 2, byte(und.StateNull)},
+		{undcodec.Version, 1, 0},
+		{undcodec.Version, 1, byte(und.StateNull), 0},
+	} {
+		_, err := undcodec.UnmarshalUnd[int](undcodec.JSON, data)
+		assert.ErrorIs(t, err, undcodec.ErrFormat, "data = %v", data)
+	}
+	for _, data := range [][]byte{
+		{undcodec.Version, 3, byte(und.StateDefined)},
+		{undcodec.Version, 3, byte(und.StateDefined), 5, 0},
+		{undcodec.Version, 3, byte(und.StateDefined), 1, 2},
+		{undcodec.Version, 3, byte(und.StateDefined), 1, 1, 2, 'a'},
+		{undcodec.Version, 3, byte(und.StateDefined), 1, 0, 0},
+	} {
+		_, err := undcodec.UnmarshalElastic[string](undcodec.Raw, data)
+		assert.ErrorIs(t, err, undcodec.ErrFormat, "data = %v", data)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	assertSame := func(t *testing.T, c undcodec.PayloadCodec, bin []byte, err error, m interface{ MarshalBinary() ([]byte, error) }) {
+		t.Helper()
+		assert.NilError(t, err)
+		expected, err := m.MarshalBinary()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, append([]byte{undcodec.Version, c.ID()}, expected...), bin)
+	}
+
+	for _, u := range []und.Und[value]{und.Undefined[value](), und.Null[value](), und.Defined(value{B: "foo"})} {
+		bin, err := undcodec.MarshalUnd(undcodec.CBOR, u)
+		assertSame(t, undcodec.CBOR, bin, err, u)
+
+		s := sliceund.FromUnd(u)
+		bin, err = undcodec.MarshalSliceUnd(undcodec.CBOR, s)
+		assertSame(t, undcodec.CBOR, bin, err, s)
+	}
+	for _, o := range []option.Option[value]{option.None[value](), option.Some(value{B: "foo"})} {
+		bin, err := undcodec.MarshalOption(undcodec.CBOR, o)
+		assertSame(t, undcodec.CBOR, bin, err, o)
+	}
+
+	addr := und.Defined(netip.MustParseAddr("127.0.0.1"))
+	bin, err := undcodec.MarshalUnd(undcodec.Raw, addr)
+	assertSame(t, undcodec.Raw, bin, err, addr)
+}
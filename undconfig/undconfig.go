@@ -0,0 +1,77 @@
+// Package undconfig populates und typed fields from configuration maps,
+// as decoded by configuration libraries like github.com/spf13/viper and github.com/knadh/koanf.
+//
+// Keys absent from a configuration map leave fields undefined,
+// keys set to nil make fields null, and other keys make fields defined.
+//
+// Integrating with the configuration libraries only needs their decode hook signatures,
+// so this package does not depend on them.
+package undconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ngicks/und/internal/undreflect"
+)
+
+// DecodeHook is a decode hook for github.com/go-viper/mapstructure/v2 and github.com/mitchellh/mapstructure,
+// which is the decoder behind viper's Unmarshal and koanf's UnmarshalWithConf.
+// It has the signature of mapstructure.DecodeHookFuncValue, so it can be passed wherever a mapstructure.DecodeHookFunc is accepted,
+// e.g. viper.DecodeHook(undconfig.DecodeHook) or mapstructure.ComposeDecodeHookFunc(undconfig.DecodeHook, ...).
+//
+// DecodeHook converts from into to if to is und.Und[T], sliceund.Und[T], option.Option[T] or an elastic type,
+// or more precisely, a type which implements und.UndStater, validate.UndLike or validate.OptionLike, and json.Unmarshaler via its pointer.
+// Otherwise from is returned as it is.
+//
+// mapstructure does not call hooks for absent keys, so fields for those stay undefined.
+// nil becomes null (none for option.Option[T]).
+// Note that mapstructure skips nil values as well as absent keys unless DecoderConfig.DecodeNil
+// (github.com/go-viper/mapstructure/v2 v2.3.0 or later) is true.
+// Other values are converted through JSON:
+// they are encoded by json.Marshal, then decoded by UnmarshalJSON of to.
+// As an exception, strings are used as JSON values as they are if the value type of to is not a string
+// and they are valid JSON, e.g. "8080" for und.Und[int], since environment variables and flags are loaded as strings.
+func DecodeHook(from, to reflect.Value) (any, error) {
+	if !from.IsValid() {
+		if !to.IsValid() || !undreflect.IsUndType(to.Type()) {
+			return nil, nil
+		}
+		return decode(to.Type(), []byte(`null`))
+	}
+	if !to.IsValid() || !undreflect.IsUndType(to.Type()) || from.Type() == to.Type() {
+		return from.Interface(), nil
+	}
+
+	for from.Kind() == reflect.Interface || from.Kind() == reflect.Pointer {
+		if from.IsNil() {
+			return decode(to.Type(), []byte(`null`))
+		}
+		from = from.Elem()
+	}
+
+	if from.Kind() == reflect.String {
+		s := from.String()
+		if rt := undreflect.ValueType(to.Type()); rt != nil &&
+			rt.Kind() != reflect.String &&
+			!reflect.PointerTo(rt).Implements(undreflect.TextUnmarshalerTy) &&
+			json.Valid([]byte(s)) {
+			return decode(to.Type(), []byte(s))
+		}
+	}
+
+	data, err := json.Marshal(from.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("undconfig: encoding %s for %s: %w", from.Type(), to.Type(), err)
+	}
+	return decode(to.Type(), data)
+}
+
+func decode(rt reflect.Type, data []byte) (any, error) {
+	nv := reflect.New(rt)
+	if err := nv.Interface().(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("undconfig: decoding %s: %w", rt, err)
+	}
+	return nv.Elem().Interface(), nil
+}
@@ -0,0 +1,64 @@
+package undconfig_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/undconfig"
+	"gotest.tools/v3/assert"
+)
+
+// decodeHookFuncValue is same as mapstructure.DecodeHookFuncValue.
+type decodeHookFuncValue func(from reflect.Value, to reflect.Value) (any, error)
+
+var _ decodeHookFuncValue = undconfig.DecodeHook
+
+type server struct {
+	Host string
+	Port int
+}
+
+func hook[T any](t *testing.T, from any) T {
+	t.Helper()
+	var to T
+	v, err := undconfig.DecodeHook(reflect.ValueOf(from), reflect.ValueOf(&to).Elem())
+	assert.NilError(t, err)
+	return v.(T)
+}
+
+func TestDecodeHook(t *testing.T) {
+	assert.Assert(t, und.Equal(hook[und.Und[int]](t, 8080), und.Defined(8080)))
+	assert.Assert(t, und.Equal(hook[und.Und[int]](t, "8080"), und.Defined(8080)))
+	assert.Assert(t, und.Equal(hook[und.Und[string]](t, "8080"), und.Defined("8080")))
+	assert.Assert(t, und.Equal(hook[und.Und[string]](t, `"quoted"`), und.Defined(`"quoted"`)))
+	assert.Assert(t, hook[und.Und[int]](t, nil).IsNull())
+	assert.Assert(t, hook[sliceund.Und[bool]](t, "true").Value())
+	assert.Equal(t, time.Second, hook[option.Option[time.Duration]](t, int64(time.Second)).Value())
+	assert.Assert(t, hook[option.Option[time.Duration]](t, (*int)(nil)).IsNone())
+	assert.DeepEqual(
+		t,
+		server{Host: "localhost", Port: 8080},
+		hook[und.Und[server]](t, map[string]any{"host": "localhost", "port": 8080}).Value(),
+	)
+	assert.DeepEqual(t, []string{"a", "b"}, hook[elastic.Elastic[string]](t, []any{"a", "b"}).Values())
+	assert.DeepEqual(t, []string{"a"}, hook[elastic.Elastic[string]](t, "a").Values())
+
+	// non und types are passed through.
+	v, err := undconfig.DecodeHook(reflect.ValueOf("8080"), reflect.ValueOf(new(int)).Elem())
+	assert.NilError(t, err)
+	assert.Equal(t, "8080", v)
+
+	// same types are passed through.
+	u := und.Defined(5)
+	v, err = undconfig.DecodeHook(reflect.ValueOf(u), reflect.ValueOf(new(und.Und[int])).Elem())
+	assert.NilError(t, err)
+	assert.Assert(t, und.Equal(u, v.(und.Und[int])))
+
+	_, err = undconfig.DecodeHook(reflect.ValueOf("foo"), reflect.ValueOf(new(und.Und[int])).Elem())
+	assert.ErrorContains(t, err, "undconfig: decoding")
+}
@@ -0,0 +1,166 @@
+// Package undenv populates und typed fields of structs from environment variables.
+//
+// Environment variables have 3 states that map directly onto und types:
+// an unset variable is undefined, a variable set to an empty string is null,
+// and a variable set to a non-empty string is defined.
+package undenv
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+// TagName is the struct tag key which specifies the name of the environment variable of a field.
+const TagName = "env"
+
+var (
+	// ErrUnsupportedType is returned by Load if a field tagged with `env` is not an und type.
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+var durationTy = reflect.TypeFor[time.Duration]()
+
+// Loader loads environment variables into structs.
+// The zero value is ready to use and reads the process environment.
+type Loader struct {
+	// LookupEnv looks up an environment variable.
+	// If nil, os.LookupEnv is used.
+	LookupEnv func(key string) (string, bool)
+	// EmptyAsDefined makes variables set to an empty string defined instead of null.
+	// An empty value must then be parsable as the value type of the field, e.g. string.
+	EmptyAsDefined bool
+}
+
+// Load populates fields of the struct pointed by v with the zero [Loader].
+func Load(v any) error {
+	return Loader{}.Load(v)
+}
+
+// Load populates fields of the struct pointed by v from environment variables.
+//
+// Fields tagged with `env:"NAME"` are populated from the variable NAME.
+// Those fields must be und.Und[T], sliceund.Und[T], option.Option[T] or elastic types,
+// or more precisely, types which implement und.UndStater, validate.UndLike or validate.OptionLike, and json.Unmarshaler via their pointer.
+// Fields without the tag are ignored, except for nested structs and non-nil pointers to structs, which are populated recursively.
+// Fields tagged with `env:"-"` are ignored, nested structs included.
+//
+// For unset variables fields are left untouched, so that undefined fields stay undefined.
+// For variables set to an empty string fields become null (none for option.Option[T]),
+// unless l.EmptyAsDefined is true.
+// Other values are parsed into the value type of the field, e.g. T of und.Und[T]:
+// strings are used as they are, bool, numeric types and time.Duration are parsed with strconv or time.ParseDuration,
+// types implementing encoding.TextUnmarshaler are parsed with it, and any other types are decoded as JSON.
+// Elastic types also accept a JSON array of values.
+func (l Loader) Load(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: input must be a non-nil pointer to a struct but is %T", validate.ErrNotStruct, v)
+	}
+	return l.load(rv.Elem())
+}
+
+func (l Loader) lookupEnv(key string) (string, bool) {
+	if l.LookupEnv != nil {
+		return l.LookupEnv(key)
+	}
+	return os.LookupEnv(key)
+}
+
+func (l Loader) load(rv reflect.Value) error {
+	return undreflect.WalkTagged(rv, TagName, func(ft reflect.StructField, fv reflect.Value, name string) error {
+		if !undreflect.IsUndType(ft.Type) {
+			return fmt.Errorf("%w: %s", ErrUnsupportedType, ft.Type)
+		}
+
+		env, ok := l.lookupEnv(name)
+		if !ok {
+			return nil
+		}
+
+		var data []byte
+		switch {
+		case env == "" && !l.EmptyAsDefined:
+			data = []byte(`null`)
+		case ft.Type.Implements(undreflect.ElasticLikeTy) && len(env) > 0 && env[0] == '[' && json.Valid([]byte(env)):
+			data = []byte(env)
+		default:
+			var err error
+			data, err = parse(undreflect.ValueType(ft.Type), env)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", name, err)
+			}
+		}
+
+		nv := reflect.New(ft.Type)
+		if err := nv.Interface().(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+			return fmt.Errorf("parsing %s: %w", name, err)
+		}
+		fv.Set(nv.Elem())
+		return nil
+	})
+}
+
+// parse parses env into a value of rt then encodes it as JSON.
+func parse(rt reflect.Type, env string) ([]byte, error) {
+	if rt == nil {
+		return []byte(env), nil
+	}
+	if rt == durationTy {
+		d, err := time.ParseDuration(env)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(d)
+	}
+	if reflect.PointerTo(rt).Implements(undreflect.TextUnmarshalerTy) {
+		v := reflect.New(rt)
+		if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(env)); err != nil {
+			return nil, err
+		}
+		return json.Marshal(v.Interface())
+	}
+	v := reflect.New(rt).Elem()
+	switch rt.Kind() {
+	case reflect.String:
+		v.SetString(env)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(env)
+		if err != nil {
+			return nil, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(env, 0, rt.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(env, 0, rt.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(env, rt.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetFloat(f)
+	default:
+		if !json.Valid([]byte(env)) {
+			return nil, fmt.Errorf("invalid JSON for %s: %q", rt, env)
+		}
+		return []byte(env), nil
+	}
+	return json.Marshal(v.Interface())
+}
@@ -0,0 +1,110 @@
+package undenv_test
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/undenv"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+type nested struct {
+	Level und.Und[string] `env:"LOG_LEVEL"`
+}
+
+type config struct {
+	Host     und.Und[string]              `env:"HOST"`
+	Port     und.Und[int]                 `env:"PORT"`
+	Debug    sliceund.Und[bool]           `env:"DEBUG"`
+	Timeout  option.Option[time.Duration] `env:"TIMEOUT"`
+	Addr     und.Und[netip.Addr]          `env:"ADDR"`
+	Tags     elastic.Elastic[string]      `env:"TAGS"`
+	Unset    und.Und[int]                 `env:"UNSET"`
+	Empty    und.Und[string]              `env:"EMPTY"`
+	Kept     und.Und[int]                 `env:"KEPT"`
+	Untagged und.Und[int]
+	Nested   nested
+	NestedP  *nested
+	Ignored  nested `env:"-"`
+}
+
+func lookupEnv(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestLoad(t *testing.T) {
+	env := map[string]string{
+		"HOST":      "localhost",
+		"PORT":      "0x1f90",
+		"DEBUG":     "true",
+		"TIMEOUT":   "1m30s",
+		"ADDR":      "127.0.0.1",
+		"TAGS":      `["a",null]`,
+		"EMPTY":     "",
+		"LOG_LEVEL": "info",
+	}
+	c := config{
+		Kept:    und.Defined(5),
+		NestedP: &nested{},
+	}
+	assert.NilError(t, undenv.Loader{LookupEnv: lookupEnv(env)}.Load(&c))
+
+	assert.Assert(t, und.Equal(c.Host, und.Defined("localhost")))
+	assert.Assert(t, und.Equal(c.Port, und.Defined(8080)))
+	assert.Assert(t, sliceund.Equal(c.Debug, sliceund.Defined(true)))
+	assert.Assert(t, option.Equal(c.Timeout, option.Some(90*time.Second)))
+	assert.Assert(t, und.Equal(c.Addr, und.Defined(netip.MustParseAddr("127.0.0.1"))))
+	assert.Assert(t, elastic.Equal(c.Tags, elastic.FromOptions(option.Some("a"), option.None[string]())))
+	assert.Assert(t, c.Unset.IsUndefined())
+	assert.Assert(t, c.Empty.IsNull())
+	assert.Assert(t, und.Equal(c.Kept, und.Defined(5)))
+	assert.Assert(t, c.Untagged.IsUndefined())
+	assert.Assert(t, und.Equal(c.Nested.Level, und.Defined("info")))
+	assert.Assert(t, und.Equal(c.NestedP.Level, und.Defined("info")))
+	assert.Assert(t, c.Ignored.Level.IsUndefined())
+
+	var emptyAsDefined config
+	assert.NilError(t, undenv.Loader{LookupEnv: lookupEnv(env), EmptyAsDefined: true}.Load(&emptyAsDefined))
+	assert.Assert(t, und.Equal(emptyAsDefined.Empty, und.Defined("")))
+}
+
+func TestLoad_process_env(t *testing.T) {
+	t.Setenv("UNDENV_TEST_VALUE", "12")
+	var s struct {
+		V und.Und[int] `env:"UNDENV_TEST_VALUE"`
+	}
+	assert.NilError(t, undenv.Load(&s))
+	assert.Assert(t, und.Equal(s.V, und.Defined(12)))
+}
+
+func TestLoad_error(t *testing.T) {
+	env := lookupEnv(map[string]string{"V": "foo"})
+
+	assert.ErrorIs(t, undenv.Load(config{}), validate.ErrNotStruct)
+
+	var notUnd struct {
+		V int `env:"V"`
+	}
+	assert.ErrorIs(t, undenv.Loader{LookupEnv: env}.Load(&notUnd), undenv.ErrUnsupportedType)
+
+	var notInt struct {
+		V und.Und[int] `env:"V"`
+	}
+	assert.ErrorContains(t, undenv.Loader{LookupEnv: env}.Load(&notInt), "V")
+
+	var nestedErr struct {
+		N struct {
+			V und.Und[int] `env:"V"`
+		}
+	}
+	assert.ErrorContains(t, undenv.Loader{LookupEnv: env}.Load(&nestedErr), "N.V")
+}
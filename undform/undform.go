@@ -0,0 +1,247 @@
+// Package undform encodes structs with und typed fields into url.Values and decodes them back,
+// for query strings and form posts.
+//
+// Keys of url.Values have 3 states that map directly onto und types:
+// an absent key is undefined, a key with an empty value is null,
+// and a key with a non-empty value is defined.
+package undform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+// TagName is the struct tag key which specifies the key of a field in url.Values.
+const TagName = "form"
+
+var (
+	// ErrUnsupportedType is returned by Marshal and Unmarshal if a field tagged with `form` is not an und type.
+	ErrUnsupportedType = errors.New("unsupported type")
+	// ErrAmbiguousValue is returned by Marshal if a field would be encoded into form values
+	// which the [Decoder] with the same setting decodes into another state,
+	// e.g. und.Defined("") which is encoded as an empty value, that is, null.
+	ErrAmbiguousValue = errors.New("ambiguous value")
+)
+
+// Encoder encodes structs into url.Values.
+// The zero value is ready to use.
+type Encoder struct {
+	// OmitNull omits null fields (none for option.Option[T]) as well as undefined ones.
+	// Otherwise null fields are encoded as keys with an empty value.
+	OmitNull bool
+	// EmptyAsDefined allows defined values to be encoded as empty values, e.g. und.Defined(""),
+	// for the Decoder with EmptyAsDefined set.
+	// Null fields and null elements of elastic types are then ambiguous and result in an error,
+	// unless null fields are omitted by OmitNull.
+	EmptyAsDefined bool
+}
+
+// Marshal encodes the struct v with the zero [Encoder].
+func Marshal(v any) (url.Values, error) {
+	return Encoder{}.Marshal(v)
+}
+
+// Marshal encodes fields of the struct v, or the struct pointed by v, into url.Values.
+//
+// Fields tagged with `form:"key"` are encoded under key.
+// Those fields must be und.Und[T], sliceund.Und[T], option.Option[T] or elastic types,
+// or more precisely, types which implement und.UndStater, validate.UndLike or validate.OptionLike, and json.Unmarshaler via their pointer.
+// Fields without the tag are ignored, except for nested structs and non-nil pointers to structs, which are encoded recursively
+// into the same url.Values.
+// Fields tagged with `form:"-"` are ignored, nested structs included.
+//
+// Undefined fields are omitted. Null fields are encoded as keys with an empty value unless e.OmitNull is true.
+// Defined values are encoded as JSON, then strings are unquoted and other values are used as they are,
+// e.g. "foo" for "foo", 12 for 12 and {"a":1} for a struct.
+// Each value of elastic types is added under the key; null values become empty values.
+//
+// Marshal returns an error wrapping [ErrAmbiguousValue] if the result would not be decoded back into the same state
+// by the [Decoder] whose EmptyAsDefined is same as e.EmptyAsDefined:
+// defined values encoded as empty values unless e.EmptyAsDefined is true,
+// null fields and null elements if e.EmptyAsDefined is true,
+// defined elastic types without elements, and elastic types with a single element encoded as an empty value.
+func (e Encoder) Marshal(v any) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: input must be a struct or a non-nil pointer to a struct but is %T", validate.ErrNotStruct, v)
+	}
+	values := url.Values{}
+	if err := e.encode(values, rv); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (e Encoder) encode(values url.Values, rv reflect.Value) error {
+	return undreflect.WalkTagged(rv, TagName, func(ft reflect.StructField, fv reflect.Value, key string) error {
+		if !undreflect.IsUndType(ft.Type) {
+			return fmt.Errorf("%w: %s", ErrUnsupportedType, ft.Type)
+		}
+
+		if s, _ := undreflect.StateOf(fv.Interface()); s == und.StateUndefined {
+			return nil
+		}
+
+		data, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(data, []byte(`null`)) {
+			switch {
+			case e.OmitNull:
+			case e.EmptyAsDefined:
+				return fmt.Errorf("%w: null is not distinguishable from an empty value", ErrAmbiguousValue)
+			default:
+				values.Add(key, "")
+			}
+			return nil
+		}
+
+		if ft.Type.Implements(undreflect.ElasticLikeTy) {
+			var elems []json.RawMessage
+			if err := json.Unmarshal(data, &elems); err != nil {
+				return err
+			}
+			switch {
+			case len(elems) == 0:
+				return fmt.Errorf("%w: no elements is not distinguishable from undefined", ErrAmbiguousValue)
+			case len(elems) == 1 && !e.EmptyAsDefined && bytes.Equal(elems[0], []byte(`null`)):
+				return fmt.Errorf("%w: a single null element is not distinguishable from null", ErrAmbiguousValue)
+			}
+			for _, elem := range elems {
+				s, err := e.formValue(elem)
+				if err != nil {
+					return err
+				}
+				values.Add(key, s)
+			}
+			return nil
+		}
+
+		s, err := e.formValue(data)
+		if err != nil {
+			return err
+		}
+		values.Add(key, s)
+		return nil
+	})
+}
+
+// formValue converts a JSON value into a form value.
+// It fails if the value is ambiguous under e.EmptyAsDefined.
+func (e Encoder) formValue(data []byte) (string, error) {
+	switch {
+	case bytes.Equal(data, []byte(`null`)):
+		if e.EmptyAsDefined {
+			return "", fmt.Errorf("%w: null element is not distinguishable from an empty value", ErrAmbiguousValue)
+		}
+		return "", nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		if s == "" && !e.EmptyAsDefined {
+			return "", fmt.Errorf("%w: empty value is decoded as null", ErrAmbiguousValue)
+		}
+		return s, nil
+	}
+	return string(data), nil
+}
+
+// Decoder decodes url.Values into structs.
+// The zero value is ready to use.
+type Decoder struct {
+	// EmptyAsDefined makes keys with an empty value defined instead of null.
+	// An empty value must then be parsable as the value type of the field, e.g. string.
+	EmptyAsDefined bool
+}
+
+// Unmarshal decodes values into the struct pointed by v with the zero [Decoder].
+func Unmarshal(values url.Values, v any) error {
+	return Decoder{}.Unmarshal(values, v)
+}
+
+// Unmarshal decodes values into fields of the struct pointed by v.
+//
+// Fields are selected in the same manner as [Encoder.Marshal].
+//
+// For absent keys fields are left untouched, so that undefined fields stay undefined.
+// For keys with an empty value fields become null (none for option.Option[T]),
+// unless d.EmptyAsDefined is true.
+// Other values are decoded into the value type of the field, e.g. T of und.Und[T]:
+// values are used as JSON strings for string types and types implementing encoding.TextUnmarshaler,
+// and as JSON values for any other types, or as JSON strings if they are not valid JSON.
+// Only the first value of the key is used except for elastic types,
+// which take all values as elements; empty values become null elements.
+// A single empty value of elastic types makes the field null.
+func (d Decoder) Unmarshal(values url.Values, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: input must be a non-nil pointer to a struct but is %T", validate.ErrNotStruct, v)
+	}
+	return d.decode(values, rv.Elem())
+}
+
+func (d Decoder) decode(values url.Values, rv reflect.Value) error {
+	return undreflect.WalkTagged(rv, TagName, func(ft reflect.StructField, fv reflect.Value, key string) error {
+		if !undreflect.IsUndType(ft.Type) {
+			return fmt.Errorf("%w: %s", ErrUnsupportedType, ft.Type)
+		}
+
+		vs, ok := values[key]
+		if !ok || len(vs) == 0 {
+			return nil
+		}
+
+		var data []byte
+		switch {
+		case len(vs) == 1 && vs[0] == "" && !d.EmptyAsDefined:
+			data = []byte(`null`)
+		case ft.Type.Implements(undreflect.ElasticLikeTy):
+			elems := make([]json.RawMessage, len(vs))
+			for j, s := range vs {
+				elems[j] = d.parse(undreflect.ValueType(ft.Type), s)
+			}
+			var err error
+			data, err = json.Marshal(elems)
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", key, err)
+			}
+		default:
+			data = d.parse(undreflect.ValueType(ft.Type), vs[0])
+		}
+
+		nv := reflect.New(ft.Type)
+		if err := nv.Interface().(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+			return fmt.Errorf("parsing %s: %w", key, err)
+		}
+		fv.Set(nv.Elem())
+		return nil
+	})
+}
+
+// parse converts s, a form value, into a JSON value to be decoded into rt.
+func (d Decoder) parse(rt reflect.Type, s string) []byte {
+	if s == "" && !d.EmptyAsDefined {
+		return []byte(`null`)
+	}
+	if rt != nil && (rt.Kind() == reflect.String || reflect.PointerTo(rt).Implements(undreflect.TextUnmarshalerTy)) ||
+		!json.Valid([]byte(s)) {
+		data, _ := json.Marshal(s)
+		return data
+	}
+	return []byte(s)
+}
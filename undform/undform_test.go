@@ -0,0 +1,154 @@
+package undform_test
+
+import (
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/undform"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+type nested struct {
+	Sort und.Und[string] `form:"sort"`
+}
+
+type query struct {
+	Name     und.Und[string]              `form:"name"`
+	Limit    und.Und[int]                 `form:"limit"`
+	Active   sliceund.Und[bool]           `form:"active"`
+	Since    option.Option[time.Time]     `form:"since"`
+	Addr     und.Und[netip.Addr]          `form:"addr"`
+	Tags     elastic.Elastic[string]      `form:"tag"`
+	Filter   und.Und[map[string]int]      `form:"filter"`
+	Null     und.Und[string]              `form:"null"`
+	Unset    und.Und[int]                 `form:"unset"`
+	Timeout  option.Option[time.Duration] `form:"timeout"`
+	Untagged und.Und[int]
+	Nested   nested
+}
+
+func TestMarshal(t *testing.T) {
+	q := query{
+		Name:    und.Defined("foo bar"),
+		Limit:   und.Defined(10),
+		Active:  sliceund.Defined(true),
+		Since:   option.Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Addr:    und.Defined(netip.MustParseAddr("127.0.0.1")),
+		Tags:    elastic.FromOptions(option.Some("a"), option.None[string](), option.Some("12")),
+		Filter:  und.Defined(map[string]int{"a": 1}),
+		Null:    und.Null[string](),
+		Timeout: option.Some(time.Second),
+		Nested:  nested{Sort: und.Defined("asc")},
+	}
+
+	values, err := undform.Marshal(q)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, url.Values{
+		"name":    {"foo bar"},
+		"limit":   {"10"},
+		"active":  {"true"},
+		"since":   {"2024-01-02T03:04:05Z"},
+		"addr":    {"127.0.0.1"},
+		"tag":     {"a", "", "12"},
+		"filter":  {`{"a":1}`},
+		"null":    {""},
+		"timeout": {"1000000000"},
+		"sort":    {"asc"},
+	}, values)
+
+	var decoded query
+	assert.NilError(t, undform.Unmarshal(values, &decoded))
+	assert.Assert(t, und.Equal(q.Name, decoded.Name))
+	assert.Assert(t, q.Since.Value().Equal(decoded.Since.Value()))
+	assert.Assert(t, elastic.Equal(q.Tags, decoded.Tags))
+	assert.Assert(t, decoded.Null.IsNull())
+	assert.Assert(t, decoded.Unset.IsUndefined())
+	reencoded, err := undform.Marshal(decoded)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values, reencoded)
+
+	values, err = undform.Encoder{OmitNull: true}.Marshal(&q)
+	assert.NilError(t, err)
+	_, ok := values["null"]
+	assert.Assert(t, !ok)
+	assert.DeepEqual(t, []string{"a", "", "12"}, values["tag"])
+
+	_, err = undform.Marshal(1)
+	assert.ErrorIs(t, err, validate.ErrNotStruct)
+	_, err = undform.Marshal(struct {
+		Foo int `form:"foo"`
+	}{})
+	assert.ErrorIs(t, err, undform.ErrUnsupportedType)
+}
+
+func TestUnmarshal(t *testing.T) {
+	values, err := url.ParseQuery("name=foo&limit=10&active=&tag=a&tag=&null=&sort=asc&filter=bar")
+	assert.NilError(t, err)
+	delete(values, "filter")
+
+	q := query{Unset: und.Defined(5)}
+	assert.NilError(t, undform.Unmarshal(values, &q))
+	assert.Assert(t, und.Equal(q.Name, und.Defined("foo")))
+	assert.Assert(t, und.Equal(q.Limit, und.Defined(10)))
+	assert.Assert(t, q.Active.IsNull())
+	assert.Assert(t, q.Since.IsNone())
+	assert.Assert(t, option.EqualOptions(option.Options[string]{option.Some("a"), option.None[string]()}, q.Tags.Unwrap().Value()))
+	assert.Assert(t, q.Null.IsNull())
+	assert.Assert(t, und.Equal(q.Unset, und.Defined(5)))
+	assert.Assert(t, q.Untagged.IsUndefined())
+	assert.Assert(t, und.Equal(q.Nested.Sort, und.Defined("asc")))
+
+	q = query{}
+	assert.NilError(t, undform.Decoder{EmptyAsDefined: true}.Unmarshal(url.Values{"name": {""}, "tag": {""}}, &q))
+	assert.Assert(t, und.Equal(q.Name, und.Defined("")))
+	assert.Assert(t, option.EqualOptions(option.Options[string]{option.Some("")}, q.Tags.Unwrap().Value()))
+
+	err = undform.Unmarshal(url.Values{"limit": {"ten"}}, &q)
+	assert.ErrorContains(t, err, "Limit: parsing limit")
+	err = undform.Unmarshal(url.Values{}, q)
+	assert.ErrorIs(t, err, validate.ErrNotStruct)
+}
+
+func TestMarshal_ambiguous(t *testing.T) {
+	type empty struct {
+		Name und.Und[string]         `form:"name"`
+		Tags elastic.Elastic[string] `form:"tag"`
+	}
+
+	for _, v := range []empty{
+		{Name: und.Defined("")},
+		{Tags: elastic.FromValues("a", "")},
+		{Tags: elastic.FromOptions[string]()},
+		{Tags: elastic.FromOptions(option.None[string]())},
+	} {
+		_, err := undform.Marshal(v)
+		assert.ErrorIs(t, err, undform.ErrAmbiguousValue)
+	}
+
+	v := empty{Name: und.Defined(""), Tags: elastic.FromValues("")}
+	values, err := undform.Encoder{EmptyAsDefined: true}.Marshal(v)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, url.Values{"name": {""}, "tag": {""}}, values)
+	var decoded empty
+	assert.NilError(t, undform.Decoder{EmptyAsDefined: true}.Unmarshal(values, &decoded))
+	assert.Assert(t, und.Equal(v.Name, decoded.Name))
+	assert.Assert(t, elastic.Equal(v.Tags, decoded.Tags))
+
+	for _, v := range []empty{
+		{Name: und.Null[string]()},
+		{Tags: elastic.FromOptions(option.Some("a"), option.None[string]())},
+	} {
+		_, err := undform.Encoder{EmptyAsDefined: true}.Marshal(v)
+		assert.ErrorIs(t, err, undform.ErrAmbiguousValue)
+	}
+	values, err = undform.Encoder{EmptyAsDefined: true, OmitNull: true}.Marshal(empty{Name: und.Null[string]()})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, url.Values{}, values)
+}
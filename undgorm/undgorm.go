@@ -0,0 +1,105 @@
+// Package undgorm builds partial update maps for GORM from structs of und typed fields.
+//
+// GORM's Updates skips zero values of struct fields, which makes it impossible to set a column to NULL or zero
+// from a struct, and maps must be assembled by hand instead.
+// With und types a struct already tells which fields are to be updated (defined), set to NULL (null) or left as they are (undefined).
+// [UpdateMap] converts such a struct into the map[string]any that Updates expects.
+//
+// undgorm does not depend on GORM; maps it returns can be passed to any query builder that accepts column-value maps.
+package undgorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+var (
+	// ErrUnsupportedType is returned by UpdateMap if an exported field is of an elastic type,
+	// which can not be stored in a single column.
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// UpdateMap converts the struct v, or the struct pointed by v, into a column-value map for GORM's Updates.
+//
+// Only exported fields of und.Und[T], sliceund.Und[T] and option.Option[T] are considered,
+// including those promoted from embedded structs; other fields are ignored.
+// Defined (or some) fields are mapped to their values, null (or none) fields to nil, which GORM writes as NULL,
+// and undefined fields are skipped.
+// Fields of pointers to those types are considered as well; nil pointers are treated as undefined.
+//
+// Keys are column names given by `gorm:"column:name"` tag options, or field names otherwise,
+// which GORM resolves into column names through the model's schema.
+// Fields tagged with `gorm:"-"` are skipped.
+// Third party types implementing und.UndStater are mapped to what AnyValue returns.
+// Fields of elastic types, and of other types without the Value method, cause an error wrapping [ErrUnsupportedType].
+func UpdateMap(v any) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: input must be a struct or a non-nil pointer to a struct but is %T", validate.ErrNotStruct, v)
+	}
+
+	m := make(map[string]any)
+	for _, ft := range reflect.VisibleFields(rv.Type()) {
+		if !ft.IsExported() || ft.Anonymous {
+			continue
+		}
+		column, skip := columnName(ft)
+		if skip {
+			continue
+		}
+		if ft.Type.Implements(undreflect.ElasticLikeTy) {
+			return nil, fmt.Errorf("%s: %w: %s", ft.Name, ErrUnsupportedType, ft.Type)
+		}
+		if !undreflect.HasState(ft.Type) {
+			continue
+		}
+		if !ft.Type.Implements(undreflect.UndStaterTy) && undreflect.ValueType(ft.Type) == nil {
+			return nil, fmt.Errorf("%s: %w: %s has no Value method", ft.Name, ErrUnsupportedType, ft.Type)
+		}
+		fv, err := rv.FieldByIndexErr(ft.Index)
+		if err != nil {
+			// nil embedded pointer
+			continue
+		}
+		switch s, _ := undreflect.StateOf(fv.Interface()); s {
+		case und.StateDefined:
+			m[column] = valueOf(fv)
+		case und.StateNull:
+			m[column] = nil
+		}
+	}
+	return m, nil
+}
+
+// valueOf returns the value of the defined fv,
+// taken from AnyValue if fv implements und.UndStater, or from the Value method otherwise.
+func valueOf(fv reflect.Value) any {
+	if s, ok := fv.Interface().(und.UndStater); ok {
+		return s.AnyValue()
+	}
+	return fv.MethodByName("Value").Call(nil)[0].Interface()
+}
+
+// columnName returns the column name of ft from its gorm tag, falling back to the field name.
+func columnName(ft reflect.StructField) (name string, skip bool) {
+	tag := ft.Tag.Get("gorm")
+	if tag == "-" {
+		return "", true
+	}
+	for _, opt := range strings.Split(tag, ";") {
+		k, v, ok := strings.Cut(opt, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(k), "column") {
+			return strings.TrimSpace(v), false
+		}
+	}
+	return ft.Name, false
+}
@@ -0,0 +1,95 @@
+package undgorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/undgorm"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+type Timestamps struct {
+	UpdatedAt und.Und[time.Time]
+}
+
+type userPatch struct {
+	Name     und.Und[string]
+	Nickname und.Und[string] `gorm:"column:nick_name"`
+	Age      sliceund.Und[int]
+	Email    option.Option[string] `gorm:"type:text; column: mail ;not null"`
+	Ignored  und.Und[int]          `gorm:"-"`
+	Plain    string
+	Timestamps
+	private und.Und[int]
+}
+
+// stater is a third party type which implements und.UndStater but has no Value method.
+type stater struct {
+	state und.State
+	v     string
+}
+
+func (s stater) State() und.State { return s.state }
+func (s stater) AnyValue() any    { return s.v }
+
+// undLike implements validate.UndLike only.
+type undLike struct{}
+
+func (undLike) IsDefined() bool   { return true }
+func (undLike) IsNull() bool      { return false }
+func (undLike) IsUndefined() bool { return false }
+
+func TestUpdateMap(t *testing.T) {
+	now := time.Now()
+	m, err := undgorm.UpdateMap(userPatch{
+		Name:       und.Defined("foo"),
+		Nickname:   und.Null[string](),
+		Email:      option.None[string](),
+		Ignored:    und.Defined(1),
+		Plain:      "plain",
+		Timestamps: Timestamps{UpdatedAt: und.Defined(now)},
+		private:    und.Defined(1),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]any{
+		"Name":      "foo",
+		"nick_name": nil,
+		"mail":      nil,
+		"UpdatedAt": now,
+	}, m)
+
+	m, err = undgorm.UpdateMap(&userPatch{Age: sliceund.Defined(0), Email: option.Some("foo@example.com")})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]any{"Age": 0, "mail": "foo@example.com"}, m)
+
+	q := und.Defined(1)
+	m, err = undgorm.UpdateMap(struct {
+		P *und.Und[int]
+		Q *und.Und[int]
+		R *option.Option[int]
+	}{Q: &q})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]any{"Q": 1}, m)
+
+	m, err = undgorm.UpdateMap(struct {
+		D stater
+		N stater
+		U stater
+	}{D: stater{und.StateDefined, "foo"}, N: stater{state: und.StateNull}, U: stater{state: und.StateUndefined}})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]any{"D": "foo", "N": nil}, m)
+
+	_, err = undgorm.UpdateMap(struct{ U undLike }{})
+	assert.ErrorIs(t, err, undgorm.ErrUnsupportedType)
+	_, err = undgorm.UpdateMap(struct{ E elastic.Elastic[int] }{})
+	assert.ErrorIs(t, err, undgorm.ErrUnsupportedType)
+	_, err = undgorm.UpdateMap(1)
+	assert.ErrorIs(t, err, validate.ErrNotStruct)
+	_, err = undgorm.UpdateMap((*userPatch)(nil))
+	assert.ErrorIs(t, err, validate.ErrNotStruct)
+}
@@ -0,0 +1,201 @@
+// Package undhttp binds JSON request bodies into structs of und typed fields and validates them.
+//
+// Decoding a request body, validating it against `und` struct tags and mapping failures to HTTP responses
+// is what every handler taking und typed payloads does.
+// [Bind] does all of them and reports failures as [*BindError], which carries a status code
+// and the JSON pointer to the offending field, e.g. for 422 Unprocessable Entity responses.
+package undhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+// BindError is returned by [Binder.Bind] when a request can not be bound.
+type BindError struct {
+	// Status is an HTTP status code suitable for the response:
+	// http.StatusUnsupportedMediaType for non-JSON content types,
+	// http.StatusRequestEntityTooLarge for bodies exceeding the limit,
+	// http.StatusBadRequest for malformed bodies,
+	// and http.StatusUnprocessableEntity for well-formed bodies failing validation.
+	Status int
+	// Pointer is the RFC 6901 JSON pointer to the offending field, e.g. "/items/0/name".
+	// It is empty if the failure is not specific to a field.
+	Pointer string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *BindError) Error() string {
+	if e.Pointer != "" {
+		return fmt.Sprintf("%s at %s: %v", http.StatusText(e.Status), e.Pointer, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", http.StatusText(e.Status), e.Err)
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// Binder binds JSON request bodies.
+// The zero value is ready to use.
+type Binder struct {
+	// MaxBytes limits the size of request bodies. Zero or negative values mean no limit.
+	MaxBytes int64
+	// DisallowUnknownFields rejects bodies having fields which do not match any field of the destination.
+	DisallowUnknownFields bool
+}
+
+// Bind binds the body of r into v with the zero [Binder].
+func Bind(r *http.Request, v any) error {
+	return Binder{}.Bind(r, v)
+}
+
+// Bind decodes the JSON body of r into v, then validates v by validate.UndValidate.
+//
+// v must be a non-nil pointer to a struct.
+// The Content-Type of r must be application/json or a type suffixed with +json if it is set.
+// The body must be a single JSON value.
+// Any failure is returned as a [*BindError].
+func (b Binder) Bind(r *http.Request, v any) error {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return &BindError{Status: http.StatusUnsupportedMediaType, Err: fmt.Errorf("content type %q is not JSON", ct)}
+		}
+	}
+
+	body := r.Body
+	if body == nil {
+		body = http.NoBody
+	}
+	if b.MaxBytes > 0 {
+		body = http.MaxBytesReader(nil, body, b.MaxBytes)
+	}
+	dec := json.NewDecoder(body)
+	if b.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return decodeError(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("trailing data after JSON value")
+		}
+		return decodeError(err)
+	}
+
+	if err := validate.UndValidate(v); err != nil {
+		if errors.Is(err, validate.ErrNotStruct) {
+			return err
+		}
+		bindErr := &BindError{Status: http.StatusUnprocessableEntity, Err: err}
+		var vErr *validate.ValidationError
+		if errors.As(err, &vErr) {
+			bindErr.Pointer = jsonPointer(reflect.TypeOf(v), vErr.Pointer())
+		}
+		return bindErr
+	}
+	return nil
+}
+
+func decodeError(err error) error {
+	var (
+		maxBytesErr *http.MaxBytesError
+		typeErr     *json.UnmarshalTypeError
+		invalidErr  *json.InvalidUnmarshalError
+	)
+	switch {
+	case errors.As(err, &invalidErr):
+		// programming error, not a bad request.
+		return err
+	case errors.As(err, &maxBytesErr):
+		return &BindError{Status: http.StatusRequestEntityTooLarge, Err: err}
+	case errors.Is(err, io.EOF):
+		return &BindError{Status: http.StatusBadRequest, Err: errors.New("empty body")}
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		var sb strings.Builder
+		for _, seg := range strings.Split(typeErr.Field, ".") {
+			sb.WriteByte('/')
+			sb.WriteString(pointerEscaper.Replace(seg))
+		}
+		return &BindError{Status: http.StatusBadRequest, Pointer: sb.String(), Err: err}
+	}
+	return &BindError{Status: http.StatusBadRequest, Err: err}
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// jsonPointer translates ptr, a JSON pointer into rt built from Go field names as validate reports,
+// into one built from JSON field names.
+// Segments that can not be resolved are kept as they are.
+func jsonPointer(rt reflect.Type, ptr string) string {
+	if ptr == "" {
+		return ptr
+	}
+	segs := strings.Split(ptr[1:], "/")
+	for i := 0; i < len(segs) && rt != nil; i++ {
+		var isElastic bool
+		rt, isElastic = derefType(rt)
+		if isElastic {
+			if _, err := strconv.Atoi(segs[i]); err == nil {
+				continue
+			}
+		}
+		switch rt.Kind() {
+		case reflect.Struct:
+			f, ok := rt.FieldByName(pointerUnescaper.Replace(segs[i]))
+			if !ok {
+				return "/" + strings.Join(segs, "/")
+			}
+			segs[i] = pointerEscaper.Replace(jsonName(f))
+			rt = f.Type
+		case reflect.Slice, reflect.Array, reflect.Map:
+			rt = rt.Elem()
+		default:
+			rt = nil
+		}
+	}
+	return "/" + strings.Join(segs, "/")
+}
+
+// derefType dereferences pointer types and unwraps und types into their value types.
+// isElastic reports whether the last unwrapped type is an elastic type, whose elements are indexed.
+func derefType(rt reflect.Type) (_ reflect.Type, isElastic bool) {
+	for {
+		switch {
+		case rt.Kind() == reflect.Pointer:
+			rt = rt.Elem()
+			continue
+		case undreflect.HasState(rt):
+			if vt := undreflect.ValueType(rt); vt != nil {
+				isElastic = rt.Implements(undreflect.ElasticLikeTy)
+				rt = vt
+				continue
+			}
+		}
+		return rt, isElastic
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
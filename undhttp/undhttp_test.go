@@ -0,0 +1,82 @@
+package undhttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/undhttp"
+	"github.com/ngicks/und/validate"
+	"gotest.tools/v3/assert"
+)
+
+type item struct {
+	Name und.Und[string] `json:"item_name" und:"required"`
+}
+
+type payload struct {
+	Title    und.Und[string]         `json:"title" und:"def"`
+	Count    und.Und[int]            `json:"count,omitzero"`
+	Priority int                     `json:"priority"`
+	Items    elastic.Elastic[string] `json:"items"`
+	Inner    item                    `json:"inner"`
+}
+
+func newRequest(body, contentType string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func TestBind(t *testing.T) {
+	var p payload
+	err := undhttp.Bind(newRequest(`{"title":"foo","count":null,"inner":{"item_name":"bar"}}`, "application/json; charset=utf-8"), &p)
+	assert.NilError(t, err)
+	assert.Assert(t, und.Equal(p.Title, und.Defined("foo")))
+	assert.Assert(t, p.Count.IsNull())
+	assert.Assert(t, p.Items.IsUndefined())
+
+	p = payload{}
+	assert.NilError(t, undhttp.Bind(newRequest(`{"title":"foo","inner":{"item_name":"bar"}}`, ""), &p))
+	assert.Assert(t, p.Count.IsUndefined())
+
+	for _, tc := range []struct {
+		name    string
+		binder  undhttp.Binder
+		body    string
+		ct      string
+		status  int
+		pointer string
+	}{
+		{"content type", undhttp.Binder{}, `{"title":"foo"}`, "text/plain", http.StatusUnsupportedMediaType, ""},
+		{"too large", undhttp.Binder{MaxBytes: 4}, `{"title":"foo"}`, "", http.StatusRequestEntityTooLarge, ""},
+		{"empty", undhttp.Binder{}, ``, "", http.StatusBadRequest, ""},
+		{"syntax", undhttp.Binder{}, `{"title":`, "", http.StatusBadRequest, ""},
+		{"trailing", undhttp.Binder{}, `{"title":"foo"} {}`, "", http.StatusBadRequest, ""},
+		{"unknown field", undhttp.Binder{DisallowUnknownFields: true}, `{"title":"foo","bar":1}`, "", http.StatusBadRequest, ""},
+		{"type", undhttp.Binder{}, `{"title":"foo","priority":"high"}`, "", http.StatusBadRequest, "/priority"},
+		{"undefined", undhttp.Binder{}, `{}`, "application/problem+json", http.StatusUnprocessableEntity, "/title"},
+		{"nested", undhttp.Binder{}, `{"title":"foo","inner":{}}`, "", http.StatusUnprocessableEntity, "/inner/item_name"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var p payload
+			err := tc.binder.Bind(newRequest(tc.body, tc.ct), &p)
+			var bindErr *undhttp.BindError
+			assert.Assert(t, errors.As(err, &bindErr), "err = %v", err)
+			assert.Equal(t, tc.status, bindErr.Status, "err = %v", err)
+			assert.Equal(t, tc.pointer, bindErr.Pointer, "err = %v", err)
+			assert.Assert(t, strings.HasPrefix(err.Error(), http.StatusText(tc.status)), "err = %v", err)
+		})
+	}
+
+	err = undhttp.Bind(newRequest(`1`, ""), new(int))
+	assert.ErrorIs(t, err, validate.ErrNotStruct)
+	var bindErr *undhttp.BindError
+	assert.Assert(t, !errors.As(err, &bindErr))
+}
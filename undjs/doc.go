@@ -0,0 +1,8 @@
+// Package undjs converts values between js.Value and types defined in this module for Go programs compiled to js/wasm.
+//
+// Undefined values correspond to JavaScript undefined and null values to JavaScript null, so tri-state values
+// can be exchanged with JavaScript objects losslessly.
+// Defined values of Elastic types correspond to JavaScript arrays whose null elements are None.
+//
+// Converters are only available under the js/wasm build target.
+package undjs
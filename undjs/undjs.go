@@ -0,0 +1,141 @@
+//go:build js && wasm
+
+package undjs
+
+import (
+	"syscall/js"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+)
+
+// ValueOf converts t into js.Value by [js.ValueOf].
+// It can be passed to From* functions as a conversion function for T types js.ValueOf supports.
+//
+// ValueOf panics if t is not one of types listed in js.ValueOf's doc.
+func ValueOf[T any](t T) js.Value {
+	return js.ValueOf(t)
+}
+
+// ToOption converts v into option.Option[T].
+// It returns None if v is undefined or null, otherwise Some with the value converted by conv.
+//
+// conv may panic as js.Value methods do if v is not a type conv expects.
+func ToOption[T any](v js.Value, conv func(js.Value) T) option.Option[T] {
+	if v.IsUndefined() || v.IsNull() {
+		return option.None[T]()
+	}
+	return option.Some(conv(v))
+}
+
+// FromOption converts o into js.Value.
+// It returns js null if o is None, otherwise the value converted by conv.
+func FromOption[T any](o option.Option[T], conv func(T) js.Value) js.Value {
+	if o.IsNone() {
+		return js.Null()
+	}
+	return conv(o.Value())
+}
+
+// ToUnd converts v into und.Und[T].
+// JavaScript undefined becomes undefined, null becomes null,
+// and other values become defined with the value converted by conv.
+//
+// conv may panic as js.Value methods do if v is not a type conv expects.
+func ToUnd[T any](v js.Value, conv func(js.Value) T) und.Und[T] {
+	switch {
+	case v.IsUndefined():
+		return und.Undefined[T]()
+	case v.IsNull():
+		return und.Null[T]()
+	default:
+		return und.Defined(conv(v))
+	}
+}
+
+// FromUnd converts u into js.Value.
+// It returns js undefined if u is undefined, js null if u is null,
+// otherwise the value converted by conv.
+func FromUnd[T any](u und.Und[T], conv func(T) js.Value) js.Value {
+	switch {
+	case u.IsUndefined():
+		return js.Undefined()
+	case u.IsNull():
+		return js.Null()
+	default:
+		return conv(u.Value())
+	}
+}
+
+// ToSliceUnd is like [ToUnd] but converts v into sliceund.Und[T].
+func ToSliceUnd[T any](v js.Value, conv func(js.Value) T) sliceund.Und[T] {
+	return sliceund.FromUnd(ToUnd(v, conv))
+}
+
+// FromSliceUnd is like [FromUnd] but converts sliceund.Und[T].
+func FromSliceUnd[T any](u sliceund.Und[T], conv func(T) js.Value) js.Value {
+	return FromUnd(u.Und(), conv)
+}
+
+// ToElastic converts v into elastic.Elastic[T].
+// JavaScript undefined becomes undefined and null becomes null.
+// If v is an array, each element becomes an element of the Elastic: None for undefined or null, otherwise Some.
+// Other values become a defined Elastic that has the single value.
+//
+// conv may panic as js.Value methods do if v is not a type conv expects.
+func ToElastic[T any](v js.Value, conv func(js.Value) T) elastic.Elastic[T] {
+	return elastic.FromUnd(toOptions(v, conv))
+}
+
+// FromElastic converts e into js.Value.
+// It returns js undefined if e is undefined, js null if e is null,
+// otherwise a JavaScript array whose elements are null for None and values converted by conv for Some.
+func FromElastic[T any](e elastic.Elastic[T], conv func(T) js.Value) js.Value {
+	return fromOptions(e.Unwrap(), conv)
+}
+
+// ToSliceElastic is like [ToElastic] but converts v into sliceund/elastic.Elastic[T].
+func ToSliceElastic[T any](v js.Value, conv func(js.Value) T) sliceelastic.Elastic[T] {
+	return sliceelastic.FromUnd(sliceund.FromUnd(toOptions(v, conv)))
+}
+
+// FromSliceElastic is like [FromElastic] but converts sliceund/elastic.Elastic[T].
+func FromSliceElastic[T any](e sliceelastic.Elastic[T], conv func(T) js.Value) js.Value {
+	return fromOptions(e.Unwrap().Und(), conv)
+}
+
+var array = js.Global().Get("Array")
+
+func toOptions[T any](v js.Value, conv func(js.Value) T) und.Und[option.Options[T]] {
+	switch {
+	case v.IsUndefined():
+		return und.Undefined[option.Options[T]]()
+	case v.IsNull():
+		return und.Null[option.Options[T]]()
+	case !v.InstanceOf(array):
+		return und.Defined(option.Options[T]{option.Some(conv(v))})
+	}
+	opts := make(option.Options[T], v.Length())
+	for i := range opts {
+		opts[i] = ToOption(v.Index(i), conv)
+	}
+	return und.Defined(opts)
+}
+
+func fromOptions[T any](u und.Und[option.Options[T]], conv func(T) js.Value) js.Value {
+	switch {
+	case u.IsUndefined():
+		return js.Undefined()
+	case u.IsNull():
+		return js.Null()
+	}
+	opts := u.Value()
+	arr := array.New(len(opts))
+	for i, o := range opts {
+		arr.SetIndex(i, FromOption(o, conv))
+	}
+	return arr
+}
@@ -0,0 +1,71 @@
+//go:build js && wasm
+
+package undjs
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnd(t *testing.T) {
+	for _, tc := range []struct {
+		v js.Value
+		u und.Und[string]
+	}{
+		{js.Undefined(), und.Undefined[string]()},
+		{js.Null(), und.Null[string]()},
+		{js.ValueOf("foo"), und.Defined("foo")},
+	} {
+		assert.Assert(t, und.Equal(ToUnd(tc.v, js.Value.String), tc.u))
+		assert.Assert(t, sliceund.Equal(ToSliceUnd(tc.v, js.Value.String), sliceund.FromUnd(tc.u)))
+		assert.Assert(t, FromUnd(tc.u, ValueOf).Equal(tc.v))
+		assert.Assert(t, FromSliceUnd(sliceund.FromUnd(tc.u), ValueOf).Equal(tc.v))
+	}
+
+	assert.Assert(t, ToOption(js.Undefined(), js.Value.Int).IsNone())
+	assert.Assert(t, ToOption(js.Null(), js.Value.Int).IsNone())
+	assert.Equal(t, ToOption(js.ValueOf(5), js.Value.Int), option.Some(5))
+	assert.Assert(t, FromOption(option.None[int](), ValueOf).IsNull())
+	assert.Equal(t, FromOption(option.Some(5), ValueOf).Int(), 5)
+}
+
+func TestElastic(t *testing.T) {
+	arr := js.ValueOf([]any{nil, "foo", js.Undefined(), "bar"})
+	opts := []option.Option[string]{option.None[string](), option.Some("foo"), option.None[string](), option.Some("bar")}
+
+	for _, tc := range []struct {
+		v js.Value
+		e elastic.Elastic[string]
+	}{
+		{js.Undefined(), elastic.Undefined[string]()},
+		{js.Null(), elastic.Null[string]()},
+		{js.ValueOf("foo"), elastic.FromValue("foo")},
+		{arr, elastic.FromOptions(opts...)},
+	} {
+		assert.Assert(t, elastic.Equal(ToElastic(tc.v, js.Value.String), tc.e))
+		assert.Assert(t, sliceelastic.Equal(ToSliceElastic(tc.v, js.Value.String), sliceelastic.FromUnd(sliceund.FromUnd(tc.e.Unwrap()))))
+	}
+
+	assert.Assert(t, FromElastic(elastic.Undefined[string](), ValueOf).IsUndefined())
+	assert.Assert(t, FromElastic(elastic.Null[string](), ValueOf).IsNull())
+	assert.Assert(t, FromSliceElastic(sliceelastic.Undefined[string](), ValueOf).IsUndefined())
+	assert.Assert(t, FromSliceElastic(sliceelastic.Null[string](), ValueOf).IsNull())
+
+	for _, v := range []js.Value{
+		FromElastic(elastic.FromOptions(opts...), ValueOf),
+		FromSliceElastic(sliceelastic.FromOptions(opts...), ValueOf),
+	} {
+		assert.Equal(t, v.Length(), 4)
+		assert.Assert(t, v.Index(0).IsNull())
+		assert.Equal(t, v.Index(1).String(), "foo")
+		assert.Assert(t, v.Index(2).IsNull())
+		assert.Equal(t, v.Index(3).String(), "bar")
+	}
+}
@@ -0,0 +1,156 @@
+// Package undndjson reads and writes NDJSON (JSON Lines) streams of structs with und typed fields.
+//
+// Records are encoded and decoded by encoding/json, the same way as json.Marshal and json.Unmarshal do,
+// so undefined fields are skipped when they are tagged with `json:",omitzero"`
+// (or `json:",omitempty"` for sliceund types), and absent fields are decoded as undefined.
+package undndjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/ngicks/und/validate"
+)
+
+// LineError is an error occurred while decoding or validating a line.
+type LineError struct {
+	// Line is the 1-based line number.
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads records from an NDJSON stream.
+type Reader[T any] struct {
+	// DisallowUnknownFields makes lines with fields not in T an error.
+	DisallowUnknownFields bool
+	// Validate validates each record by validate.UndValidate.
+	// T must be a struct or a pointer to a struct if Validate is true.
+	Validate bool
+
+	r    *bufio.Reader
+	line int
+	err  error
+}
+
+// NewReader returns a new Reader reading from r.
+func NewReader[T any](r io.Reader) *Reader[T] {
+	return &Reader[T]{r: bufio.NewReader(r)}
+}
+
+// Line returns the number of the line last read.
+func (r *Reader[T]) Line() int {
+	return r.line
+}
+
+// Read reads a next record.
+// Empty lines and lines consisting only of white spaces are skipped.
+// Lines can be terminated by either of "\n" or "\r\n", and the last line may lack a terminator.
+//
+// If the line is not a valid JSON value of T, or it fails validation,
+// Read returns an error of type *LineError. Reading can be continued from the next line after that.
+// At the end of the stream Read returns io.EOF.
+// Other errors are ones returned by the underlying io.Reader, after which Read keeps returning the same error.
+// A partial line read before such an error is discarded without being decoded.
+func (r *Reader[T]) Read() (T, error) {
+	var zero T
+	for {
+		if r.err != nil {
+			return zero, r.err
+		}
+		line, err := r.r.ReadBytes('\n')
+		if err != nil {
+			r.err = err
+			// A fragment read before an error other than io.EOF may be truncated.
+			if err != io.EOF || len(line) == 0 {
+				continue
+			}
+		}
+		r.line++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		v, err := r.decode(line)
+		if err != nil {
+			return zero, &LineError{Line: r.line, Err: err}
+		}
+		return v, nil
+	}
+}
+
+func (r *Reader[T]) decode(line []byte) (T, error) {
+	var v T
+	dec := json.NewDecoder(bytes.NewReader(line))
+	if r.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(&v); err != nil {
+		return v, err
+	}
+	if dec.More() {
+		return v, errors.New("multiple JSON values in a line")
+	}
+	if r.Validate {
+		if err := validate.UndValidate(v); err != nil {
+			return v, err
+		}
+	}
+	return v, nil
+}
+
+// All returns an iterator over records read from r.
+// Errors of type *LineError are yielded and iteration continues from the next line.
+// Other errors except for io.EOF are yielded then iteration stops.
+func (r *Reader[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			v, err := r.Read()
+			if err == io.EOF {
+				return
+			}
+			if !yield(v, err) {
+				return
+			}
+			var lineErr *LineError
+			if err != nil && !errors.As(err, &lineErr) {
+				return
+			}
+		}
+	}
+}
+
+// Writer writes records to an NDJSON stream.
+// Writes are buffered; Flush must be called after the last Write.
+type Writer[T any] struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// NewWriter returns a new Writer writing to w.
+func NewWriter[T any](w io.Writer) *Writer[T] {
+	bw := bufio.NewWriter(w)
+	return &Writer[T]{w: bw, enc: json.NewEncoder(bw)}
+}
+
+// Write writes v as a line.
+func (w *Writer[T]) Write(v T) error {
+	return w.enc.Encode(v)
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *Writer[T]) Flush() error {
+	return w.w.Flush()
+}
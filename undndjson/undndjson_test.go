@@ -0,0 +1,101 @@
+package undndjson_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+	"github.com/ngicks/und/undndjson"
+	"gotest.tools/v3/assert"
+)
+
+type record struct {
+	ID    int                          `json:"id"`
+	Name  sliceund.Und[string]         `json:"name,omitempty" und:"def"`
+	Tags  sliceelastic.Elastic[string] `json:"tags,omitempty"`
+	Score sliceund.Und[float64]        `json:"score,omitempty"`
+}
+
+func TestReader(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":1,"name":"foo","tags":["a",null]}`,
+		``,
+		`{"id":2,"name":"bar","score":null}`,
+		`{"id":`,
+		`   `,
+		`{"id":4,"name":"baz"} {"id":5}`,
+		`{"id":6,"name":"qux","unknown":1}`,
+		"{\"id\":7}\r",
+		`{"id":8,"name":"quux"}`,
+	}, "\n")
+
+	r := undndjson.NewReader[record](strings.NewReader(input))
+	r.DisallowUnknownFields = true
+	r.Validate = true
+
+	var (
+		ids       []int
+		errLines  []int
+		lastScore sliceund.Und[float64]
+	)
+	for v, err := range r.All() {
+		if err != nil {
+			var lineErr *undndjson.LineError
+			assert.Assert(t, errors.As(err, &lineErr))
+			assert.Assert(t, strings.HasPrefix(err.Error(), "line "))
+			errLines = append(errLines, lineErr.Line)
+			continue
+		}
+		ids = append(ids, v.ID)
+		if v.ID == 2 {
+			lastScore = v.Score
+		}
+	}
+	assert.DeepEqual(t, []int{1, 2, 8}, ids)
+	assert.DeepEqual(t, []int{4, 6, 7, 8}, errLines)
+	assert.Assert(t, lastScore.IsNull())
+	assert.Equal(t, 9, r.Line())
+
+	_, err := r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReader_error(t *testing.T) {
+	// The first read returns everything, and the second one fails in the middle of the last line.
+	r := undndjson.NewReader[record](iotest.TimeoutReader(strings.NewReader("{\"id\":1}\n{\"id\":")))
+	v, err := r.Read()
+	assert.NilError(t, err)
+	assert.Equal(t, 1, v.ID)
+	for range 2 {
+		_, err = r.Read()
+		assert.Equal(t, iotest.ErrTimeout, err)
+	}
+	assert.Equal(t, 1, r.Line())
+}
+
+func TestWriter(t *testing.T) {
+	var sb strings.Builder
+	w := undndjson.NewWriter[record](&sb)
+	assert.NilError(t, w.Write(record{ID: 1, Name: sliceund.Defined("foo"), Tags: sliceelastic.FromValues("a")}))
+	assert.NilError(t, w.Write(record{ID: 2, Score: sliceund.Null[float64]()}))
+	assert.NilError(t, w.Write(record{ID: 3}))
+	assert.Equal(t, "", sb.String())
+	assert.NilError(t, w.Flush())
+	assert.Equal(
+		t,
+		`{"id":1,"name":"foo","tags":["a"]}`+"\n"+
+			`{"id":2,"score":null}`+"\n"+
+			`{"id":3}`+"\n",
+		sb.String(),
+	)
+
+	r := undndjson.NewReader[record](strings.NewReader(sb.String()))
+	v, err := r.Read()
+	assert.NilError(t, err)
+	assert.Assert(t, und.Equal(v.Name.Und(), und.Defined("foo")))
+}
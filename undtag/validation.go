@@ -84,6 +84,17 @@ const (
 	// 	Foo string `und:"values:nonnull"`
 	// }
 	UndTagValueValues = "values"
+	// The default value of the field, formatted as default=value.
+	// It does not constrain states of the field; und.ApplyDefaults fills fields that are not defined with it.
+	//
+	// The value is everything after "default=", including commas,
+	// thus it must be the last option.
+	//
+	// example:
+	// type Sample struct {
+	// 	Foo string `und:"def,und,default=foo, bar"`
+	// }
+	UndTagValueDefault = "default"
 )
 
 var (
@@ -122,9 +133,10 @@ type OptionLike interface {
 }
 
 type UndOptExport struct {
-	States *StateValidator
-	Len    *LenValidator
-	Values *ValuesValidator
+	States  *StateValidator
+	Len     *LenValidator
+	Values  *ValuesValidator
+	Default *string
 }
 
 func (o UndOptExport) Into() UndOpt {
@@ -134,6 +146,7 @@ func (o UndOptExport) Into() UndOpt {
 		states: option.FromPointer(o.States),
 		len:    option.FromPointer(o.Len),
 		values: option.FromPointer(o.Values),
+		def:    option.FromPointer(o.Default),
 	}
 }
 
@@ -143,6 +156,7 @@ type UndOpt struct {
 	states option.Option[StateValidator]
 	len    option.Option[LenValidator]
 	values option.Option[ValuesValidator]
+	def    option.Option[string]
 }
 
 func ParseOption(s string) (UndOpt, error) {
@@ -156,6 +170,10 @@ func ParseOption(s string) (UndOpt, error) {
 		opts        UndOpt
 	)
 	for len(s) > 0 {
+		if def, ok := strings.CutPrefix(s, UndTagValueDefault+"="); ok {
+			opts.def = option.Some(def)
+			break
+		}
 		opt, s, _ = strings.Cut(s, ",")
 		if strings.HasPrefix(opt, UndTagValueLen) {
 			if opts.len.IsSome() {
@@ -236,6 +254,11 @@ func (u UndOpt) Values() option.Option[ValuesValidator] {
 	return u.values
 }
 
+// Default returns the default value specified by default option.
+func (u UndOpt) Default() option.Option[string] {
+	return u.def
+}
+
 func (o UndOpt) Describe() string {
 	var builder strings.Builder
 
@@ -261,6 +284,10 @@ func (o UndOpt) Describe() string {
 }
 
 func (o UndOpt) ValidOpt(opt OptionLike) bool {
+	if o.states.IsNone() && o.def.IsSome() {
+		// default option alone does not constrain states.
+		return true
+	}
 	return o.states.IsSomeAnd(func(s StateValidator) bool {
 		switch {
 		case opt.IsSome():
@@ -272,6 +299,10 @@ func (o UndOpt) ValidOpt(opt OptionLike) bool {
 }
 
 func (o UndOpt) ValidUnd(u UndLike) bool {
+	if o.states.IsNone() && o.def.IsSome() {
+		// default option alone does not constrain states.
+		return true
+	}
 	return o.states.IsSomeAnd(func(s StateValidator) bool {
 		switch {
 		case u.IsDefined():
@@ -0,0 +1,92 @@
+// Package undtemplate provides template functions for und types, for text/template and html/template.
+//
+// Methods of und types are callable from templates as they are,
+// e.g. {{ if .Field.IsDefined }}{{ .Field.Value }}{{ end }}.
+// Functions in this package additionally work on any of und types uniformly
+// and fall back to a default without a wrapper method on every struct,
+// e.g. {{ .Field | deref "n/a" }}.
+package undtemplate
+
+import (
+	"reflect"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/internal/undreflect"
+	"github.com/ngicks/und/validate"
+)
+
+// FuncMap returns functions for templates.
+// The returned map can be passed to Funcs method of both text/template and html/template.
+//
+//   - deref: [Deref]
+//   - isDefined: [IsDefined]
+//   - isNull: [IsNull]
+//   - isUndefined: [IsUndefined]
+func FuncMap() map[string]any {
+	return map[string]any{
+		"deref":       Deref,
+		"isDefined":   IsDefined,
+		"isNull":      IsNull,
+		"isUndefined": IsUndefined,
+	}
+}
+
+// Deref returns the value of v if v is defined, def otherwise.
+// The default comes first so that Deref can be used at the end of pipelines,
+// e.g. {{ .Field | deref "n/a" }}.
+//
+// For und.Und[T], sliceund.Und[T] and option.Option[T], the value is T returned from Value method.
+// For elastic types, the value is []T returned from Values method.
+// For pointers, the value is the pointee, or def if nil.
+// nil returns def and other values are returned as they are.
+func Deref(def, v any) any {
+	if v == nil {
+		return def
+	}
+	rv := reflect.ValueOf(v)
+	switch state(v) {
+	case und.StateUndefined, und.StateNull:
+		return def
+	case und.StateDefined:
+		name := "Value"
+		if _, ok := v.(validate.ElasticLike); ok {
+			name = "Values"
+		}
+		m := rv.MethodByName(name)
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			return v
+		}
+		return m.Call(nil)[0].Interface()
+	}
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return def
+		}
+		return rv.Elem().Interface()
+	}
+	return v
+}
+
+// IsDefined reports whether v is defined, or some for option.Option[T].
+// Values of other types, including nil pointers, are never defined.
+func IsDefined(v any) bool {
+	return state(v) == und.StateDefined
+}
+
+// IsNull reports whether v is null, or none for option.Option[T].
+// It reports false for values of other types and nil pointers.
+func IsNull(v any) bool {
+	return state(v) == und.StateNull
+}
+
+// IsUndefined reports whether v is undefined.
+// It reports false for values of other types and nil pointers.
+func IsUndefined(v any) bool {
+	return state(v) == und.StateUndefined
+}
+
+// state returns the state of v, or 0 if v is not an und type or is a nil pointer.
+func state(v any) und.State {
+	s, _ := undreflect.StateOf(v)
+	return s
+}
@@ -0,0 +1,72 @@
+package undtemplate_test
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	"github.com/ngicks/und/undtemplate"
+	"gotest.tools/v3/assert"
+)
+
+type model struct {
+	Name   und.Und[string]
+	Nick   sliceund.Und[string]
+	Age    option.Option[int]
+	Tags   elastic.Elastic[string]
+	Note   *string
+	Plain  string
+	Absent und.Und[string]
+}
+
+func TestFuncMap(t *testing.T) {
+	note := "note"
+	m := model{
+		Name:  und.Defined("foo"),
+		Nick:  sliceund.Null[string](),
+		Age:   option.Some(20),
+		Tags:  elastic.FromValues("a", "b"),
+		Note:  &note,
+		Plain: "plain",
+	}
+
+	const text = `{{ .Name | deref "n/a" }},{{ .Nick | deref "n/a" }},{{ .Age | deref 0 }},` +
+		`{{ .Tags | deref nil }},{{ .Note | deref "" }},{{ .Plain | deref "" }},{{ .Absent | deref "n/a" }},` +
+		`{{ isDefined .Name }},{{ isNull .Nick }},{{ isUndefined .Absent }},{{ isDefined .Plain }},` +
+		`{{ if .Name.IsDefined }}{{ .Name.Value }}{{ end }}`
+	const expected = `foo,n/a,20,[a b],note,plain,n/a,true,true,true,false,foo`
+
+	var sb strings.Builder
+	tmpl := template.Must(template.New("").Funcs(undtemplate.FuncMap()).Parse(text))
+	assert.NilError(t, tmpl.Execute(&sb, m))
+	assert.Equal(t, expected, sb.String())
+
+	sb.Reset()
+	htmlTmpl := htmltemplate.Must(htmltemplate.New("").Funcs(undtemplate.FuncMap()).Parse(text))
+	assert.NilError(t, htmlTmpl.Execute(&sb, m))
+	assert.Equal(t, expected, sb.String())
+
+	m.Note = nil
+	assert.Equal(t, "default", undtemplate.Deref("default", m.Note))
+	assert.Equal(t, "default", undtemplate.Deref("default", nil))
+	assert.Assert(t, undtemplate.IsNull(option.None[int]()))
+	assert.Assert(t, !undtemplate.IsUndefined(option.None[int]()))
+}
+
+func TestNilPointer(t *testing.T) {
+	for _, v := range []any{(*und.Und[int])(nil), (*option.Option[int])(nil), (*elastic.Elastic[int])(nil)} {
+		assert.Assert(t, !undtemplate.IsDefined(v), "%T", v)
+		assert.Assert(t, !undtemplate.IsNull(v), "%T", v)
+		assert.Assert(t, !undtemplate.IsUndefined(v), "%T", v)
+		assert.Equal(t, "default", undtemplate.Deref("default", v), "%T", v)
+	}
+
+	u := und.Defined(1)
+	assert.Assert(t, undtemplate.IsDefined(&u))
+	assert.Equal(t, 1, undtemplate.Deref(0, &u))
+}
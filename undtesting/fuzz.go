@@ -0,0 +1,81 @@
+package undtesting
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+)
+
+// States lists all states in the order [UndFromFuzz] maps them.
+var States = [3]und.State{und.StateUndefined, und.StateNull, und.StateDefined}
+
+// OptionFromFuzz converts fuzzing inputs into an option.Option[T].
+// The option is some if some is true.
+func OptionFromFuzz[T any](some bool, v T) option.Option[T] {
+	return option.FromOk(v, some)
+}
+
+// UndFromFuzz converts fuzzing inputs into an und.Und[T].
+// state is mapped to a state by state % 3, as an index of [States].
+func UndFromFuzz[T any](state uint8, v T) und.Und[T] {
+	switch States[state%3] {
+	case und.StateDefined:
+		return und.Defined(v)
+	case und.StateNull:
+		return und.Null[T]()
+	default:
+		return und.Undefined[T]()
+	}
+}
+
+// AddOptionCorpus adds seed corpus entries to f for each of values, both some and none,
+// so that the fuzz target function is of form func(t *testing.T, some bool, v T)
+// which can be passed to [OptionFromFuzz].
+//
+// T must be a type f.Add accepts.
+func AddOptionCorpus[T any](f *testing.F, values ...T) {
+	f.Helper()
+	for _, v := range values {
+		f.Add(false, v)
+		f.Add(true, v)
+	}
+}
+
+// AddUndCorpus adds seed corpus entries to f for each of values in all states,
+// so that the fuzz target function is of form func(t *testing.T, state uint8, v T)
+// which can be passed to [UndFromFuzz].
+//
+// T must be a type f.Add accepts.
+func AddUndCorpus[T any](f *testing.F, values ...T) {
+	f.Helper()
+	for _, v := range values {
+		for i := range States {
+			f.Add(uint8(i), v)
+		}
+	}
+}
+
+// AddElasticCorpus adds seed corpus entries of JSON inputs that Elastic types accept,
+// i.e. null, a single value, an empty array and arrays mixing null and values,
+// built from values.
+// The fuzz target function is of form func(t *testing.T, data []byte),
+// which is expected to unmarshal data into an Elastic value.
+//
+// AddElasticCorpus panics if any of values can not be marshaled into JSON.
+func AddElasticCorpus[T any](f *testing.F, values ...T) {
+	f.Helper()
+	f.Add([]byte(`null`))
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`[null]`))
+	for _, v := range values {
+		single, err := json.Marshal(v)
+		if err != nil {
+			panic(err)
+		}
+		f.Add(single)
+		f.Add([]byte(`[` + string(single) + `]`))
+		f.Add([]byte(`[null,` + string(single) + `,null,` + string(single) + `]`))
+	}
+}
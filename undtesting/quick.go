@@ -0,0 +1,176 @@
+// Package undtesting provides helpers for property-based tests and fuzz tests over und types.
+//
+// Generators in this package produce values of all states, *defined*, *null* and *undefined*,
+// with controllable probability.
+package undtesting
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing/quick"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/option"
+	"github.com/ngicks/und/sliceund"
+	sliceelastic "github.com/ngicks/und/sliceund/elastic"
+)
+
+var (
+	_ quick.Generator = Option[any]{}
+	_ quick.Generator = Und[any]{}
+	_ quick.Generator = SliceUnd[any]{}
+	_ quick.Generator = Elastic[any]{}
+	_ quick.Generator = SliceElastic[any]{}
+)
+
+// Weights is relative weights of each state.
+// The probability of a state is its weight divided by sum of all weights.
+//
+// For option.Option, both Null and Undefined are weights of None.
+// For elements of Elastic values, Null is the weight of None and Undefined is ignored.
+type Weights struct {
+	Defined   int
+	Null      int
+	Undefined int
+}
+
+// DefaultWeights is used by generator types, e.g. [Und].Generate.
+// Every state appears with equal probability.
+var DefaultWeights = Weights{Defined: 1, Null: 1, Undefined: 1}
+
+// State randomly chooses a state according to w.
+// State panics if any weight is negative or all weights are zero.
+func (w Weights) State(r *rand.Rand) und.State {
+	if w.Defined < 0 || w.Null < 0 || w.Undefined < 0 || w.Defined+w.Null+w.Undefined == 0 {
+		panic(fmt.Sprintf("undtesting: invalid weights %+v", w))
+	}
+	n := r.Intn(w.Defined + w.Null + w.Undefined)
+	switch {
+	case n < w.Defined:
+		return und.StateDefined
+	case n < w.Defined+w.Null:
+		return und.StateNull
+	default:
+		return und.StateUndefined
+	}
+}
+
+// Value generates an arbitrary value of T by [quick.Value].
+// T must be a type quick.Value can generate, or an implementor of [quick.Generator].
+// Value panics otherwise.
+func Value[T any](r *rand.Rand) T {
+	rv, ok := quick.Value(reflect.TypeFor[T](), r)
+	if !ok {
+		panic(fmt.Sprintf("undtesting: can not generate a value of %s", reflect.TypeFor[T]()))
+	}
+	return rv.Interface().(T)
+}
+
+// RandomOption generates an option.Option[T].
+// The option is some with probability of w.Defined out of all weights.
+func RandomOption[T any](r *rand.Rand, w Weights) option.Option[T] {
+	if w.State(r) != und.StateDefined {
+		return option.None[T]()
+	}
+	return option.Some(Value[T](r))
+}
+
+// RandomUnd generates an und.Und[T] whose state is chosen according to w.
+func RandomUnd[T any](r *rand.Rand, w Weights) und.Und[T] {
+	switch w.State(r) {
+	case und.StateDefined:
+		return und.Defined(Value[T](r))
+	case und.StateNull:
+		return und.Null[T]()
+	default:
+		return und.Undefined[T]()
+	}
+}
+
+// RandomSliceUnd generates a sliceund.Und[T] whose state is chosen according to w.
+func RandomSliceUnd[T any](r *rand.Rand, w Weights) sliceund.Und[T] {
+	return sliceund.FromUnd(RandomUnd[T](r, w))
+}
+
+func randomOptions[T any](r *rand.Rand, size int, w Weights) option.Options[T] {
+	// Undefined is meaningless for elements.
+	w.Undefined = 0
+	if w.Defined+w.Null == 0 {
+		w.Null = 1
+	}
+	opts := make(option.Options[T], r.Intn(max(size, 0)+1))
+	for i := range opts {
+		opts[i] = RandomOption[T](r, w)
+	}
+	return opts
+}
+
+// RandomElastic generates an elastic.Elastic[T] whose state is chosen according to w.
+// A defined Elastic[T] has at most size elements, which are None with probability of w.Null out of w.Defined + w.Null.
+func RandomElastic[T any](r *rand.Rand, size int, w Weights) elastic.Elastic[T] {
+	switch w.State(r) {
+	case und.StateDefined:
+		return elastic.FromOptions(randomOptions[T](r, size, w)...)
+	case und.StateNull:
+		return elastic.Null[T]()
+	default:
+		return elastic.Undefined[T]()
+	}
+}
+
+// RandomSliceElastic is like [RandomElastic] but generates a sliceund/elastic.Elastic[T].
+func RandomSliceElastic[T any](r *rand.Rand, size int, w Weights) sliceelastic.Elastic[T] {
+	return sliceelastic.FromElastic(RandomElastic[T](r, size, w))
+}
+
+// Option wraps option.Option[T] to implement [quick.Generator].
+type Option[T any] struct {
+	option.Option[T]
+}
+
+// Generate implements quick.Generator using [DefaultWeights].
+func (Option[T]) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Option[T]{RandomOption[T](r, DefaultWeights)})
+}
+
+// Und wraps und.Und[T] to implement [quick.Generator].
+type Und[T any] struct {
+	und.Und[T]
+}
+
+// Generate implements quick.Generator using [DefaultWeights].
+func (Und[T]) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Und[T]{RandomUnd[T](r, DefaultWeights)})
+}
+
+// SliceUnd wraps sliceund.Und[T] to implement [quick.Generator].
+type SliceUnd[T any] struct {
+	sliceund.Und[T]
+}
+
+// Generate implements quick.Generator using [DefaultWeights].
+func (SliceUnd[T]) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(SliceUnd[T]{RandomSliceUnd[T](r, DefaultWeights)})
+}
+
+// Elastic wraps elastic.Elastic[T] to implement [quick.Generator].
+type Elastic[T any] struct {
+	elastic.Elastic[T]
+}
+
+// Generate implements quick.Generator using [DefaultWeights].
+func (Elastic[T]) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Elastic[T]{RandomElastic[T](r, size, DefaultWeights)})
+}
+
+// SliceElastic wraps sliceund/elastic.Elastic[T] to implement [quick.Generator].
+type SliceElastic[T any] struct {
+	sliceelastic.Elastic[T]
+}
+
+// Generate implements quick.Generator using [DefaultWeights].
+func (SliceElastic[T]) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(SliceElastic[T]{RandomSliceElastic[T](r, size, DefaultWeights)})
+}
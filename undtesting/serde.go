@@ -0,0 +1,219 @@
+package undtesting
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/option"
+)
+
+// SerdeCase is a row of the JSON serialization matrix enumerated by [UndSerdeCases] and [ElasticSerdeCases].
+//
+// Input and Marshaled are JSON objects of form {"pad1":1,"v":<value>,"pad2":2},
+// where "v" is omitted for undefined values.
+// Each row of the matrix is also enumerated with "pad1" or "pad2" omitted,
+// so that "v" is tested as the first, the middle and the last member.
+type SerdeCase[T any] struct {
+	// Name describes the case.
+	Name string
+	// Input is the JSON input to be unmarshaled.
+	Input string
+	// State is the expected state after unmarshaling Input.
+	State und.State
+	// Values are expected values after unmarshaling Input.
+	// It is nil for null and undefined, and has exactly 1 element for defined Und types.
+	Values []option.Option[T]
+	// Marshaled is the expected output of marshaling the unmarshaled value.
+	Marshaled string
+}
+
+// SerdeTarget is the struct type which [SerdeCase] Input and Marshaled are encoded from / decoded into.
+//
+// The field V is tagged with both omitempty and omitzero
+// so that undefined values of all variants of und types are omitted (omitzero needs Go 1.24 or later).
+// Pad1 and Pad2 are omitted when those are zero.
+type SerdeTarget[U any] struct {
+	Pad1 int `json:"pad1,omitempty"`
+	V    U   `json:"v,omitempty,omitzero"`
+	Pad2 int `json:"pad2,omitempty"`
+}
+
+// serdePositions places "v" in the middle, first and last of serde objects.
+var serdePositions = []struct {
+	name       string
+	pad1, pad2 bool
+}{
+	{"middle", true, true},
+	{"first", false, true},
+	{"last", true, false},
+}
+
+func serdeObject(v string, pad1, pad2 bool) string {
+	var members []string
+	if pad1 {
+		members = append(members, `"pad1":1`)
+	}
+	if v != "" {
+		members = append(members, `"v":`+v)
+	}
+	if pad2 {
+		members = append(members, `"pad2":2`)
+	}
+	return "{" + strings.Join(members, ",") + "}"
+}
+
+// expandSerdeRows turns rows, whose Input and Marshaled are bare values ("" for undefined),
+// into cases for each of serdePositions.
+func expandSerdeRows[T any](rows []SerdeCase[T]) []SerdeCase[T] {
+	cases := make([]SerdeCase[T], 0, len(rows)*len(serdePositions))
+	for _, row := range rows {
+		for _, pos := range serdePositions {
+			tc := row
+			tc.Name = row.Name + "/" + pos.name
+			tc.Input = serdeObject(row.Input, pos.pad1, pos.pad2)
+			tc.Marshaled = serdeObject(row.Marshaled, pos.pad1, pos.pad2)
+			cases = append(cases, tc)
+		}
+	}
+	return cases
+}
+
+// UndSerdeCases enumerates the matrix of JSON inputs, expected states and expected outputs
+// for Und types, e.g. und.Und[T] and sliceund.Und[T], using value as the defined value.
+//
+// It returns an error only if value can not be marshaled into JSON.
+func UndSerdeCases[T any](value T) ([]SerdeCase[T], error) {
+	bin, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	v := string(bin)
+	return expandSerdeRows([]SerdeCase[T]{
+		{Name: "undefined", State: und.StateUndefined},
+		{Name: "null", Input: "null", State: und.StateNull, Marshaled: "null"},
+		{Name: "defined", Input: v, State: und.StateDefined, Values: []option.Option[T]{option.Some(value)}, Marshaled: v},
+	}), nil
+}
+
+// ElasticSerdeCases enumerates the matrix of JSON inputs, expected states and expected outputs
+// for Elastic types, e.g. elastic.Elastic[T] and sliceund/elastic.Elastic[T], using value as the defined value.
+//
+// It returns an error only if value can not be marshaled into JSON.
+func ElasticSerdeCases[T any](value T) ([]SerdeCase[T], error) {
+	bin, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		v    = string(bin)
+		some = option.Some(value)
+		none = option.None[T]()
+	)
+	return expandSerdeRows([]SerdeCase[T]{
+		{Name: "undefined", State: und.StateUndefined},
+		{Name: "null", Input: "null", State: und.StateNull, Marshaled: "null"},
+		{Name: "single", Input: v, State: und.StateDefined, Values: []option.Option[T]{some}, Marshaled: "[" + v + "]"},
+		{Name: "empty", Input: "[]", State: und.StateDefined, Values: []option.Option[T]{}, Marshaled: "[]"},
+		{Name: "single_null", Input: "[null]", State: und.StateDefined, Values: []option.Option[T]{none}, Marshaled: "[null]"},
+		{Name: "array", Input: "[" + v + "]", State: und.StateDefined, Values: []option.Option[T]{some}, Marshaled: "[" + v + "]"},
+		{
+			Name:      "mixed",
+			Input:     "[null," + v + ",null," + v + "]",
+			State:     und.StateDefined,
+			Values:    []option.Option[T]{none, some, none, some},
+			Marshaled: "[null," + v + ",null," + v + "]",
+		},
+	}), nil
+}
+
+// UndType is the set of methods AssertUndSerde uses to inspect Und types.
+type UndType[T any] interface {
+	State() und.State
+	Unwrap() option.Option[option.Option[T]]
+}
+
+// ElasticType is the set of methods AssertElasticSerde uses to inspect Elastic types.
+type ElasticType[T any] interface {
+	State() und.State
+	Pointers() []*T
+}
+
+// AssertUndSerde unmarshals each Input of [UndSerdeCases] into [SerdeTarget][U],
+// then asserts its state, its value and the marshaled output.
+// eq tests equality of values.
+//
+// AssertUndSerde lets users verify their custom T types behave correctly with Und types across all states.
+//
+// The undefined case relies on the omitzero option of [SerdeTarget], which needs Go 1.24 or later
+// for types omitempty does not omit, e.g. und.Und[T]; with older Go only sliceund.Und[T] passes.
+func AssertUndSerde[U UndType[T], T any](t *testing.T, value T, eq func(i, j T) bool) {
+	t.Helper()
+	cases, err := UndSerdeCases(value)
+	if err != nil {
+		t.Fatalf("marshaling value: %v", err)
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			target := assertSerde[U](t, tc)
+			var values []option.Option[T]
+			if opt := target.V.Unwrap(); opt.Value().IsSome() {
+				values = []option.Option[T]{opt.Value()}
+			}
+			assertValues(t, tc, values, eq)
+		})
+	}
+}
+
+// AssertElasticSerde is like [AssertUndSerde] but for Elastic types, with [ElasticSerdeCases].
+//
+// As with AssertUndSerde, elastic.Elastic[T] needs Go 1.24 or later, while sliceund/elastic.Elastic[T] does not.
+func AssertElasticSerde[E ElasticType[T], T any](t *testing.T, value T, eq func(i, j T) bool) {
+	t.Helper()
+	cases, err := ElasticSerdeCases(value)
+	if err != nil {
+		t.Fatalf("marshaling value: %v", err)
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Helper()
+			target := assertSerde[E](t, tc)
+			var values []option.Option[T]
+			if ps := target.V.Pointers(); ps != nil {
+				values = make([]option.Option[T], len(ps))
+				for i, p := range ps {
+					values[i] = option.FromPointer(p)
+				}
+			}
+			assertValues(t, tc, values, eq)
+		})
+	}
+}
+
+func assertSerde[U interface{ State() und.State }, T any](t *testing.T, tc SerdeCase[T]) SerdeTarget[U] {
+	t.Helper()
+	var target SerdeTarget[U]
+	if err := json.Unmarshal([]byte(tc.Input), &target); err != nil {
+		t.Fatalf("unmarshaling %s: %v", tc.Input, err)
+	}
+	if s := target.V.State(); s != tc.State {
+		t.Errorf("state: expected %s, but is %s", tc.State, s)
+	}
+	bin, err := json.Marshal(target)
+	if err != nil {
+		t.Fatalf("marshaling: %v", err)
+	}
+	if string(bin) != tc.Marshaled {
+		t.Errorf("marshaled: expected %s, but is %s", tc.Marshaled, bin)
+	}
+	return target
+}
+
+func assertValues[T any](t *testing.T, tc SerdeCase[T], values []option.Option[T], eq func(i, j T) bool) {
+	t.Helper()
+	if !option.Options[T](values).EqualFunc(tc.Values, eq) {
+		t.Errorf("values: expected %v, but is %v", tc.Values, values)
+	}
+}
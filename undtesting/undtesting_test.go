@@ -0,0 +1,94 @@
+package undtesting_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/elastic"
+	"github.com/ngicks/und/undtesting"
+	"gotest.tools/v3/assert"
+)
+
+func TestWeights(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 100 {
+		assert.Assert(t, undtesting.RandomUnd[int](r, undtesting.Weights{Defined: 1}).IsDefined())
+		assert.Assert(t, undtesting.RandomUnd[int](r, undtesting.Weights{Null: 1}).IsNull())
+		assert.Assert(t, undtesting.RandomUnd[int](r, undtesting.Weights{Undefined: 1}).IsUndefined())
+		assert.Assert(t, undtesting.RandomOption[int](r, undtesting.Weights{Null: 3, Undefined: 2}).IsNone())
+		e := undtesting.RandomElastic[int](r, 5, undtesting.Weights{Defined: 1})
+		assert.Assert(t, e.IsDefined() && e.Len() <= 5 && !e.HasNull())
+	}
+
+	seen := map[und.State]bool{}
+	for range 100 {
+		seen[undtesting.RandomSliceUnd[string](r, undtesting.DefaultWeights).State()] = true
+	}
+	assert.Equal(t, len(seen), 3)
+
+	assert.Assert(t, func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		undtesting.Weights{}.State(r)
+		return
+	}())
+}
+
+func TestQuick(t *testing.T) {
+	roundTrip := func(u undtesting.Und[int], e undtesting.Elastic[string]) bool {
+		bin, err := json.Marshal(u)
+		if err != nil {
+			return false
+		}
+		var decoded und.Und[int]
+		if err := json.Unmarshal(bin, &decoded); err != nil {
+			return false
+		}
+		if u.IsDefined() != decoded.IsDefined() || u.Value() != decoded.Value() {
+			return false
+		}
+
+		bin, err = json.Marshal(e)
+		if err != nil {
+			return false
+		}
+		var decodedE elastic.Elastic[string]
+		if err := json.Unmarshal(bin, &decodedE); err != nil {
+			return false
+		}
+		return !e.IsDefined() || elastic.Equal(e.Elastic, decodedE)
+	}
+	assert.NilError(t, quick.Check(roundTrip, nil))
+}
+
+func FuzzUnd(f *testing.F) {
+	undtesting.AddUndCorpus(f, "", "foo")
+	f.Fuzz(func(t *testing.T, state uint8, v string) {
+		u := undtesting.UndFromFuzz(state, v)
+		assert.Equal(t, u.State(), undtesting.States[state%3])
+		if u.IsDefined() {
+			assert.Equal(t, u.Value(), v)
+		}
+	})
+}
+
+func FuzzOption(f *testing.F) {
+	undtesting.AddOptionCorpus(f, 0, 15)
+	f.Fuzz(func(t *testing.T, some bool, v int) {
+		o := undtesting.OptionFromFuzz(some, v)
+		assert.Equal(t, o.IsSome(), some)
+	})
+}
+
+func FuzzElastic(f *testing.F) {
+	undtesting.AddElasticCorpus(f, 1, 20)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var e elastic.Elastic[int]
+		if err := json.Unmarshal(data, &e); err != nil {
+			return
+		}
+		assert.Assert(t, !e.IsUndefined())
+	})
+}
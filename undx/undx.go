@@ -0,0 +1,111 @@
+// Package undx defines pre-instantiated aliases of und.Und for common value types,
+// along with constructors and time types with alternative JSON encodings.
+//
+// Aliases are identical to their generic instantiations, e.g. UndString is und.Und[string],
+// so values can be passed to and from functions of und package as they are.
+package undx
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/ngicks/und"
+)
+
+type (
+	UndString  = und.Und[string]
+	UndInt     = und.Und[int]
+	UndInt64   = und.Und[int64]
+	UndFloat64 = und.Und[float64]
+	UndBool    = und.Und[bool]
+	// UndTime is encoded as RFC 3339 string, which is how time.Time is encoded into JSON.
+	UndTime = und.Und[time.Time]
+	// UndUnixMilli is encoded as a number of milliseconds elapsed since the Unix epoch.
+	UndUnixMilli = und.Und[UnixMilli]
+	// UndUnix is encoded as a number of seconds elapsed since the Unix epoch.
+	UndUnix = und.Und[Unix]
+)
+
+// String returns a defined UndString.
+func String(s string) UndString { return und.Defined(s) }
+
+// Int returns a defined UndInt.
+func Int(i int) UndInt { return und.Defined(i) }
+
+// Int64 returns a defined UndInt64.
+func Int64(i int64) UndInt64 { return und.Defined(i) }
+
+// Float64 returns a defined UndFloat64.
+func Float64(f float64) UndFloat64 { return und.Defined(f) }
+
+// Bool returns a defined UndBool.
+func Bool(b bool) UndBool { return und.Defined(b) }
+
+// Time returns a defined UndTime.
+func Time(t time.Time) UndTime { return und.Defined(t) }
+
+// TimeUnixMilli returns a defined UndUnixMilli.
+func TimeUnixMilli(t time.Time) UndUnixMilli { return und.Defined(UnixMilli{t}) }
+
+// TimeUnix returns a defined UndUnix.
+func TimeUnix(t time.Time) UndUnix { return und.Defined(Unix{t}) }
+
+var (
+	_ json.Marshaler   = UnixMilli{}
+	_ json.Unmarshaler = (*UnixMilli)(nil)
+	_ json.Marshaler   = Unix{}
+	_ json.Unmarshaler = (*Unix)(nil)
+)
+
+// UnixMilli is time.Time encoded into JSON as a number of milliseconds elapsed since the Unix epoch.
+// Precision finer than a millisecond is lost while encoding.
+type UnixMilli struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t UnixMilli) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, t.UnixMilli(), 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The decoded time is in the local time zone.
+func (t *UnixMilli) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		// as time.Time does, null is no-op.
+		return nil
+	}
+	var ms int64
+	if err := json.Unmarshal(data, &ms); err != nil {
+		return err
+	}
+	t.Time = time.UnixMilli(ms)
+	return nil
+}
+
+// Unix is time.Time encoded into JSON as a number of seconds elapsed since the Unix epoch.
+// Precision finer than a second is lost while encoding.
+type Unix struct {
+	time.Time
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Unix) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, t.Unix(), 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The decoded time is in the local time zone.
+func (t *Unix) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		// as time.Time does, null is no-op.
+		return nil
+	}
+	var sec int64
+	if err := json.Unmarshal(data, &sec); err != nil {
+		return err
+	}
+	t.Time = time.Unix(sec, 0)
+	return nil
+}
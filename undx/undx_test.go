@@ -0,0 +1,59 @@
+package undx_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ngicks/und"
+	"github.com/ngicks/und/undx"
+	"gotest.tools/v3/assert"
+)
+
+type sample struct {
+	S  undx.UndString    `json:"s,omitzero"`
+	I  undx.UndInt       `json:"i,omitzero"`
+	I6 undx.UndInt64     `json:"i6,omitzero"`
+	F  undx.UndFloat64   `json:"f,omitzero"`
+	B  undx.UndBool      `json:"b,omitzero"`
+	T  undx.UndTime      `json:"t,omitzero"`
+	TM undx.UndUnixMilli `json:"tm,omitzero"`
+	TU undx.UndUnix      `json:"tu,omitzero"`
+}
+
+func TestUndx(t *testing.T) {
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	s := sample{
+		S:  undx.String("foo"),
+		I:  undx.Int(1),
+		I6: undx.Int64(2),
+		F:  undx.Float64(1.5),
+		B:  undx.Bool(true),
+		T:  undx.Time(tm),
+		TM: undx.TimeUnixMilli(tm),
+		TU: und.Null[undx.Unix](),
+	}
+	bin, err := json.Marshal(s)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		string(bin),
+		`{"s":"foo","i":1,"i6":2,"f":1.5,"b":true,"t":"2024-01-02T03:04:05.006Z","tm":1704164645006,"tu":null}`,
+	)
+
+	var decoded sample
+	assert.NilError(t, json.Unmarshal(bin, &decoded))
+	assert.Assert(t, und.Equal(decoded.S, s.S))
+	assert.Assert(t, decoded.T.Value().Equal(tm))
+	assert.Assert(t, decoded.TM.Value().Equal(tm))
+	assert.Assert(t, decoded.TU.IsNull())
+
+	assert.NilError(t, json.Unmarshal([]byte(`{"tu":1704164645}`), &decoded))
+	assert.Assert(t, decoded.TU.Value().Equal(tm.Truncate(time.Second)))
+
+	bin, err = json.Marshal(sample{TU: undx.TimeUnix(tm)})
+	assert.NilError(t, err)
+	assert.Equal(t, string(bin), `{"tu":1704164645}`)
+
+	assert.Assert(t, json.Unmarshal([]byte(`{"tm":"foo"}`), &decoded) != nil)
+}
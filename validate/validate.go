@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/ngicks/und/internal/undstate"
 	"github.com/ngicks/und/undtag"
 )
 
@@ -39,7 +40,10 @@ func ReportState(v any) string {
 			return fmt.Sprintf("defined, len=%d, has null=%t", i.Len(), i.HasNull())
 		}
 	}
-	if i, ok := v.(UndLike); ok {
+	if i, ok := v.(UndLike); ok || (v != nil && isUndStater(reflect.TypeOf(v))) {
+		if !ok {
+			i = asUndLike(reflect.ValueOf(v))
+		}
 		switch {
 		case i.IsUndefined():
 			return "undefined"
@@ -167,13 +171,34 @@ var (
 	optionLikeTy   = reflect.TypeFor[undtag.OptionLike]()
 	validatorUndTy = reflect.TypeFor[UndValidator]()
 	checkerUndTy   = reflect.TypeFor[UndChecker]()
+	undStaterTy    = reflect.TypeFor[undstate.UndStater]()
 )
 
+// isUndStater reports whether rt implements und.UndStater.
+func isUndStater(rt reflect.Type) bool {
+	return rt.Kind() != reflect.Interface && rt.Implements(undStaterTy)
+}
+
+// stateUndLike adapts und.State to UndLike.
+type stateUndLike undstate.State
+
+func (s stateUndLike) IsUndefined() bool { return undstate.State(s) == undstate.StateUndefined }
+func (s stateUndLike) IsNull() bool      { return undstate.State(s) == undstate.StateNull }
+func (s stateUndLike) IsDefined() bool   { return undstate.State(s) == undstate.StateDefined }
+
+// asUndLike converts fv, whose type implements UndLike or und.UndStater, to UndLike.
+func asUndLike(fv reflect.Value) UndLike {
+	if u, ok := fv.Interface().(UndLike); ok {
+		return u
+	}
+	return stateUndLike(fv.Interface().(undstate.UndStater).State())
+}
+
 // UndValidate validates whether s is compliant to the constraint placed by `und` struct tag.
 //
 // UndValidate only accepts struct or pointer to struct.
 //
-// Only fields whose struct tag contains `und`, and whose type is implementor of OptionLike, UndLike, ElasticLike,
+// Only fields whose struct tag contains `und`, and whose type is implementor of OptionLike, UndLike, ElasticLike, und.UndStater,
 // or array, slice, map whose value type are one of implementor,
 // are validated.
 func UndValidate(s any) error {
@@ -258,7 +283,7 @@ func makeValidator(rt reflect.Type, visited map[reflect.Type]*cachedValidator) c
 		}
 
 		isElasticLike := ft.Type.Implements(elasticLike)
-		isUndLike := ft.Type.Implements(undLikeTy)
+		isUndLike := ft.Type.Implements(undLikeTy) || isUndStater(ft.Type)
 		isOptLike := ft.Type.Implements(optionLikeTy)
 		if !isElasticLike && !isUndLike && !isOptLike {
 			ftDeref := ft.Type
@@ -293,7 +318,7 @@ func makeValidator(rt reflect.Type, visited map[reflect.Type]*cachedValidator) c
 				case reflect.Array, reflect.Slice, reflect.Map:
 					elem := ftDeref.Elem()
 					isElasticLike := elem.Implements(elasticLike)
-					isUndLike := elem.Implements(undLikeTy)
+					isUndLike := elem.Implements(undLikeTy) || isUndStater(elem)
 					isOptLike := elem.Implements(optionLikeTy)
 					hasTag, validator, err := makeFieldValidator(ft, isOptLike, isUndLike, isElasticLike)
 					if !hasTag {
@@ -386,7 +411,7 @@ func makeFieldValidator(ft reflect.StructField, isOptLike, isUndLike, isElasticL
 		}
 	case isUndLike:
 		validateOpt = func(fv reflect.Value) error {
-			if !opt.ValidUnd(fv.Interface().(UndLike)) {
+			if !opt.ValidUnd(asUndLike(fv)) {
 				return AppendValidationErrorDot(fmt.Errorf("input %s", opt.Describe()), ft.Name)
 			}
 			return nil
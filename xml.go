@@ -0,0 +1,50 @@
+package und
+
+import (
+	"encoding/xml"
+	"slices"
+)
+
+// XMLNullStyle specifies how null is marshaled into XML by [Und.MarshalXMLNull].
+type XMLNullStyle int
+
+const (
+	// XMLNullOmit omits the element for null, as MarshalXML does.
+	XMLNullOmit XMLNullStyle = iota
+	// XMLNullNil writes an empty element with xsi:nil="true" attribute
+	// along with the declaration of xsi namespace.
+	XMLNullNil
+	// XMLNullEmpty writes an empty element.
+	XMLNullEmpty
+)
+
+var (
+	xsiNamespaceAttr = xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: "http://www.w3.org/2001/XMLSchema-instance"}
+	xsiNilAttr       = xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"}
+)
+
+// MarshalXMLNull is like MarshalXML but marshals null according to style.
+// Undefined u is always omitted.
+//
+// Since xml struct tags can not pass options to xml.Marshaler,
+// wrap Und[T] with a type whose MarshalXML calls MarshalXMLNull to use it in structs:
+//
+//	type NillableInt struct{ und.Und[int] }
+//
+//	func (n NillableInt) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+//		return n.MarshalXMLNull(e, start, und.XMLNullNil)
+//	}
+//
+// UnmarshalXML unmarshals elements with xsi:nil="true" attribute as null regardless of style.
+func (u Und[T]) MarshalXMLNull(e *xml.Encoder, start xml.StartElement, style XMLNullStyle) error {
+	if !u.IsNull() || style == XMLNullOmit {
+		return u.MarshalXML(e, start)
+	}
+	if style == XMLNullNil {
+		start.Attr = append(slices.Clip(start.Attr), xsiNamespaceAttr, xsiNilAttr)
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
@@ -0,0 +1,30 @@
+package und
+
+import "github.com/ngicks/und/option"
+
+// MarshalYAML implements the yaml marshaler interface
+// of gopkg.in/yaml.v3 (also gopkg.in/yaml.v2 and github.com/goccy/go-yaml).
+//
+// Both null and undefined are marshaled as null.
+// Undefined fields are skipped if `yaml:",omitempty"` option is attached to those fields,
+// since Und[T] implements IsZero.
+func (u Und[T]) MarshalYAML() (any, error) {
+	return u.opt.Value().MarshalYAML()
+}
+
+// UnmarshalYAML implements the yaml unmarshaler interface
+// of gopkg.in/yaml.v2 (also gopkg.in/yaml.v3 as an obsolete unmarshaler, and github.com/goccy/go-yaml).
+//
+// A missing key leaves u undefined.
+// Be cautious that gopkg.in/yaml.v2 and gopkg.in/yaml.v3 do not call UnmarshalYAML for null;
+// the field is left untouched and stays undefined.
+// Thus with those decoders an explicit null can not be told apart from a missing key.
+func (u *Und[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var opt option.Option[T]
+	err := opt.UnmarshalYAML(unmarshal)
+	if err != nil {
+		return err
+	}
+	*u = FromOption(option.Some(opt))
+	return nil
+}